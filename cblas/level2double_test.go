@@ -17,3 +17,15 @@ func TestDger(t *testing.T) {
 func TestDtxmv(t *testing.T) {
 	testblas.DtxmvTest(t, blasser)
 }
+
+func TestDspmv(t *testing.T) {
+	testblas.DspmvTest(t, blasser)
+}
+
+func TestDtrsv(t *testing.T) {
+	testblas.DtrsvTest(t, blasser)
+}
+
+func TestDtxsv(t *testing.T) {
+	testblas.DtxsvTest(t, blasser)
+}
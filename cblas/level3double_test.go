@@ -0,0 +1,15 @@
+package cblas
+
+import (
+	"testing"
+
+	"github.com/gonum/blas/testblas"
+)
+
+func TestDgemm(t *testing.T) {
+	testblas.TestDgemm(t, blasser)
+}
+
+func TestDsyrk(t *testing.T) {
+	testblas.DsyrkTest(t, blasser)
+}
@@ -0,0 +1,169 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbw
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/gonum/blas"
+)
+
+// The functions in this file check the arguments of the corresponding
+// wrappers without calling the implementation. Each returns nil if the
+// wrapper would accept its arguments and otherwise an error describing the
+// first problem found. The wrappers panic with the same error, so callers
+// that validate untrusted input may check first instead of recovering from
+// a panic. The validity of a single matrix or vector is reported by its
+// Check method.
+
+// CheckGemv checks the arguments of Gemv.
+func CheckGemv(tA blas.Transpose, A General, x, y Vector) error {
+	if err := A.Check(); err != nil {
+		return err
+	}
+	return checkMV(tA, A.Rows, A.Cols, x, y)
+}
+
+// CheckGbmv checks the arguments of Gbmv.
+func CheckGbmv(tA blas.Transpose, A GeneralBand, x, y Vector) error {
+	if err := A.Check(); err != nil {
+		return err
+	}
+	return checkMV(tA, A.Rows, A.Cols, x, y)
+}
+
+// checkMV checks the vectors of a matrix-vector product y = op(A)*x with an
+// m×n matrix A.
+func checkMV(tA blas.Transpose, m, n int, x, y Vector) error {
+	if err := x.Check(); err != nil {
+		return err
+	}
+	if err := y.Check(); err != nil {
+		return err
+	}
+	switch tA {
+	case blas.NoTrans:
+	case blas.Trans:
+		m, n = n, m
+	default:
+		return errors.New("blas: illegal value for tA")
+	}
+	if x.N != n {
+		return fmt.Errorf("blas: dimension mismatch: x.N = %d, want %d", x.N, n)
+	}
+	if y.N != m {
+		return fmt.Errorf("blas: dimension mismatch: y.N = %d, want %d", y.N, m)
+	}
+	return nil
+}
+
+// CheckGer checks the arguments of Ger.
+func CheckGer(x, y Vector, A General) error {
+	if err := x.Check(); err != nil {
+		return err
+	}
+	if err := y.Check(); err != nil {
+		return err
+	}
+	if err := A.Check(); err != nil {
+		return err
+	}
+	if x.N != A.Rows {
+		return fmt.Errorf("blas: dimension mismatch: x.N = %d, want A.Rows = %d", x.N, A.Rows)
+	}
+	if y.N != A.Cols {
+		return fmt.Errorf("blas: dimension mismatch: y.N = %d, want A.Cols = %d", y.N, A.Cols)
+	}
+	return nil
+}
+
+// CheckGemm checks the arguments of Gemm.
+func CheckGemm(tA, tB blas.Transpose, A, B, C General) error {
+	if err := A.Check(); err != nil {
+		return err
+	}
+	if err := B.Check(); err != nil {
+		return err
+	}
+	if err := C.Check(); err != nil {
+		return err
+	}
+	m, k, err := opDims(tA, "tA", A)
+	if err != nil {
+		return err
+	}
+	kb, n, err := opDims(tB, "tB", B)
+	if err != nil {
+		return err
+	}
+	if k != kb {
+		return fmt.Errorf("blas: dimension mismatch: op(A) is %d×%d but op(B) is %d×%d", m, k, kb, n)
+	}
+	if m != C.Rows || n != C.Cols {
+		return fmt.Errorf("blas: dimension mismatch: op(A)*op(B) is %d×%d but C is %d×%d", m, n, C.Rows, C.Cols)
+	}
+	return nil
+}
+
+// opDims returns the dimensions of op(A) for the transpose flag t named name.
+func opDims(t blas.Transpose, name string, A General) (r, c int, err error) {
+	switch t {
+	case blas.NoTrans:
+		return A.Rows, A.Cols, nil
+	case blas.Trans:
+		return A.Cols, A.Rows, nil
+	}
+	return 0, 0, fmt.Errorf("blas: illegal value for %s", name)
+}
+
+// Try calls f, which is expected to call one or more of the wrappers, and
+// returns as an error any panic that signals invalid arguments to a BLAS
+// routine. The wrappers panic with an error, and implementations usually
+// with a string, whose messages begin with "blas:" or the name of the
+// implementation, as in "goblas:" and "referenceblas:". A panic with an
+// error or string with one of those prefixes is returned as an error, the
+// string being wrapped by errors.New. Any other panic, including a runtime
+// error such as an index out of range and a panic from user code in f, is
+// propagated unchanged. Try returns nil if f returns normally.
+//
+// Since the panic is recovered, f may have partially completed.
+func Try(f func()) (err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		switch v := r.(type) {
+		case runtime.Error:
+		case error:
+			if isBlasMessage(v.Error()) {
+				err = v
+				return
+			}
+		case string:
+			if isBlasMessage(v) {
+				err = errors.New(v)
+				return
+			}
+		}
+		panic(r)
+	}()
+	f()
+	return nil
+}
+
+// isBlasMessage returns whether msg is the message of a panic raised by the
+// wrappers or by a BLAS implementation.
+func isBlasMessage(msg string) bool {
+	for _, prefix := range []string{"blas:", "goblas:", "referenceblas:"} {
+		if strings.HasPrefix(msg, prefix) {
+			return true
+		}
+	}
+	return false
+}
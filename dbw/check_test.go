@@ -0,0 +1,171 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbw
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/goblas"
+)
+
+func TestCheckGemv(t *testing.T) {
+	A := NewGeneral(3, 4, nil)
+	for i, test := range []struct {
+		tA    blas.Transpose
+		A     General
+		x, y  Vector
+		valid bool
+	}{
+		{blas.NoTrans, A, NewVector(make([]float64, 4)), NewVector(make([]float64, 3)), true},
+		{blas.Trans, A, NewVector(make([]float64, 3)), NewVector(make([]float64, 4)), true},
+		{blas.NoTrans, A, NewVector(make([]float64, 3)), NewVector(make([]float64, 3)), false},
+		{blas.Trans, A, NewVector(make([]float64, 4)), NewVector(make([]float64, 3)), false},
+		{blas.ConjTrans, A, NewVector(make([]float64, 4)), NewVector(make([]float64, 3)), false},
+		{blas.NoTrans, General{3, 4, 2, make([]float64, 12)}, NewVector(make([]float64, 4)), NewVector(make([]float64, 3)), false},
+		{blas.NoTrans, A, Vector{make([]float64, 4), 4, 0}, NewVector(make([]float64, 3)), false},
+	} {
+		err := CheckGemv(test.tA, test.A, test.x, test.y)
+		if (err == nil) != test.valid {
+			t.Errorf("Case %v: unexpected error %v", i, err)
+		}
+		p := panics(func() { Gemv(test.tA, 1, test.A, test.x, 0, test.y) })
+		if p == test.valid {
+			t.Errorf("Case %v: Gemv panic = %v, CheckGemv error = %v", i, p, err)
+		}
+	}
+}
+
+func TestCheckGer(t *testing.T) {
+	A := NewGeneral(3, 4, nil)
+	x3 := NewVector(make([]float64, 3))
+	x4 := NewVector(make([]float64, 4))
+	for i, test := range []struct {
+		x, y  Vector
+		A     General
+		valid bool
+	}{
+		{x3, x4, A, true},
+		{x4, x4, A, false},
+		{x3, x3, A, false},
+		{x3, x4, General{3, 4, 4, make([]float64, 11)}, false},
+	} {
+		err := CheckGer(test.x, test.y, test.A)
+		if (err == nil) != test.valid {
+			t.Errorf("Case %v: unexpected error %v", i, err)
+		}
+		p := panics(func() { Ger(1, test.x, test.y, test.A) })
+		if p == test.valid {
+			t.Errorf("Case %v: Ger panic = %v, CheckGer error = %v", i, p, err)
+		}
+	}
+}
+
+func TestCheckGemm(t *testing.T) {
+	for i, test := range []struct {
+		tA, tB  blas.Transpose
+		A, B, C General
+		valid   bool
+	}{
+		{blas.NoTrans, blas.NoTrans, NewGeneral(2, 3, nil), NewGeneral(3, 4, nil), NewGeneral(2, 4, nil), true},
+		{blas.Trans, blas.NoTrans, NewGeneral(3, 2, nil), NewGeneral(3, 4, nil), NewGeneral(2, 4, nil), true},
+		{blas.NoTrans, blas.Trans, NewGeneral(2, 3, nil), NewGeneral(4, 3, nil), NewGeneral(2, 4, nil), true},
+		{blas.NoTrans, blas.NoTrans, NewGeneral(2, 3, nil), NewGeneral(4, 3, nil), NewGeneral(2, 4, nil), false},
+		{blas.NoTrans, blas.NoTrans, NewGeneral(2, 3, nil), NewGeneral(3, 4, nil), NewGeneral(4, 2, nil), false},
+		{'X', blas.NoTrans, NewGeneral(2, 3, nil), NewGeneral(3, 4, nil), NewGeneral(2, 4, nil), false},
+		{blas.NoTrans, 'X', NewGeneral(2, 3, nil), NewGeneral(3, 4, nil), NewGeneral(2, 4, nil), false},
+		{blas.NoTrans, blas.NoTrans, NewGeneral(2, 3, nil), NewGeneral(3, 4, nil), General{2, 4, 4, make([]float64, 7)}, false},
+	} {
+		err := CheckGemm(test.tA, test.tB, test.A, test.B, test.C)
+		if (err == nil) != test.valid {
+			t.Errorf("Case %v: unexpected error %v", i, err)
+		}
+		p := panics(func() { Gemm(test.tA, test.tB, 1, test.A, test.B, 0, test.C) })
+		if p == test.valid {
+			t.Errorf("Case %v: Gemm panic = %v, CheckGemm error = %v", i, p, err)
+		}
+	}
+}
+
+func TestTry(t *testing.T) {
+	A := NewGeneral(2, 3, nil)
+	x := NewVector(make([]float64, 3))
+	y := NewVector(make([]float64, 2))
+	if err := Try(func() { Gemv(blas.NoTrans, 1, A, x, 0, y) }); err != nil {
+		t.Errorf("valid Gemv: unexpected error %v", err)
+	}
+
+	// A wrapper's own check panics with an error.
+	err := Try(func() { Gemv(blas.NoTrans, 1, A, y, 0, x) })
+	if want := CheckGemv(blas.NoTrans, A, y, x); err == nil || err.Error() != want.Error() {
+		t.Errorf("dimension mismatch: got error %v, want %v", err, want)
+	}
+
+	// A panic with a string, as from an implementation, is returned too.
+	err = Try(func() { panic("blas: some problem") })
+	if err == nil || err.Error() != "blas: some problem" {
+		t.Errorf("string panic: got error %v", err)
+	}
+
+	// So is a panic from the goblas implementation.
+	err = Try(func() { panic("referenceblas: illegal diag") })
+	if err == nil || err.Error() != "referenceblas: illegal diag" {
+		t.Errorf("goblas panic: got error %v", err)
+	}
+
+	// The argument checks of goblas itself are recognized, whether they
+	// panic with an error, as for a short matrix, or with a string, as for
+	// a bad leading dimension.
+	for _, test := range []struct {
+		name string
+		f    func()
+		want string
+	}{
+		{
+			name: "Dsyrk",
+			f: func() {
+				goblas.Blas{}.Dsyrk(blas.Upper, blas.NoTrans, 2, 2, 1, make([]float64, 3), 2, 0, make([]float64, 4), 2)
+			},
+			want: "goblas: general: insufficient length",
+		},
+		{
+			name: "Dtrmv",
+			f: func() {
+				goblas.Blas{}.Dtrmv(blas.Upper, blas.NoTrans, blas.NonUnit, 2, make([]float64, 4), 1, make([]float64, 2), 1)
+			},
+			want: "goblas: lda must be greater than max(1,n)",
+		},
+	} {
+		err := Try(test.f)
+		if err == nil || err.Error() != test.want {
+			t.Errorf("%v: got error %v, want %v", test.name, err, test.want)
+		}
+	}
+
+	// Other panics, including those of user code in f, propagate unchanged.
+	for i, v := range []interface{}{
+		"oops",
+		errors.New("oops"),
+		42,
+	} {
+		var got interface{}
+		func() {
+			defer func() { got = recover() }()
+			Try(func() { panic(v) })
+		}()
+		if got != v {
+			t.Errorf("Case %v: got panic %v, want %v to propagate", i, got, v)
+		}
+	}
+	if !panics(func() {
+		Try(func() {
+			var s []float64
+			_ = s[1]
+		})
+	}) {
+		t.Errorf("expected runtime error to propagate")
+	}
+}
@@ -1,3 +1,8 @@
+// Package dbw provides typed wrappers around a registered implementation of the
+// double precision real BLAS routines.
+//
+// All matrix types are row-major: element (i, j) of a General is stored at
+// Data[i*Stride+j]. There is no column-major variant.
 package dbw
 
 import "github.com/gonum/blas"
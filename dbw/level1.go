@@ -3,6 +3,8 @@ package dbw
 import "github.com/gonum/blas"
 
 func Dot(x, y Vector) float64 {
+	must(x.Check())
+	must(y.Check())
 	if x.N != y.N {
 		panic("blas: dimension mismatch")
 	}
@@ -10,18 +12,23 @@ func Dot(x, y Vector) float64 {
 }
 
 func Nrm2(x Vector) float64 {
+	must(x.Check())
 	return impl.Dnrm2(x.N, x.Data, x.Inc)
 }
 
 func Asum(x Vector) float64 {
+	must(x.Check())
 	return impl.Dasum(x.N, x.Data, x.Inc)
 }
 
 func Iamax(x Vector) int {
+	must(x.Check())
 	return impl.Idamax(x.N, x.Data, x.Inc)
 }
 
 func Swap(x, y Vector) {
+	must(x.Check())
+	must(y.Check())
 	if x.N != y.N {
 		panic("blas: dimension mismatch")
 	}
@@ -29,6 +36,8 @@ func Swap(x, y Vector) {
 }
 
 func Copy(x, y Vector) {
+	must(x.Check())
+	must(y.Check())
 	if x.N != y.N {
 		panic("blas: dimension mismatch")
 	}
@@ -36,6 +45,8 @@ func Copy(x, y Vector) {
 }
 
 func Axpy(alpha float64, x, y Vector) {
+	must(x.Check())
+	must(y.Check())
 	if x.N != y.N {
 		panic("blas: dimension mismatch")
 	}
@@ -51,6 +62,8 @@ func Rotmg(d1, d2, b1, b2 float64) (p blas.DrotmParams, rd1, rd2, rb1 float64) {
 }
 
 func Rot(x, y Vector, c, s float64) {
+	must(x.Check())
+	must(y.Check())
 	if x.N != y.N {
 		panic("blas: dimension mismatch")
 	}
@@ -58,6 +71,8 @@ func Rot(x, y Vector, c, s float64) {
 }
 
 func Rotm(x, y Vector, p blas.DrotmParams) {
+	must(x.Check())
+	must(y.Check())
 	if x.N != y.N {
 		panic("blas: dimension mismatch")
 	}
@@ -65,5 +80,6 @@ func Rotm(x, y Vector, p blas.DrotmParams) {
 }
 
 func Scal(alpha float64, x Vector) {
+	must(x.Check())
 	impl.Dscal(x.N, alpha, x.Data, x.Inc)
 }
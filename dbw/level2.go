@@ -3,37 +3,19 @@ package dbw
 import "github.com/gonum/blas"
 
 func Gemv(tA blas.Transpose, alpha float64, A General, x Vector, beta float64, y Vector) {
-	if tA == blas.NoTrans {
-		if x.N != A.Cols || y.N != A.Rows {
-			panic("blas: dimension mismatch")
-		}
-	} else if tA == blas.Trans {
-		if x.N != A.Rows || y.N != A.Cols {
-			panic("blas: dimension mismatch")
-		}
-	} else {
-		panic("blas: illegal value for tA")
-	}
+	must(CheckGemv(tA, A, x, y))
 	impl.Dgemv(tA, A.Rows, A.Cols, alpha, A.Data, A.Stride, x.Data, x.Inc, beta, y.Data, y.Inc)
 }
 
 func Gbmv(tA blas.Transpose, alpha float64, A GeneralBand, x Vector, beta float64, y Vector) {
-	if tA == blas.NoTrans {
-		if x.N != A.Cols || y.N != A.Rows {
-			panic("blas: dimension mismatch")
-		}
-	} else if tA == blas.Trans {
-		if x.N != A.Rows || y.N != A.Cols {
-			panic("blas: dimension mismatch")
-		}
-	} else {
-		panic("blas: illegal value for tA")
-	}
+	must(CheckGbmv(tA, A, x, y))
 	impl.Dgbmv(tA, A.Rows, A.Cols, A.KL, A.KU, alpha, A.Data,
 		A.Stride, x.Data, x.Inc, beta, y.Data, y.Inc)
 }
 
 func Trmv(tA blas.Transpose, A Triangular, x Vector) {
+	must(x.Check())
+	must(A.Check())
 	if x.N != A.N {
 		panic("blas: dimension mismatch")
 	}
@@ -41,6 +23,8 @@ func Trmv(tA blas.Transpose, A Triangular, x Vector) {
 }
 
 func Tbmv(tA blas.Transpose, A TriangularBand, x Vector) {
+	must(x.Check())
+	must(A.Check())
 	if x.N != A.N {
 		panic("blas: dimension mismatch")
 	}
@@ -48,6 +32,8 @@ func Tbmv(tA blas.Transpose, A TriangularBand, x Vector) {
 }
 
 func Tpmv(tA blas.Transpose, A TriangularPacked, x Vector) {
+	must(x.Check())
+	must(A.Check())
 	if x.N != A.N {
 		panic("blas: dimension mismatch")
 	}
@@ -55,6 +41,8 @@ func Tpmv(tA blas.Transpose, A TriangularPacked, x Vector) {
 }
 
 func Trsv(tA blas.Transpose, A Triangular, x Vector) {
+	must(x.Check())
+	must(A.Check())
 	if x.N != A.N {
 		panic("blas: dimension mismatch")
 	}
@@ -62,6 +50,8 @@ func Trsv(tA blas.Transpose, A Triangular, x Vector) {
 }
 
 func Tbsv(tA blas.Transpose, A TriangularBand, x Vector) {
+	must(x.Check())
+	must(A.Check())
 	if x.N != A.N {
 		panic("blas: dimension mismatch")
 	}
@@ -69,6 +59,8 @@ func Tbsv(tA blas.Transpose, A TriangularBand, x Vector) {
 }
 
 func Tpsv(tA blas.Transpose, A TriangularPacked, x Vector) {
+	must(x.Check())
+	must(A.Check())
 	if x.N != A.N {
 		panic("blas: dimension mismatch")
 	}
@@ -76,6 +68,9 @@ func Tpsv(tA blas.Transpose, A TriangularPacked, x Vector) {
 }
 
 func Symv(alpha float64, A Symmetric, x Vector, beta float64, y Vector) {
+	must(A.Check())
+	must(x.Check())
+	must(y.Check())
 	if x.N != A.N || y.N != A.N {
 		panic("blas: dimension mismatch")
 	}
@@ -83,6 +78,9 @@ func Symv(alpha float64, A Symmetric, x Vector, beta float64, y Vector) {
 }
 
 func Sbmv(alpha float64, A SymmetricBand, x Vector, beta float64, y Vector) {
+	must(x.Check())
+	must(y.Check())
+	must(A.Check())
 	if x.N != A.N || y.N != A.N {
 		panic("blas: dimension mismatch")
 	}
@@ -91,6 +89,9 @@ func Sbmv(alpha float64, A SymmetricBand, x Vector, beta float64, y Vector) {
 }
 
 func Spmv(alpha float64, A SymmetricPacked, x Vector, beta float64, y Vector) {
+	must(A.Check())
+	must(x.Check())
+	must(y.Check())
 	if x.N != A.N || y.N != A.N {
 		panic("blas: dimension mismatch")
 	}
@@ -98,16 +99,13 @@ func Spmv(alpha float64, A SymmetricPacked, x Vector, beta float64, y Vector) {
 }
 
 func Ger(alpha float64, x Vector, y Vector, A General) {
-	if x.N != A.Rows {
-		panic("blas: dimension mismatch")
-	}
-	if y.N != A.Cols {
-		panic("blas: dimension mismatch")
-	}
+	must(CheckGer(x, y, A))
 	impl.Dger(A.Rows, A.Cols, alpha, x.Data, x.Inc, y.Data, y.Inc, A.Data, A.Stride)
 }
 
 func Syr(alpha float64, x Vector, A Symmetric) {
+	must(A.Check())
+	must(x.Check())
 	if x.N != A.N {
 		panic("blas: dimension mismatch")
 	}
@@ -115,6 +113,8 @@ func Syr(alpha float64, x Vector, A Symmetric) {
 }
 
 func Spr(alpha float64, x Vector, A SymmetricPacked) {
+	must(A.Check())
+	must(x.Check())
 	if x.N != A.N {
 		panic("blas: dimension mismatch")
 	}
@@ -122,6 +122,9 @@ func Spr(alpha float64, x Vector, A SymmetricPacked) {
 }
 
 func Syr2(alpha float64, x Vector, y Vector, A Symmetric) {
+	must(A.Check())
+	must(x.Check())
+	must(y.Check())
 	if x.N != A.N || y.N != A.N {
 		panic("blas: dimension mismatch")
 	}
@@ -129,6 +132,9 @@ func Syr2(alpha float64, x Vector, y Vector, A Symmetric) {
 }
 
 func Spr2(alpha float64, x Vector, y Vector, A SymmetricPacked) {
+	must(A.Check())
+	must(x.Check())
+	must(y.Check())
 	if x.N != A.N || y.N != A.N {
 		panic("blas: dimension mismatch")
 	}
@@ -2,35 +2,26 @@ package dbw
 
 import "github.com/gonum/blas"
 
+// Gemm computes C := alpha * op(A) * op(B) + beta * C, where op(X) is X or X^T
+// according to the corresponding transpose flag. C.Data is updated in place
+// and Gemm itself performs no allocation, so it may be called repeatedly with
+// the same C in a hot loop. Any allocation is made by the registered
+// implementation; goblas does not allocate when it computes serially.
 func Gemm(tA, tB blas.Transpose, alpha float64, A, B General, beta float64, C General) {
-	var m, n, k int
-	if tA == blas.NoTrans {
-		m, k = A.Rows, A.Cols
-	} else {
-		m, k = A.Cols, A.Rows
-	}
-	if tB == blas.NoTrans {
-		n = B.Cols
-		if k != B.Rows {
-			panic("blas: dimension mismatch")
-		}
-	} else {
-		n = B.Rows
-		if k != B.Cols {
-			panic("blas: dimension mismatch")
-		}
-	}
-	if m != C.Rows {
-		panic("blas: dimension mismatch")
-	}
-	if n != C.Cols {
-		panic("blas: dimension mismatch")
+	must(CheckGemm(tA, tB, A, B, C))
+	m, n := C.Rows, C.Cols
+	k := A.Cols
+	if tA != blas.NoTrans {
+		k = A.Rows
 	}
 	impl.Dgemm(tA, tB, m, n, k, alpha, A.Data, A.Stride,
 		B.Data, B.Stride, beta, C.Data, C.Stride)
 }
 
 func Symm(s blas.Side, alpha float64, A Symmetric, B General, beta float64, C General) {
+	must(A.Check())
+	must(B.Check())
+	must(C.Check())
 	var m, n int
 	if s == blas.Left {
 		m = A.N
@@ -56,6 +47,8 @@ func Symm(s blas.Side, alpha float64, A Symmetric, B General, beta float64, C Ge
 }
 
 func Syrk(t blas.Transpose, alpha float64, A General, beta float64, C Symmetric) {
+	must(A.Check())
+	must(C.Check())
 	var n, k int
 	if t == blas.NoTrans {
 		n, k = A.Rows, A.Cols
@@ -70,6 +63,9 @@ func Syrk(t blas.Transpose, alpha float64, A General, beta float64, C Symmetric)
 }
 
 func Syr2k(t blas.Transpose, alpha float64, A, B General, beta float64, C Symmetric) {
+	must(A.Check())
+	must(B.Check())
+	must(C.Check())
 	var n, k int
 	if t == blas.NoTrans {
 		n, k = A.Rows, A.Cols
@@ -90,6 +86,8 @@ func Syr2k(t blas.Transpose, alpha float64, A, B General, beta float64, C Symmet
 }
 
 func Trmm(s blas.Side, tA blas.Transpose, alpha float64, A Triangular, B General) {
+	must(A.Check())
+	must(B.Check())
 	if s == blas.Left {
 		if A.N != B.Rows {
 			panic("blas: dimension mismatch")
@@ -104,6 +102,8 @@ func Trmm(s blas.Side, tA blas.Transpose, alpha float64, A Triangular, B General
 }
 
 func Trsm(s blas.Side, tA blas.Transpose, alpha float64, A Triangular, B General) {
+	must(A.Check())
+	must(B.Check())
 	if s == blas.Left {
 		if A.N != B.Rows {
 			panic("blas: dimension mismatch")
@@ -0,0 +1,90 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbw
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/goblas"
+)
+
+func init() {
+	Register(goblas.Blas{})
+}
+
+func randGeneral(m, n int) General {
+	a := NewGeneral(m, n, nil)
+	for i := range a.Data {
+		a.Data[i] = rand.Float64()
+	}
+	return a
+}
+
+// gemmAllocsSize is the size of the matrices in TestGemmNoAllocs and
+// BenchmarkGemm. With more than one worker, goblas computes a Dgemm of this
+// size concurrently in blocks; with a single worker it computes it serially.
+const gemmAllocsSize = 32
+
+// TestGemmNoAllocs checks that Gemm does not allocate on the serial path. The
+// worker count is pinned to one rather than taken from GOMAXPROCS, because
+// the concurrent path allocates for its workers.
+func TestGemmNoAllocs(t *testing.T) {
+	Register(goblas.New(goblas.WithMaxWorkers(1)))
+	defer Register(goblas.Blas{})
+
+	a := randGeneral(gemmAllocsSize, gemmAllocsSize)
+	b := randGeneral(gemmAllocsSize, gemmAllocsSize)
+	c := randGeneral(gemmAllocsSize, gemmAllocsSize)
+	for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, tB := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			allocs := testing.AllocsPerRun(10, func() {
+				Gemm(tA, tB, 1, a, b, 0.5, c)
+			})
+			if allocs != 0 {
+				t.Errorf("tA = %v, tB = %v: unexpected allocations: %v", tA, tB, allocs)
+			}
+		}
+	}
+}
+
+func BenchmarkGemm(b *testing.B) {
+	x := randGeneral(gemmAllocsSize, gemmAllocsSize)
+	y := randGeneral(gemmAllocsSize, gemmAllocsSize)
+	c := randGeneral(gemmAllocsSize, gemmAllocsSize)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Gemm(blas.NoTrans, blas.NoTrans, 1, x, y, 0.5, c)
+	}
+}
+
+// TestLevel3Check checks that the Level 3 wrappers validate every General
+// argument themselves rather than leaving a short Data to the implementation.
+func TestLevel3Check(t *testing.T) {
+	good := randGeneral(2, 2)
+	short := General{2, 2, 2, good.Data[:3]}
+	sym := Symmetric{good.Data, 2, 2, blas.Upper}
+	tri := Triangular{good.Data, 2, 2, blas.Upper, blas.NonUnit}
+	for _, test := range []struct {
+		name string
+		f    func()
+	}{
+		{"Symm B", func() { Symm(blas.Left, 1, sym, short, 0, good) }},
+		{"Symm C", func() { Symm(blas.Left, 1, sym, good, 0, short) }},
+		{"Syrk A", func() { Syrk(blas.NoTrans, 1, short, 0, sym) }},
+		{"Syr2k A", func() { Syr2k(blas.NoTrans, 1, short, good, 0, sym) }},
+		{"Syr2k B", func() { Syr2k(blas.NoTrans, 1, good, short, 0, sym) }},
+		{"Syr2k C", func() { Syr2k(blas.NoTrans, 1, good, good, 0, Symmetric{good.Data[:3], 2, 2, blas.Upper}) }},
+		{"Trmm B", func() { Trmm(blas.Left, blas.NoTrans, 1, tri, short) }},
+		{"Trsm B", func() { Trsm(blas.Left, blas.NoTrans, 1, tri, short) }},
+	} {
+		err := Try(test.f)
+		if err == nil || err.Error() != "blas: insufficient amount of data" {
+			t.Errorf("%v: unexpected error: %v", test.name, err)
+		}
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"fmt"
 )
 
+const maxInt = int(^uint(0) >> 1)
+
 func Allocate(dims ...int) []float64 {
 	if len(dims) == 0 {
 		return nil
@@ -34,6 +36,28 @@ func NewGeneral(m, n int, data []float64) General {
 	return A
 }
 
+// Dense returns the rows×cols General with stride cols that is stored in
+// data, which is used directly. Unlike NewGeneral, which allocates data if it
+// is nil and accepts a longer slice, Dense panics unless len(data) is exactly
+// rows*cols, so that a slice of the wrong size is caught at construction.
+func Dense(rows, cols int, data []float64) General {
+	if rows < 0 {
+		panic("blas: m < 0")
+	}
+	if cols < 0 {
+		panic("blas: n < 0")
+	}
+	if cols > 0 && rows > maxInt/cols {
+		panic("blas: rows*cols overflows int")
+	}
+	if len(data) != rows*cols {
+		panic(fmt.Sprintf("blas: len(data) = %d, want %d×%d = %d", len(data), rows, cols, rows*cols))
+	}
+	A := General{rows, cols, max(1, cols), data}
+	must(A.Check())
+	return A
+}
+
 func (A General) At(i, j int) float64 {
 	return A.Data[A.Index(i, j)]
 }
@@ -63,6 +87,13 @@ func (A General) Check() error {
 	if A.Stride < A.Cols {
 		return errors.New("blas: illegal stride")
 	}
+	if A.Rows == 0 || A.Cols == 0 {
+		// An empty matrix does not reference any data.
+		return nil
+	}
+	if A.Rows-1 > (maxInt-A.Cols)/A.Stride {
+		return errors.New("blas: rows*stride overflows int")
+	}
 	if (A.Rows-1)*A.Stride+A.Cols > len(A.Data) {
 		return errors.New("blas: insufficient amount of data")
 	}
@@ -103,6 +134,70 @@ type GeneralBand struct {
 	KL, KU int
 }
 
+// NewGeneralBand returns the rows×cols band matrix with kl sub-diagonals and
+// ku super-diagonals stored in data with the minimum stride, kl+ku+1. If data
+// is nil, it is allocated. Otherwise it must hold every row that intersects
+// the band, min(rows, cols+kl)*(kl+ku+1) elements, and NewGeneralBand panics
+// with the required length if it is shorter.
+func NewGeneralBand(rows, cols, kl, ku int, data []float64) GeneralBand {
+	A := GeneralBand{General{rows, cols, kl + ku + 1, data}, kl, ku}
+	if rows >= 0 && cols >= 0 && kl >= 0 && ku >= 0 {
+		A.Data = bandData(min(rows, cols+kl), A.Stride, data)
+	}
+	must(A.Check())
+	return A
+}
+
+// Check returns an error if A is not a valid band matrix. Row i of the band
+// is stored in Data[i*Stride : i*Stride+KL+KU+1], so Stride must be at least
+// KL+KU+1 and Data must hold every row that intersects the band. Unlike
+// General.Check, Stride is not compared with Cols.
+func (A GeneralBand) Check() error {
+	if A.Rows < 0 {
+		return errors.New("blas: m < 0")
+	}
+	if A.Cols < 0 {
+		return errors.New("blas: n < 0")
+	}
+	if A.KL < 0 {
+		return errors.New("blas: kl < 0")
+	}
+	if A.KU < 0 {
+		return errors.New("blas: ku < 0")
+	}
+	if A.Stride < A.KL+A.KU+1 {
+		return errors.New("blas: illegal stride")
+	}
+	rows := A.Rows
+	if A.Cols+A.KL < rows {
+		rows = A.Cols + A.KL
+	}
+	if rows > 0 && rows-1 > (maxInt-A.Stride)/A.Stride {
+		return errors.New("blas: rows*stride overflows int")
+	}
+	if rows > 0 && (rows-1)*A.Stride+A.KL+A.KU+1 > len(A.Data) {
+		return errors.New("blas: insufficient amount of data")
+	}
+	return nil
+}
+
+// ToGeneral returns the dense form of A as a newly allocated Rows×Cols General
+// with zeros outside the band. Element (i, j) of the band, for
+// i-KL <= j <= i+KU, is read from Data[i*Stride+KL+j-i], the layout used by
+// Gbmv.
+func (A GeneralBand) ToGeneral() General {
+	must(A.Check())
+	G := Dense(A.Rows, A.Cols, make([]float64, A.Rows*A.Cols))
+	for i := 0; i < A.Rows; i++ {
+		jl := max(0, i-A.KL)
+		ju := min(A.Cols, i+A.KU+1)
+		if jl < ju {
+			copy(G.Data[i*G.Stride+jl:i*G.Stride+ju], A.Data[i*A.Stride+A.KL+jl-i:])
+		}
+	}
+	return G
+}
+
 type Triangular struct {
 	Data   []float64
 	N      int
@@ -111,6 +206,66 @@ type Triangular struct {
 	Diag   blas.Diag
 }
 
+func (A Triangular) Check() error {
+	if err := checkTriangular(A.Uplo, A.Diag); err != nil {
+		return err
+	}
+	if A.N < 0 {
+		return errors.New("blas: n < 0")
+	}
+	if A.Stride < 1 {
+		return errors.New("blas: illegal stride")
+	}
+	if A.Stride < A.N {
+		return errors.New("blas: illegal stride")
+	}
+	if A.N > 0 && A.N-1 > (maxInt-A.N)/A.Stride {
+		return errors.New("blas: n*stride overflows int")
+	}
+	if (A.N-1)*A.Stride+A.N > len(A.Data) {
+		return errors.New("blas: insufficient amount of data")
+	}
+	return nil
+}
+
+// ToGeneral returns the dense form of A as a newly allocated N×N General.
+// The triangle of A that is not referenced is set to zero, and if A is unit
+// diagonal the diagonal is set to one.
+func (A Triangular) ToGeneral() General {
+	must(A.Check())
+	G := Dense(A.N, A.N, make([]float64, A.N*A.N))
+	for i := 0; i < A.N; i++ {
+		var jl, ju int
+		if A.Uplo == blas.Upper {
+			jl, ju = i, A.N
+		} else {
+			jl, ju = 0, i+1
+		}
+		copy(G.Data[G.Index(i, jl):G.Index(i, ju)], A.Data[i*A.Stride+jl:i*A.Stride+ju])
+		if A.Diag == blas.Unit {
+			G.Set(i, i, 1)
+		}
+	}
+	return G
+}
+
+// Pack returns the referenced triangle of A in newly allocated packed storage.
+// The triangle is packed row by row, the order expected by Tpmv and Tpsv: for
+// blas.Upper row i holds columns i through N-1, for blas.Lower columns 0
+// through i. The diagonal elements are copied even if A is unit diagonal.
+func (A Triangular) Pack() TriangularPacked {
+	must(A.Check())
+	ap := make([]float64, 0, A.N*(A.N+1)/2)
+	for i := 0; i < A.N; i++ {
+		if A.Uplo == blas.Upper {
+			ap = append(ap, A.Data[i*A.Stride+i:i*A.Stride+A.N]...)
+		} else {
+			ap = append(ap, A.Data[i*A.Stride:i*A.Stride+i+1]...)
+		}
+	}
+	return TriangularPacked{ap, A.N, A.Uplo, A.Diag}
+}
+
 type TriangularBand struct {
 	Data   []float64
 	N, K   int
@@ -119,6 +274,62 @@ type TriangularBand struct {
 	Diag   blas.Diag
 }
 
+// NewTriangularBand returns the n×n triangular band matrix with k diagonals
+// off the main one stored in data with the minimum stride, k+1. If data is
+// nil, it is allocated. Otherwise it must hold n*(k+1) elements, and
+// NewTriangularBand panics with the required length if it is shorter.
+func NewTriangularBand(n, k int, ul blas.Uplo, d blas.Diag, data []float64) TriangularBand {
+	A := TriangularBand{data, n, k, k + 1, ul, d}
+	if n >= 0 && k >= 0 {
+		A.Data = bandData(n, A.Stride, data)
+	}
+	must(A.Check())
+	return A
+}
+
+func (A TriangularBand) Check() error {
+	if err := checkTriangular(A.Uplo, A.Diag); err != nil {
+		return err
+	}
+	if A.N < 0 {
+		return errors.New("blas: n < 0")
+	}
+	if A.K < 0 {
+		return errors.New("blas: k < 0")
+	}
+	if A.Stride < A.K+1 {
+		return errors.New("blas: illegal stride")
+	}
+	if A.N > 0 && A.N-1 > (maxInt-A.Stride)/A.Stride {
+		return errors.New("blas: n*stride overflows int")
+	}
+	if (A.N-1)*A.Stride+A.K+1 > len(A.Data) {
+		return errors.New("blas: insufficient amount of data")
+	}
+	return nil
+}
+
+// ToGeneral returns the dense form of A as a newly allocated N×N General with
+// zeros outside the band, in the layout used by Tbmv and Tbsv: for
+// blas.Upper element (i, j), i <= j <= i+K, is read from Data[i*Stride+j-i],
+// and for blas.Lower element (i, j), i-K <= j <= i, from
+// Data[i*Stride+K+j-i]. If A is unit diagonal the diagonal is set to one.
+func (A TriangularBand) ToGeneral() General {
+	must(A.Check())
+	G := Dense(A.N, A.N, make([]float64, A.N*A.N))
+	for i := 0; i < A.N; i++ {
+		jl, ju, off := i, min(A.N, i+A.K+1), 0
+		if A.Uplo == blas.Lower {
+			jl, ju, off = max(0, i-A.K), i+1, A.K
+		}
+		copy(G.Data[i*G.Stride+jl:i*G.Stride+ju], A.Data[i*A.Stride+off+jl-i:])
+		if A.Diag == blas.Unit {
+			G.Set(i, i, 1)
+		}
+	}
+	return G
+}
+
 type TriangularPacked struct {
 	Data []float64
 	N    int
@@ -126,24 +337,257 @@ type TriangularPacked struct {
 	Diag blas.Diag
 }
 
+func (A TriangularPacked) Check() error {
+	if err := checkTriangular(A.Uplo, A.Diag); err != nil {
+		return err
+	}
+	if A.N < 0 {
+		return errors.New("blas: n < 0")
+	}
+	if A.N > 0 && A.N > maxInt/(A.N+1) {
+		return errors.New("blas: n*(n+1)/2 overflows int")
+	}
+	if A.N*(A.N+1)/2 > len(A.Data) {
+		return errors.New("blas: insufficient amount of data")
+	}
+	return nil
+}
+
+// Unpack returns A in newly allocated full storage with stride max(1, N). It
+// is the inverse of Triangular.Pack. The triangle that is not referenced is
+// zero.
+func (A TriangularPacked) Unpack() Triangular {
+	must(A.Check())
+	stride := A.N
+	if stride < 1 {
+		stride = 1
+	}
+	T := Triangular{make([]float64, A.N*stride), A.N, stride, A.Uplo, A.Diag}
+	var k int
+	for i := 0; i < A.N; i++ {
+		var jl, ju int
+		if A.Uplo == blas.Upper {
+			jl, ju = i, A.N
+		} else {
+			jl, ju = 0, i+1
+		}
+		k += copy(T.Data[i*T.Stride+jl:i*T.Stride+ju], A.Data[k:])
+	}
+	return T
+}
+
+// checkTriangular returns an error if ul or d are not legal values for
+// a triangular matrix.
+func checkTriangular(ul blas.Uplo, d blas.Diag) error {
+	if ul != blas.Upper && ul != blas.Lower {
+		return errors.New("blas: illegal triangularization")
+	}
+	if d != blas.Unit && d != blas.NonUnit {
+		return errors.New("blas: illegal diag")
+	}
+	return nil
+}
+
+// Symmetric is an N×N symmetric matrix stored row-major with stride Stride,
+// of which only the triangle given by Uplo is referenced. There is no
+// column-major form: the upper triangle of a column-major matrix occupies the
+// same elements as the lower triangle of the row-major matrix with the same
+// stride, so such a matrix is described by swapping Uplo.
 type Symmetric struct {
 	Data      []float64
 	N, Stride int
 	Uplo      blas.Uplo
 }
 
+// Check returns an error if A is not a valid symmetric matrix. As for
+// Triangular, Stride must be positive and at least N.
+func (A Symmetric) Check() error {
+	if A.Uplo != blas.Upper && A.Uplo != blas.Lower {
+		return errors.New("blas: illegal triangularization")
+	}
+	if A.N < 0 {
+		return errors.New("blas: n < 0")
+	}
+	if A.Stride < 1 {
+		return errors.New("blas: illegal stride")
+	}
+	if A.Stride < A.N {
+		return errors.New("blas: illegal stride")
+	}
+	if A.N > 0 && A.N-1 > (maxInt-A.N)/A.Stride {
+		return errors.New("blas: n*stride overflows int")
+	}
+	if (A.N-1)*A.Stride+A.N > len(A.Data) {
+		return errors.New("blas: insufficient amount of data")
+	}
+	return nil
+}
+
+// ToGeneral returns the dense form of A as a newly allocated N×N General
+// with the referenced triangle of A mirrored across the diagonal.
+func (A Symmetric) ToGeneral() General {
+	must(A.Check())
+	G := Dense(A.N, A.N, make([]float64, A.N*A.N))
+	for i := 0; i < A.N; i++ {
+		var jl, ju int
+		if A.Uplo == blas.Upper {
+			jl, ju = i, A.N
+		} else {
+			jl, ju = 0, i+1
+		}
+		for j := jl; j < ju; j++ {
+			v := A.Data[i*A.Stride+j]
+			G.Set(i, j, v)
+			G.Set(j, i, v)
+		}
+	}
+	return G
+}
+
+// Pack returns the referenced triangle of A in newly allocated packed storage.
+// The triangle is packed row by row, the order expected by Spmv, Spr and
+// Spr2: for blas.Upper row i holds columns i through N-1, for blas.Lower
+// columns 0 through i.
+func (A Symmetric) Pack() SymmetricPacked {
+	must(A.Check())
+	ap := make([]float64, 0, A.N*(A.N+1)/2)
+	for i := 0; i < A.N; i++ {
+		if A.Uplo == blas.Upper {
+			ap = append(ap, A.Data[i*A.Stride+i:i*A.Stride+A.N]...)
+		} else {
+			ap = append(ap, A.Data[i*A.Stride:i*A.Stride+i+1]...)
+		}
+	}
+	return SymmetricPacked{ap, A.N, A.Uplo}
+}
+
 type SymmetricBand struct {
 	Data         []float64
 	N, K, Stride int
 	Uplo         blas.Uplo
 }
 
+// NewSymmetricBand returns the n×n symmetric band matrix with k diagonals off
+// the main one stored in data with the minimum stride, k+1. If data is nil,
+// it is allocated. Otherwise it must hold n*(k+1) elements, and
+// NewSymmetricBand panics with the required length if it is shorter.
+func NewSymmetricBand(n, k int, ul blas.Uplo, data []float64) SymmetricBand {
+	A := SymmetricBand{data, n, k, k + 1, ul}
+	if n >= 0 && k >= 0 {
+		A.Data = bandData(n, A.Stride, data)
+	}
+	must(A.Check())
+	return A
+}
+
+// bandData returns data, or a new slice if data is nil, after checking that
+// it holds rows rows of stride elements.
+func bandData(rows, stride int, data []float64) []float64 {
+	if rows > maxInt/stride {
+		panic("blas: band storage overflows int")
+	}
+	n := rows * stride
+	if data == nil {
+		return make([]float64, n)
+	}
+	if len(data) < n {
+		panic(fmt.Sprintf("blas: len(data) = %d, want at least %d×%d = %d", len(data), rows, stride, n))
+	}
+	return data
+}
+
+// Check returns an error if A is not a valid symmetric band matrix. Row i of
+// the band is stored in Data[i*Stride : i*Stride+K+1], so Stride must be at
+// least K+1.
+func (A SymmetricBand) Check() error {
+	if A.Uplo != blas.Upper && A.Uplo != blas.Lower {
+		return errors.New("blas: illegal triangularization")
+	}
+	if A.N < 0 {
+		return errors.New("blas: n < 0")
+	}
+	if A.K < 0 {
+		return errors.New("blas: k < 0")
+	}
+	if A.Stride < A.K+1 {
+		return errors.New("blas: illegal stride")
+	}
+	if A.N > 0 && A.N-1 > (maxInt-A.Stride)/A.Stride {
+		return errors.New("blas: n*stride overflows int")
+	}
+	if (A.N-1)*A.Stride+A.K+1 > len(A.Data) {
+		return errors.New("blas: insufficient amount of data")
+	}
+	return nil
+}
+
+// ToGeneral returns the dense form of A as a newly allocated N×N General with
+// the referenced band mirrored across the diagonal and zeros outside the
+// band. The band is read in the layout used by Sbmv, which is that of
+// TriangularBand.ToGeneral.
+func (A SymmetricBand) ToGeneral() General {
+	must(A.Check())
+	G := Dense(A.N, A.N, make([]float64, A.N*A.N))
+	for i := 0; i < A.N; i++ {
+		jl, ju, off := i, min(A.N, i+A.K+1), 0
+		if A.Uplo == blas.Lower {
+			jl, ju, off = max(0, i-A.K), i+1, A.K
+		}
+		for j := jl; j < ju; j++ {
+			v := A.Data[i*A.Stride+off+j-i]
+			G.Set(i, j, v)
+			G.Set(j, i, v)
+		}
+	}
+	return G
+}
+
 type SymmetricPacked struct {
 	Data []float64
 	N    int
 	Uplo blas.Uplo
 }
 
+// Check returns an error if A is not a valid packed symmetric matrix.
+func (A SymmetricPacked) Check() error {
+	if A.Uplo != blas.Upper && A.Uplo != blas.Lower {
+		return errors.New("blas: illegal triangularization")
+	}
+	if A.N < 0 {
+		return errors.New("blas: n < 0")
+	}
+	if A.N > 0 && A.N > maxInt/(A.N+1) {
+		return errors.New("blas: n*(n+1)/2 overflows int")
+	}
+	if A.N*(A.N+1)/2 > len(A.Data) {
+		return errors.New("blas: insufficient amount of data")
+	}
+	return nil
+}
+
+// ToSymmetric returns A in newly allocated full storage with stride
+// max(1, N). It is the inverse of Symmetric.Pack. The triangle that is not
+// referenced is zero.
+func (A SymmetricPacked) ToSymmetric() Symmetric {
+	must(A.Check())
+	stride := A.N
+	if stride < 1 {
+		stride = 1
+	}
+	S := Symmetric{make([]float64, A.N*stride), A.N, stride, A.Uplo}
+	var k int
+	for i := 0; i < A.N; i++ {
+		var jl, ju int
+		if A.Uplo == blas.Upper {
+			jl, ju = i, A.N
+		} else {
+			jl, ju = 0, i+1
+		}
+		k += copy(S.Data[i*S.Stride+jl:i*S.Stride+ju], A.Data[k:])
+	}
+	return S
+}
+
 type Vector struct {
 	Data []float64
 	N    int
@@ -154,12 +598,47 @@ func NewVector(v []float64) Vector {
 	return Vector{v, len(v), 1}
 }
 
+// At returns element i of v. As in the BLAS, element 0 is Data[0] if Inc is
+// positive and Data[(N-1)*-Inc] if Inc is negative.
+func (v Vector) At(i int) float64 {
+	return v.Data[v.index(i)]
+}
+
+// SetAt sets element i of v to val, with the same indexing as At.
+func (v Vector) SetAt(i int, val float64) {
+	v.Data[v.index(i)] = val
+}
+
+// index returns the position in Data of element i of v.
+func (v Vector) index(i int) int {
+	if i < 0 || i >= v.N {
+		panic("blas: index out of range")
+	}
+	if v.Inc < 0 {
+		return (v.N - 1 - i) * -v.Inc
+	}
+	return i * v.Inc
+}
+
+// Slice returns the sub-vector of v holding the elements l through r-1. The
+// result shares Data with v and has the same Inc. For a negative Inc, as in
+// the BLAS, element 0 of a vector is the last one stored in Data, so the
+// returned Data starts at the last element of the sub-vector rather than
+// at element l.
 func (v Vector) Slice(l, r int) Vector {
 	if l < 0 || r > v.N {
 		panic("blas: index out of range")
 	}
-	if r > l {
-		panic(fmt.Sprintf("blas: invalid slice index:", r, ">", l))
+	if l > r {
+		panic(fmt.Sprintf("blas: invalid slice index: %d > %d", l, r))
+	}
+	if l == r {
+		// An empty vector references no data, and the offset of element l
+		// may lie past the end of Data.
+		return Vector{nil, 0, v.Inc}
+	}
+	if v.Inc < 0 {
+		return Vector{v.Data[(v.N-r)*-v.Inc:], r - l, v.Inc}
 	}
 	return Vector{v.Data[l*v.Inc:], r - l, v.Inc}
 }
@@ -171,7 +650,14 @@ func (v Vector) Check() error {
 	if v.Inc == 0 {
 		return errors.New("blas: zero x index increment")
 	}
-	if (v.N-1)*v.Inc >= len(v.Data) {
+	if v.N == 0 {
+		return nil
+	}
+	inc := v.Inc
+	if inc < 0 {
+		inc = -inc
+	}
+	if (v.N-1)*inc >= len(v.Data) {
 		return errors.New("blas: index out of range")
 	}
 	return nil
@@ -198,3 +684,17 @@ func must(err error) {
 		panic(err)
 	}
 }
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,611 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbw
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestTriangularPack(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 5} {
+		for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, d := range []blas.Diag{blas.NonUnit, blas.Unit} {
+				for _, stride := range []int{max(1, n), n + 3} {
+					A := Triangular{make([]float64, max(0, (n-1)*stride+n)), n, stride, ul, d}
+					for i := range A.Data {
+						A.Data[i] = rand.Float64()
+					}
+
+					P := A.Pack()
+					if P.N != n || P.Uplo != ul || P.Diag != d || len(P.Data) != n*(n+1)/2 {
+						t.Errorf("n = %v, ul = %v, d = %v: bad packed matrix %+v", n, ul, d, P)
+						continue
+					}
+					U := P.Unpack()
+					for i := 0; i < n; i++ {
+						for j := 0; j < n; j++ {
+							inTri := (ul == blas.Upper && j >= i) || (ul == blas.Lower && j <= i)
+							got := U.Data[i*U.Stride+j]
+							want := 0.0
+							if inTri {
+								want = A.Data[i*A.Stride+j]
+							}
+							if got != want {
+								t.Errorf("n = %v, ul = %v, d = %v, stride = %v: round trip mismatch at (%v, %v)", n, ul, d, stride, i, j)
+							}
+						}
+					}
+					if P2 := U.Pack(); !equalFloat64s(P2.Data, P.Data) {
+						t.Errorf("n = %v, ul = %v, d = %v: Pack(Unpack(P)) != P", n, ul, d)
+					}
+
+					// The packed layout must be the one used by Tpmv.
+					if n == 0 {
+						continue
+					}
+					x := NewVector(make([]float64, n))
+					for i := range x.Data {
+						x.Data[i] = rand.Float64()
+					}
+					xp := NewVector(append([]float64(nil), x.Data...))
+					Trmv(blas.NoTrans, U, x)
+					Tpmv(blas.NoTrans, P, xp)
+					for i := range x.Data {
+						if math.Abs(x.Data[i]-xp.Data[i]) > 1e-14 {
+							t.Errorf("n = %v, ul = %v, d = %v: Trmv and Tpmv differ", n, ul, d)
+							break
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestTriangularCheck(t *testing.T) {
+	data := make([]float64, 16)
+	for i, test := range []struct {
+		A     Triangular
+		valid bool
+	}{
+		{Triangular{data, 4, 4, blas.Upper, blas.NonUnit}, true},
+		{Triangular{data, 4, 4, blas.Lower, blas.Unit}, true},
+		{Triangular{nil, 0, 1, blas.Lower, blas.Unit}, true},
+		// The zero Uplo and Diag are not legal values.
+		{Triangular{data, 4, 4, 0, blas.NonUnit}, false},
+		{Triangular{data, 4, 4, blas.Upper, 0}, false},
+		{Triangular{data, 4, 4, blas.All, blas.NonUnit}, false},
+		{Triangular{Data: data, N: 4, Stride: 4}, false},
+		{Triangular{data, -1, 4, blas.Upper, blas.NonUnit}, false},
+		{Triangular{data[:15], 4, 4, blas.Upper, blas.NonUnit}, false},
+		// (N-1)*Stride+N overflows int and must not wrap around to a
+		// length that data appears to satisfy.
+		{Triangular{data, 4, maxInt / 2, blas.Upper, blas.NonUnit}, false},
+		{Triangular{data, 2, maxInt - 1, blas.Lower, blas.NonUnit}, false},
+	} {
+		err := test.A.Check()
+		if (err == nil) != test.valid {
+			t.Errorf("Case %v: unexpected result: %v", i, err)
+		}
+	}
+	if !panics(func() { Trmv(blas.NoTrans, Triangular{Data: data, N: 4, Stride: 4}, NewVector(make([]float64, 4))) }) {
+		t.Errorf("expected panic from Trmv for a zero-valued Uplo and Diag")
+	}
+}
+
+func TestTriangularToGeneral(t *testing.T) {
+	for _, n := range []int{0, 1, 4} {
+		for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, d := range []blas.Diag{blas.NonUnit, blas.Unit} {
+				stride := n + 2
+				// Every element of A, including the unreferenced triangle
+				// and the diagonal, is non-zero and differs from one.
+				A := Triangular{make([]float64, max(0, (n-1)*stride+n)), n, stride, ul, d}
+				for i := range A.Data {
+					A.Data[i] = float64(i + 2)
+				}
+				G := A.ToGeneral()
+				if G.Rows != n || G.Cols != n {
+					t.Errorf("n = %v, ul = %v, d = %v: G is %v×%v", n, ul, d, G.Rows, G.Cols)
+					continue
+				}
+				for i := 0; i < n; i++ {
+					for j := 0; j < n; j++ {
+						var want float64
+						switch {
+						case i == j && d == blas.Unit:
+							want = 1
+						case (ul == blas.Upper && j >= i) || (ul == blas.Lower && j <= i):
+							want = A.Data[i*A.Stride+j]
+						}
+						if got := G.At(i, j); got != want {
+							t.Errorf("n = %v, ul = %v, d = %v: G[%v][%v] = %v, want %v", n, ul, d, i, j, got, want)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestSymmetricPack(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 5} {
+		for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, stride := range []int{max(1, n), n + 3} {
+				A := Symmetric{make([]float64, max(0, (n-1)*stride+n)), n, stride, ul}
+				for i := range A.Data {
+					A.Data[i] = rand.Float64()
+				}
+
+				P := A.Pack()
+				if P.N != n || P.Uplo != ul || len(P.Data) != n*(n+1)/2 {
+					t.Errorf("n = %v, ul = %v: bad packed matrix %+v", n, ul, P)
+					continue
+				}
+				S := P.ToSymmetric()
+				for i := 0; i < n; i++ {
+					for j := 0; j < n; j++ {
+						inTri := (ul == blas.Upper && j >= i) || (ul == blas.Lower && j <= i)
+						got := S.Data[i*S.Stride+j]
+						want := 0.0
+						if inTri {
+							want = A.Data[i*A.Stride+j]
+						}
+						if got != want {
+							t.Errorf("n = %v, ul = %v, stride = %v: round trip mismatch at (%v, %v)", n, ul, stride, i, j)
+						}
+					}
+				}
+				if P2 := S.Pack(); !equalFloat64s(P2.Data, P.Data) {
+					t.Errorf("n = %v, ul = %v: Pack(ToSymmetric(P)) != P", n, ul)
+				}
+				if G := S.ToGeneral(); G.Rows != n || G.Cols != n {
+					t.Errorf("n = %v, ul = %v: ToGeneral is %v×%v", n, ul, G.Rows, G.Cols)
+				}
+
+				// The packed layout must be the one used by Spmv, and both
+				// forms must give the product with the full matrix.
+				if n == 0 {
+					continue
+				}
+				x := NewVector(make([]float64, n))
+				for i := range x.Data {
+					x.Data[i] = rand.Float64()
+				}
+				y := NewVector(make([]float64, n))
+				yp := NewVector(make([]float64, n))
+				ys := NewVector(make([]float64, n))
+				Gemv(blas.NoTrans, 1, S.ToGeneral(), x, 0, y)
+				Spmv(1, P, x, 0, yp)
+				Symv(1, S, x, 0, ys)
+				for i := range y.Data {
+					if math.Abs(y.Data[i]-yp.Data[i]) > 1e-14 || math.Abs(y.Data[i]-ys.Data[i]) > 1e-14 {
+						t.Errorf("n = %v, ul = %v: Gemv, Spmv and Symv differ", n, ul)
+						break
+					}
+				}
+			}
+		}
+	}
+
+	if !panics(func() { SymmetricPacked{make([]float64, 5), 3, blas.Upper}.ToSymmetric() }) {
+		t.Errorf("expected panic for short packed data")
+	}
+}
+
+func equalFloat64s(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, v := range a {
+		if b[i] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func TestBandCheck(t *testing.T) {
+	for i, test := range []struct {
+		A     interface{ Check() error }
+		valid bool
+	}{
+		{GeneralBand{General{Rows: 4, Cols: 3, Stride: 3, Data: make([]float64, 12)}, 1, 1}, true},
+		// Only the rows that intersect the band need to be stored.
+		{GeneralBand{General{Rows: 6, Cols: 3, Stride: 2, Data: make([]float64, 8)}, 1, 0}, true},
+		{GeneralBand{General{Rows: 4, Cols: 3, Stride: 2, Data: make([]float64, 12)}, 1, 1}, false},
+		{GeneralBand{General{Rows: 4, Cols: 3, Stride: 3, Data: make([]float64, 11)}, 1, 1}, false},
+		{GeneralBand{General{Rows: 4, Cols: 3, Stride: 3, Data: make([]float64, 12)}, -1, 1}, false},
+		{GeneralBand{General{Rows: 4, Cols: 3, Stride: 3, Data: make([]float64, 12)}, 1, -1}, false},
+		{GeneralBand{General{Rows: 0, Cols: 0, Stride: 1}, 0, 0}, true},
+
+		{SymmetricBand{make([]float64, 9), 3, 2, 3, blas.Upper}, true},
+		{SymmetricBand{make([]float64, 8), 3, 2, 3, blas.Lower}, false},
+		{SymmetricBand{make([]float64, 9), 3, 2, 2, blas.Upper}, false},
+		{SymmetricBand{make([]float64, 9), 3, -1, 3, blas.Upper}, false},
+		{SymmetricBand{make([]float64, 9), 3, 2, 3, blas.All}, false},
+		{SymmetricBand{nil, 0, 0, 1, blas.Upper}, true},
+
+		{TriangularBand{make([]float64, 6), 3, 1, 2, blas.Upper, blas.NonUnit}, true},
+		{TriangularBand{make([]float64, 6), 3, 1, 1, blas.Upper, blas.NonUnit}, false},
+		{TriangularBand{make([]float64, 5), 3, 1, 2, blas.Upper, blas.NonUnit}, false},
+
+		// The offset of the last row overflows int and must not wrap around
+		// to a length that Data appears to satisfy.
+		{GeneralBand{General{Rows: 4, Cols: 3, Stride: maxInt / 2, Data: make([]float64, 12)}, 1, 1}, false},
+		{SymmetricBand{make([]float64, 9), 3, 2, maxInt / 2, blas.Upper}, false},
+		{TriangularBand{make([]float64, 6), 2, 1, maxInt - 1, blas.Lower, blas.NonUnit}, false},
+		{Symmetric{make([]float64, 16), 4, maxInt / 2, blas.Upper}, false},
+		{Symmetric{make([]float64, 16), 2, maxInt - 1, blas.Lower}, false},
+	} {
+		err := test.A.Check()
+		if (err == nil) != test.valid {
+			t.Errorf("Case %v: unexpected result for %T: %v", i, test.A, err)
+		}
+	}
+}
+
+// TestBandToGeneral checks the dense forms of the band matrices against the
+// band matrix-vector routines, which must read the same elements.
+func TestBandToGeneral(t *testing.T) {
+	randBand := func(n int) []float64 {
+		d := make([]float64, n)
+		for i := range d {
+			d[i] = rand.Float64()
+		}
+		return d
+	}
+	mulDense := func(G General, x Vector) []float64 {
+		y := NewVector(make([]float64, G.Rows))
+		Gemv(blas.NoTrans, 1, G, x, 0, y)
+		return y.Data
+	}
+	for _, test := range []struct{ m, n, kl, ku int }{
+		{0, 0, 0, 0}, {1, 1, 0, 0}, {4, 4, 1, 2}, {6, 3, 1, 0}, {3, 6, 2, 1}, {5, 5, 4, 4},
+	} {
+		stride := test.kl + test.ku + 1
+		A := GeneralBand{General{test.m, test.n, stride, randBand(max(0, test.m*stride))}, test.kl, test.ku}
+		G := A.ToGeneral()
+		for i := 0; i < test.m; i++ {
+			for j := 0; j < test.n; j++ {
+				if (j < i-test.kl || j > i+test.ku) && G.At(i, j) != 0 {
+					t.Errorf("GeneralBand %+v: element (%v, %v) outside the band is %v", test, i, j, G.At(i, j))
+				}
+			}
+		}
+		if test.m == 0 || test.n == 0 {
+			continue
+		}
+		x := NewVector(randBand(test.n))
+		y := NewVector(make([]float64, test.m))
+		Gbmv(blas.NoTrans, 1, A, x, 0, y)
+		want := mulDense(G, x)
+		for i := range want {
+			if math.Abs(y.Data[i]-want[i]) > 1e-14 {
+				t.Errorf("GeneralBand %+v: Gbmv and Gemv differ at %v: %v != %v", test, i, y.Data[i], want[i])
+			}
+		}
+	}
+
+	for _, n := range []int{0, 1, 5} {
+		for _, k := range []int{0, 1, 3, 6} {
+			for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+				inBand := func(i, j int) bool {
+					if ul == blas.Upper {
+						return j >= i && j <= i+k
+					}
+					return j <= i && j >= i-k
+				}
+
+				S := SymmetricBand{randBand(n * (k + 1)), n, k, k + 1, ul}
+				G := S.ToGeneral()
+				for i := 0; i < n; i++ {
+					for j := 0; j < n; j++ {
+						want := 0.0
+						switch {
+						case inBand(i, j):
+							want = S.Data[i*S.Stride+j-i]
+							if ul == blas.Lower {
+								want = S.Data[i*S.Stride+k+j-i]
+							}
+						case inBand(j, i):
+							want = G.At(j, i)
+						}
+						if G.At(i, j) != want {
+							t.Errorf("SymmetricBand n = %v, k = %v, ul = %v: element (%v, %v) = %v, want %v", n, k, ul, i, j, G.At(i, j), want)
+						}
+					}
+				}
+
+				for _, d := range []blas.Diag{blas.NonUnit, blas.Unit} {
+					T := TriangularBand{randBand(n * (k + 1)), n, k, k + 1, ul, d}
+					G := T.ToGeneral()
+					for i := 0; i < n; i++ {
+						for j := 0; j < n; j++ {
+							if !inBand(i, j) && G.At(i, j) != 0 {
+								t.Errorf("TriangularBand n = %v, k = %v, ul = %v: element (%v, %v) outside the band is %v", n, k, ul, i, j, G.At(i, j))
+							}
+						}
+						if d == blas.Unit && G.At(i, i) != 1 {
+							t.Errorf("TriangularBand n = %v, k = %v, ul = %v: unit diagonal is %v", n, k, ul, G.At(i, i))
+						}
+					}
+					if n == 0 {
+						continue
+					}
+					x := NewVector(randBand(n))
+					want := mulDense(G, x)
+					Tbmv(blas.NoTrans, T, x)
+					for i := range want {
+						if math.Abs(x.Data[i]-want[i]) > 1e-14 {
+							t.Errorf("TriangularBand n = %v, k = %v, ul = %v, d = %v: Tbmv and Gemv differ at %v: %v != %v", n, k, ul, d, i, x.Data[i], want[i])
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestNewBand(t *testing.T) {
+	G := NewGeneralBand(6, 3, 1, 2, nil)
+	if G.Stride != 4 || len(G.Data) != 16 {
+		t.Errorf("unexpected GeneralBand stride %v and length %v", G.Stride, len(G.Data))
+	}
+	T := NewTriangularBand(5, 2, blas.Lower, blas.Unit, nil)
+	if T.Stride != 3 || len(T.Data) != 15 || T.Uplo != blas.Lower || T.Diag != blas.Unit {
+		t.Errorf("unexpected TriangularBand %+v", T)
+	}
+	S := NewSymmetricBand(5, 0, blas.Upper, nil)
+	if S.Stride != 1 || len(S.Data) != 5 {
+		t.Errorf("unexpected SymmetricBand %+v", S)
+	}
+	data := make([]float64, 20)
+	if A := NewSymmetricBand(4, 3, blas.Lower, data); &A.Data[0] != &data[0] {
+		t.Errorf("data not used")
+	}
+	if A := NewGeneralBand(0, 0, 0, 0, nil); A.Stride != 1 || A.Check() != nil {
+		t.Errorf("unexpected empty GeneralBand %+v", A)
+	}
+
+	for i, f := range []func(){
+		func() { NewGeneralBand(6, 3, 1, 2, make([]float64, 15)) },
+		func() { NewGeneralBand(-1, 3, 1, 2, nil) },
+		func() { NewGeneralBand(3, 3, -1, 2, nil) },
+		func() { NewTriangularBand(5, 2, blas.Lower, blas.Unit, make([]float64, 14)) },
+		func() { NewTriangularBand(5, 2, blas.All, blas.Unit, nil) },
+		func() { NewSymmetricBand(5, -1, blas.Upper, nil) },
+		func() { NewSymmetricBand(maxInt/2, 3, blas.Upper, nil) },
+	} {
+		if !panics(f) {
+			t.Errorf("Case %v: expected panic", i)
+		}
+	}
+}
+
+// TestStrideCheck checks that a zero or negative stride is rejected by every
+// typed Check, even for an empty matrix, instead of producing out of range
+// offsets in the wrappers.
+func TestStrideCheck(t *testing.T) {
+	data := make([]float64, 16)
+	for _, stride := range []int{0, -1, -4} {
+		for _, A := range []interface{ Check() error }{
+			General{4, 4, stride, data},
+			General{0, 0, stride, nil},
+			GeneralBand{General{4, 4, stride, data}, 1, 1},
+			Triangular{data, 4, stride, blas.Upper, blas.NonUnit},
+			Triangular{nil, 0, stride, blas.Upper, blas.NonUnit},
+			TriangularBand{data, 4, 1, stride, blas.Lower, blas.Unit},
+			Symmetric{data, 4, stride, blas.Upper},
+			Symmetric{nil, 0, stride, blas.Lower},
+			SymmetricBand{data, 4, 1, stride, blas.Lower},
+		} {
+			if A.Check() == nil {
+				t.Errorf("%T with stride %d: expected error", A, stride)
+			}
+		}
+	}
+	if err := (Symmetric{data, 4, 4, blas.Upper}).Check(); err != nil {
+		t.Errorf("unexpected error for valid Symmetric: %v", err)
+	}
+	if err := (Symmetric{data[:15], 4, 5, blas.Upper}).Check(); err == nil {
+		t.Errorf("expected error for short Symmetric")
+	}
+}
+
+func TestPackedCheck(t *testing.T) {
+	data := make([]float64, 10)
+	for i, test := range []struct {
+		A     interface{ Check() error }
+		valid bool
+	}{
+		{TriangularPacked{data, 4, blas.Upper, blas.NonUnit}, true},
+		{TriangularPacked{data[:9], 4, blas.Lower, blas.Unit}, false},
+		{SymmetricPacked{data, 4, blas.Lower}, true},
+		{SymmetricPacked{data[:9], 4, blas.Upper}, false},
+		// N*(N+1)/2 overflows int and must not wrap around to a length
+		// that data appears to satisfy.
+		{TriangularPacked{data, maxInt / 2, blas.Upper, blas.NonUnit}, false},
+		{SymmetricPacked{data, maxInt / 2, blas.Upper}, false},
+	} {
+		err := test.A.Check()
+		if (err == nil) != test.valid {
+			t.Errorf("Case %v: unexpected result: %v", i, err)
+		}
+	}
+}
+
+func TestDense(t *testing.T) {
+	data := []float64{1, 2, 3, 4, 5, 6}
+	A := Dense(2, 3, data)
+	if A.Rows != 2 || A.Cols != 3 || A.Stride != 3 || &A.Data[0] != &data[0] {
+		t.Errorf("unexpected matrix %+v", A)
+	}
+	if A.At(1, 0) != 4 {
+		t.Errorf("A.At(1, 0) = %v, want 4", A.At(1, 0))
+	}
+	if A := Dense(0, 0, nil); A.Stride != 1 {
+		t.Errorf("unexpected empty matrix %+v", A)
+	}
+	if A := Dense(3, 0, nil); A.Rows != 3 || A.Stride != 1 {
+		t.Errorf("unexpected 3×0 matrix %+v", A)
+	}
+	for _, f := range []func(){
+		func() { Dense(2, 3, data[:5]) },
+		func() { Dense(2, 2, data) },
+		func() { Dense(-1, 3, nil) },
+		func() { Dense(2, -3, nil) },
+		func() { Dense(maxInt/2, 3, nil) },
+	} {
+		if !panics(f) {
+			t.Errorf("Expected panic")
+		}
+	}
+}
+
+func TestVectorSlice(t *testing.T) {
+	data := make([]float64, 20)
+	for i := range data {
+		data[i] = float64(i)
+	}
+	for _, inc := range []int{1, 2, -1, -3} {
+		n := 6
+		v := Vector{data, n, inc}
+		for l := 0; l <= n; l++ {
+			for r := l; r <= n; r++ {
+				s := v.Slice(l, r)
+				if s.N != r-l || s.Inc != inc {
+					t.Errorf("inc = %v: Slice(%v, %v) has N = %v, Inc = %v", inc, l, r, s.N, s.Inc)
+					continue
+				}
+				if err := s.Check(); err != nil {
+					t.Errorf("inc = %v: Slice(%v, %v) invalid: %v", inc, l, r, err)
+				}
+				for i := 0; i < s.N; i++ {
+					if s.At(i) != v.At(l+i) {
+						t.Errorf("inc = %v: Slice(%v, %v) element %v mismatch", inc, l, r, i)
+					}
+				}
+			}
+		}
+		for _, lr := range [][2]int{{-1, 2}, {0, n + 1}, {3, 2}} {
+			if !panics(func() { v.Slice(lr[0], lr[1]) }) {
+				t.Errorf("inc = %v: Slice(%v, %v) did not panic", inc, lr[0], lr[1])
+			}
+		}
+	}
+
+	// Sub-vectors of a matrix column.
+	A := NewGeneral(5, 3, nil)
+	for i := range A.Data {
+		A.Data[i] = float64(i)
+	}
+	c := A.Col(1).Slice(1, 4)
+	for i := 0; i < c.N; i++ {
+		if c.Data[i*c.Inc] != A.At(i+1, 1) {
+			t.Errorf("column slice element %v mismatch", i)
+		}
+	}
+
+	// Every slice, including the empty ones, of vectors with no spare Data
+	// after the last element: a column of a matrix and vectors holding
+	// exactly (N-1)*|Inc|+1 elements.
+	for _, v := range []Vector{
+		NewGeneral(5, 3, nil).Col(1),
+		{make([]float64, 16), 6, -3},
+		{make([]float64, 16), 6, 3},
+	} {
+		for l := 0; l <= v.N; l++ {
+			for r := l; r <= v.N; r++ {
+				var s Vector
+				if panics(func() { s = v.Slice(l, r) }) {
+					t.Errorf("len(Data) = %v, inc = %v: Slice(%v, %v) panicked", len(v.Data), v.Inc, l, r)
+					continue
+				}
+				if s.N != r-l || s.Check() != nil {
+					t.Errorf("len(Data) = %v, inc = %v: Slice(%v, %v) = %+v is invalid", len(v.Data), v.Inc, l, r, s)
+				}
+			}
+		}
+	}
+}
+
+func panics(f func()) (b bool) {
+	defer func() {
+		if recover() != nil {
+			b = true
+		}
+	}()
+	f()
+	return
+}
+
+func TestVectorCheck(t *testing.T) {
+	for i, test := range []struct {
+		v     Vector
+		valid bool
+	}{
+		{Vector{make([]float64, 4), 4, 1}, true},
+		{Vector{make([]float64, 10), 4, 3}, true},
+		{Vector{make([]float64, 10), 4, -3}, true},
+		{Vector{nil, 0, 1}, true},
+		{Vector{nil, 0, -2}, true},
+		{Vector{make([]float64, 4), 4, 0}, false},
+		{Vector{nil, 0, 0}, false},
+		{Vector{make([]float64, 4), -1, 1}, false},
+		// Data must hold (N-1)*|Inc|+1 elements.
+		{Vector{make([]float64, 3), 4, 1}, false},
+		{Vector{make([]float64, 9), 4, 3}, false},
+		{Vector{make([]float64, 9), 4, -3}, false},
+	} {
+		err := test.v.Check()
+		if (err == nil) != test.valid {
+			t.Errorf("Case %v: unexpected result: %v", i, err)
+		}
+	}
+}
+
+func TestVectorAt(t *testing.T) {
+	for _, inc := range []int{1, 3, -1, -2} {
+		n := 4
+		v := Vector{make([]float64, (n-1)*abs(inc)+1), n, inc}
+		for i := 0; i < n; i++ {
+			v.SetAt(i, float64(i))
+		}
+		// Element 0 is first in Data for a positive increment and last for
+		// a negative one.
+		first, last := v.Data[0], v.Data[len(v.Data)-1]
+		if inc < 0 {
+			first, last = last, first
+		}
+		if first != 0 || last != float64(n-1) {
+			t.Errorf("inc = %v: elements stored in the wrong order: %v", inc, v.Data)
+		}
+		for i := 0; i < n; i++ {
+			if v.At(i) != float64(i) {
+				t.Errorf("inc = %v: At(%v) = %v", inc, i, v.At(i))
+			}
+		}
+		for _, i := range []int{-1, n} {
+			if !panics(func() { v.At(i) }) {
+				t.Errorf("inc = %v: At(%v) did not panic", inc, i)
+			}
+			if !panics(func() { v.SetAt(i, 0) }) {
+				t.Errorf("inc = %v: SetAt(%v) did not panic", inc, i)
+			}
+		}
+	}
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
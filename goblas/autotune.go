@@ -0,0 +1,110 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gonum/blas"
+)
+
+const (
+	tuneN      = 192  // dimension of the square product timed by the autotuner
+	tuneRuns   = 2    // number of timings per candidate, of which the fastest counts
+	tuneMargin = 0.10 // fraction by which a candidate must beat blockSize to replace it
+)
+
+// tuneCandidates are the block sizes tried by the autotuner.
+var tuneCandidates = []int{32, 48, blockSize, 96, 128}
+
+// autotune is read once from the GOBLAS_AUTOTUNE environment variable when
+// the package is initialized. See the package documentation.
+var autotune = parseAutotune(os.Getenv("GOBLAS_AUTOTUNE"))
+
+// parseAutotune reports whether the value of GOBLAS_AUTOTUNE enables tuning
+// of the block size. Only a value accepted by strconv.ParseBool as true
+// enables it.
+func parseAutotune(s string) bool {
+	b, err := strconv.ParseBool(s)
+	return err == nil && b
+}
+
+var tuned struct {
+	once sync.Once
+	bs   int
+}
+
+// defaultBlockSize returns the block size from which dgemmBlockSize starts
+// when none has been set with WithBlockSize. If autotune is enabled, it is
+// measured by tuneBlockSize on the first call and cached for the life of
+// the program.
+func defaultBlockSize() int {
+	if !autotune {
+		return blockSize
+	}
+	tuned.once.Do(func() {
+		tuned.bs = tuneBlockSize(timeBlockSize)
+	})
+	return tuned.bs
+}
+
+// tuneBlockSize returns the candidate block size for which measure reports
+// the shortest time, taking the fastest of tuneRuns measurements of each.
+// Timings are noisy, so blockSize is kept unless the best candidate beats
+// it by more than tuneMargin.
+func tuneBlockSize(measure func(bs int) time.Duration) int {
+	best := func(bs int) time.Duration {
+		d := measure(bs)
+		for i := 1; i < tuneRuns; i++ {
+			d = minDuration(d, measure(bs))
+		}
+		return d
+	}
+	def := best(blockSize)
+	bs, fastest := blockSize, def
+	for _, c := range tuneCandidates {
+		if c == blockSize {
+			continue
+		}
+		if d := best(c); d < fastest {
+			bs, fastest = c, d
+		}
+	}
+	if float64(fastest) >= (1-tuneMargin)*float64(def) {
+		return blockSize
+	}
+	return bs
+}
+
+// timeBlockSize returns the time taken to multiply two tuneN×tuneN matrices
+// serially in blocks of size bs, the way each worker of Dgemm computes its
+// blocks.
+func timeBlockSize(bs int) time.Duration {
+	a := newGeneral(tuneN, tuneN)
+	b := newGeneral(tuneN, tuneN)
+	c := newGeneral(tuneN, tuneN)
+	for i := range a.data {
+		a.data[i] = float64(i%7) - 3
+		b.data[i] = float64(i%5) - 2
+	}
+	var bl Blas
+	start := time.Now()
+	for i := 0; i < tuneN; i += bs {
+		for j := 0; j < tuneN; j += bs {
+			bl.dgemmBlock(blas.NoTrans, blas.NoTrans, a, b, c, i, j, min(bs, tuneN-i), min(bs, tuneN-j), tuneN, bs, 1)
+		}
+	}
+	return time.Since(start)
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,90 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"testing"
+	"time"
+)
+
+func init() {
+	// The tests expect Dgemm to start from the fixed blockSize, whatever the
+	// hardware they run on, even if GOBLAS_AUTOTUNE is set. Package
+	// variables are initialized before any init function, so this takes
+	// effect before the first Dgemm call.
+	autotune = false
+}
+
+func TestParseAutotune(t *testing.T) {
+	for _, test := range []struct {
+		s    string
+		want bool
+	}{
+		{"", false},
+		{"1", true},
+		{"true", true},
+		{"TRUE", true},
+		{"yes", false},
+		{"0", false},
+		{"false", false},
+		{"FALSE", false},
+	} {
+		if got := parseAutotune(test.s); got != test.want {
+			t.Errorf("GOBLAS_AUTOTUNE=%q: want %v, got %v", test.s, test.want, got)
+		}
+	}
+	if bs := defaultBlockSize(); bs != blockSize {
+		t.Errorf("default block size with autotune disabled: want %v, got %v", blockSize, bs)
+	}
+}
+
+func TestTuneBlockSize(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		times map[int][]time.Duration // per candidate, in the order measured
+		want  int
+	}{
+		{
+			name:  "clear win",
+			times: map[int][]time.Duration{32: {50, 50}, blockSize: {100, 100}},
+			want:  32,
+		},
+		{
+			name:  "within margin",
+			times: map[int][]time.Duration{96: {95, 95}, blockSize: {100, 100}},
+			want:  blockSize,
+		},
+		{
+			name:  "fastest run counts",
+			times: map[int][]time.Duration{128: {300, 60}, blockSize: {100, 500}},
+			want:  128,
+		},
+		{
+			name:  "default fastest",
+			times: map[int][]time.Duration{blockSize: {40, 40}},
+			want:  blockSize,
+		},
+	} {
+		calls := make(map[int]int)
+		measure := func(bs int) time.Duration {
+			defer func() { calls[bs]++ }()
+			if d, ok := test.times[bs]; ok {
+				return d[calls[bs]]
+			}
+			return 100
+		}
+		if got := tuneBlockSize(measure); got != test.want {
+			t.Errorf("%s: want %v, got %v", test.name, test.want, got)
+		}
+		for _, c := range tuneCandidates {
+			if calls[c] != tuneRuns {
+				t.Errorf("%s: block size %v measured %v times, want %v", test.name, c, calls[c], tuneRuns)
+			}
+		}
+	}
+	if d := timeBlockSize(48); d <= 0 {
+		t.Errorf("non-positive time %v", d)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build cblas
+// +build cblas
+
+package goblas
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/cblas"
+)
+
+// The benchmarks in this file compare goblas against the cgo cblas package.
+// They require a C BLAS library and are run with
+//  go test -tags cblas -run NONE -bench Compare github.com/gonum/blas/goblas
+
+// compareImpls are the implementations driven by the comparison benchmarks.
+var compareImpls = []struct {
+	name string
+	impl blas.Float64
+}{
+	{"goblas", Blas{}},
+	{"cblas", cblas.Blas{}},
+}
+
+// compareShapes is the shape matrix run through every implementation.
+var compareShapes = []struct {
+	m, n, k int
+	tA, tB  blas.Transpose
+}{
+	{10, 10, 10, blas.NoTrans, blas.NoTrans},
+	{100, 100, 100, blas.NoTrans, blas.NoTrans},
+	{100, 100, 100, blas.Trans, blas.NoTrans},
+	{100, 100, 100, blas.NoTrans, blas.Trans},
+	{100, 100, 100, blas.Trans, blas.Trans},
+	{200, 200, 200, blas.NoTrans, blas.NoTrans},
+	{500, 500, 500, blas.NoTrans, blas.NoTrans},
+	{1000, 1000, 1000, blas.NoTrans, blas.NoTrans},
+	{1000, 10, 1000, blas.NoTrans, blas.NoTrans},
+	{1000, 1000, 10, blas.NoTrans, blas.NoTrans},
+	{10, 1000, 1000, blas.NoTrans, blas.NoTrans},
+}
+
+// BenchmarkCompareDgemm runs each shape through each implementation and
+// reports the achieved rate in GFLOPS.
+func BenchmarkCompareDgemm(b *testing.B) {
+	for _, s := range compareShapes {
+		for _, impl := range compareImpls {
+			name := fmt.Sprintf("%vx%vx%v/%v%v/%v", s.m, s.n, s.k, transName(s.tA), transName(s.tB), impl.name)
+			b.Run(name, func(b *testing.B) {
+				benchmarkCompareDgemm(b, impl.impl, s.m, s.n, s.k, s.tA, s.tB)
+			})
+		}
+	}
+}
+
+func benchmarkCompareDgemm(b *testing.B, impl blas.Float64, m, n, k int, tA, tB blas.Transpose) {
+	a := make([]float64, m*k)
+	for i := range a {
+		a[i] = rand.Float64()
+	}
+	bv := make([]float64, k*n)
+	for i := range bv {
+		bv[i] = rand.Float64()
+	}
+	c := make([]float64, m*n)
+	for i := range c {
+		c[i] = rand.Float64()
+	}
+	lda := k
+	if tA == blas.Trans {
+		lda = m
+	}
+	ldb := n
+	if tB == blas.Trans {
+		ldb = k
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		impl.Dgemm(tA, tB, m, n, k, 3.0, a, lda, bv, ldb, 1.0, c, n)
+	}
+	b.StopTimer()
+	flops := 2 * float64(m) * float64(n) * float64(k) * float64(b.N)
+	b.ReportMetric(flops/b.Elapsed().Seconds()/1e9, "GFLOPS")
+}
+
+func transName(t blas.Transpose) string {
+	if t == blas.NoTrans {
+		return "N"
+	}
+	return "T"
+}
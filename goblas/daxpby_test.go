@@ -0,0 +1,71 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDaxpby(t *testing.T) {
+	for _, n := range []int{0, 1, 7} {
+		for _, inc := range []struct{ x, y int }{{1, 1}, {2, -1}, {-3, 2}} {
+			for _, ab := range []struct{ alpha, beta float64 }{
+				{2, 0.5}, {0, 0.5}, {2, 0}, {2, 1}, {0, 0}, {0, 1},
+			} {
+				x := randSlice(max(0, (n-1)*abs(inc.x)+1))
+				y := randSlice(max(0, (n-1)*abs(inc.y)+1))
+				if ab.beta == 0 {
+					// y must not be read.
+					for i := range y {
+						y[i] = math.NaN()
+					}
+				}
+				want := append([]float64(nil), y...)
+				for i := 0; i < n; i++ {
+					ix, iy := i*inc.x, i*inc.y
+					if inc.x < 0 {
+						ix = (i - n + 1) * inc.x
+					}
+					if inc.y < 0 {
+						iy = (i - n + 1) * inc.y
+					}
+					switch ab.beta {
+					case 0:
+						want[iy] = ab.alpha * x[ix]
+					default:
+						want[iy] = ab.alpha*x[ix] + ab.beta*y[iy]
+					}
+				}
+				Blasser.Daxpby(n, ab.alpha, x, inc.x, ab.beta, y, inc.y)
+				for i := range y {
+					if math.Abs(y[i]-want[i]) > 1e-15 || math.IsNaN(y[i]) != math.IsNaN(want[i]) {
+						t.Errorf("n = %v, incX = %v, incY = %v, alpha = %v, beta = %v: y[%v] = %v, want %v",
+							n, inc.x, inc.y, ab.alpha, ab.beta, i, y[i], want[i])
+					}
+				}
+			}
+		}
+	}
+
+	// If alpha is zero, x is not referenced.
+	y := []float64{1, 2, 3}
+	Blasser.Daxpby(3, 0, nil, 1, 2, y, 1)
+	for i, want := range []float64{2, 4, 6} {
+		if y[i] != want {
+			t.Errorf("alpha = 0: y[%v] = %v, want %v", i, y[i], want)
+		}
+	}
+
+	for _, f := range []func(){
+		func() { Blasser.Daxpby(-1, 1, nil, 1, 1, nil, 1) },
+		func() { Blasser.Daxpby(1, 1, []float64{1}, 0, 1, []float64{1}, 1) },
+		func() { Blasser.Daxpby(1, 1, []float64{1}, 1, 1, []float64{1}, 0) },
+	} {
+		if !panics(f) {
+			t.Errorf("expected panic")
+		}
+	}
+}
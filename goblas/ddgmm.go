@@ -0,0 +1,99 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+// DdgmmRight computes
+//
+//	A := A * diag(d),
+//
+// scaling column j of the m×n matrix A with stride lda by element j of the
+// n-element vector d with increment incD. As in the BLAS, element 0 of d is
+// d[0] if incD is positive and d[(n-1)*-incD] if it is negative. For large
+// matrices the rows are partitioned among the workers.
+func (bl Blas) DdgmmRight(m, n int, a []float64, lda int, d []float64, incD int) {
+	amat := ddgmmMat(m, n, a, lda, n, d, incD)
+	if m == 0 || n == 0 {
+		return
+	}
+	kd := 0
+	if incD < 0 {
+		kd = -(n - 1) * incD
+	}
+	if incD == 1 {
+		d = d[:n]
+	}
+	bl.parallelRows(m, n, func(i, r int) {
+		for l := i; l < i+r; l++ {
+			atmp := amat.data[l*lda : l*lda+n]
+			if incD == 1 {
+				for j, v := range d {
+					atmp[j] *= v
+				}
+				continue
+			}
+			id := kd
+			for j := range atmp {
+				atmp[j] *= d[id]
+				id += incD
+			}
+		}
+	})
+}
+
+// DdgmmLeft computes
+//
+//	A := diag(d) * A,
+//
+// scaling row i of the m×n matrix A with stride lda by element i of the
+// m-element vector d with increment incD, indexed as for DdgmmRight. For
+// large matrices the rows are partitioned among the workers.
+func (bl Blas) DdgmmLeft(m, n int, a []float64, lda int, d []float64, incD int) {
+	amat := ddgmmMat(m, n, a, lda, m, d, incD)
+	if m == 0 || n == 0 {
+		return
+	}
+	kd := 0
+	if incD < 0 {
+		kd = -(m - 1) * incD
+	}
+	bl.parallelRows(m, n, func(i, r int) {
+		for l := i; l < i+r; l++ {
+			s := d[kd+l*incD]
+			atmp := amat.data[l*lda : l*lda+n]
+			for j := range atmp {
+				atmp[j] *= s
+			}
+		}
+	})
+}
+
+// ddgmmMat checks the parameters of DdgmmRight and DdgmmLeft, where d has
+// lenD elements, and returns A as a general.
+func ddgmmMat(m, n int, a []float64, lda, lenD int, d []float64, incD int) general {
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if incD == 0 {
+		panic(zeroInc)
+	}
+	amat := general{
+		data:   a,
+		rows:   m,
+		cols:   n,
+		stride: lda,
+	}
+	amat.mustCheck()
+	inc := incD
+	if inc < 0 {
+		inc = -inc
+	}
+	if m > 0 && n > 0 && len(d) <= (lenD-1)*inc {
+		panic("goblas: d too short")
+	}
+	return amat
+}
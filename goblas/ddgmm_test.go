@@ -0,0 +1,77 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDdgmm(t *testing.T) {
+	for _, bl := range []Blas{Blasser, New(WithMaxWorkers(4))} {
+		for _, test := range []struct {
+			m, n, pad int
+		}{
+			{0, 3, 0},
+			{3, 0, 1},
+			{1, 1, 0},
+			{4, 5, 2},
+			{300, 250, 1}, // large enough to be partitioned
+		} {
+			for _, incD := range []int{1, 2, -1, -3} {
+				m, n := test.m, test.n
+				a := randmat(m, n, max(1, n+test.pad))
+				for _, right := range []bool{true, false} {
+					lenD := m
+					if right {
+						lenD = n
+					}
+					d := randSlice(max(1, (lenD-1)*abs(incD)+1))
+					// elem returns element i of d.
+					elem := func(i int) float64 {
+						if incD < 0 {
+							return d[(lenD-1-i)*-incD]
+						}
+						return d[i*incD]
+					}
+					got := a.clone()
+					if right {
+						bl.DdgmmRight(m, n, got.data, got.stride, d, incD)
+					} else {
+						bl.DdgmmLeft(m, n, got.data, got.stride, d, incD)
+					}
+					for i := 0; i < m; i++ {
+						for j := 0; j < got.stride; j++ {
+							want := a.data[i*a.stride+j]
+							if j < n {
+								if right {
+									want *= elem(j)
+								} else {
+									want *= elem(i)
+								}
+							}
+							if v := got.data[i*got.stride+j]; math.Abs(v-want) > 1e-15 {
+								t.Errorf("m = %v, n = %v, incD = %v, right = %t: a[%v,%v] = %v, want %v", m, n, incD, right, i, j, v, want)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for _, f := range []func(){
+		func() { Blasser.DdgmmRight(-1, 2, nil, 2, nil, 1) },
+		func() { Blasser.DdgmmLeft(2, -1, nil, 1, nil, 1) },
+		func() { Blasser.DdgmmRight(2, 2, make([]float64, 4), 2, make([]float64, 2), 0) },
+		func() { Blasser.DdgmmRight(2, 2, make([]float64, 4), 1, make([]float64, 2), 1) },
+		func() { Blasser.DdgmmRight(2, 3, make([]float64, 6), 3, make([]float64, 2), 1) },
+		func() { Blasser.DdgmmLeft(3, 2, make([]float64, 6), 2, make([]float64, 4), -2) },
+	} {
+		if !panics(f) {
+			t.Errorf("expected panic")
+		}
+	}
+}
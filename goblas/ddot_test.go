@@ -0,0 +1,55 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestDdotPairwise(t *testing.T) {
+	// Short vectors are summed sequentially, exactly like Ddot.
+	for _, n := range []int{0, 1, 5, pairwiseBlock} {
+		for _, inc := range []struct{ x, y int }{{1, 1}, {2, -1}, {-3, 2}} {
+			x := randSlice(max(0, (n-1)*abs(inc.x)+1))
+			y := randSlice(max(0, (n-1)*abs(inc.y)+1))
+			want := Blasser.Ddot(n, x, inc.x, y, inc.y)
+			got := Blasser.DdotPairwise(n, x, inc.x, y, inc.y)
+			if got != want {
+				t.Errorf("n = %v, incX = %v, incY = %v: mismatch with Ddot. Want %v, got %v", n, inc.x, inc.y, want, got)
+			}
+		}
+	}
+
+	// Longer vectors are more accurate than the sequential sum.
+	const n = 1 << 20
+	x := make([]float64, n)
+	y := make([]float64, n)
+	exact := new(big.Float).SetPrec(256)
+	for i := range x {
+		x[i] = 0.1
+		y[i] = 1
+		exact.Add(exact, big.NewFloat(x[i]*y[i]).SetPrec(256))
+	}
+	want, _ := exact.Float64()
+	for _, inc := range []int{1, -1} {
+		got := Blasser.DdotPairwise(n, x, inc, y, inc)
+		seq := Blasser.Ddot(n, x, inc, y, inc)
+		if errP, errS := math.Abs(got-want), math.Abs(seq-want); errP > errS || errP/want > 1e-15 {
+			t.Errorf("inc = %v: pairwise error %v, sequential error %v", inc, errP/want, errS/want)
+		}
+	}
+
+	for _, f := range []func(){
+		func() { Blasser.DdotPairwise(-1, nil, 1, nil, 1) },
+		func() { Blasser.DdotPairwise(1, []float64{1}, 0, []float64{1}, 1) },
+		func() { Blasser.DdotPairwise(1, []float64{1}, 1, []float64{1}, 0) },
+	} {
+		if !panics(f) {
+			t.Errorf("Expected panic")
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+// TestDgbmv compares Dgbmv with Dgemv on the dense form of the band matrix.
+// The slots of the band storage that lie outside the matrix are set to NaN,
+// so reading any of them spoils the result.
+func TestDgbmv(t *testing.T) {
+	for _, test := range []struct {
+		m, n, kL, kU, lda int
+	}{
+		{1, 1, 0, 0, 1},
+		{5, 5, 1, 2, 4},
+		{8, 3, 1, 0, 2},  // Rows 4 and below lie outside the band.
+		{3, 8, 2, 1, 5},  // Padded stride.
+		{6, 6, 5, 5, 11}, // The band covers the whole matrix.
+		{7, 4, 0, 3, 4},
+	} {
+		m, n, kL, kU, lda := test.m, test.n, test.kL, test.kU, test.lda
+		band := make([]float64, m*lda)
+		dense := make([]float64, m*n)
+		for i := 0; i < m; i++ {
+			for s := 0; s < lda; s++ {
+				j := i - kL + s
+				if s > kL+kU || j < 0 || j >= n {
+					band[i*lda+s] = math.NaN()
+					continue
+				}
+				v := float64(i*n+j+1) / 10
+				band[i*lda+s] = v
+				dense[i*n+j] = v
+			}
+		}
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, inc := range []struct{ x, y int }{{1, 1}, {2, 3}, {-2, 1}, {1, -3}} {
+				for _, sc := range []struct{ alpha, beta float64 }{{1, 0}, {-2, 0.5}, {0, 2}} {
+					lenX, lenY := n, m
+					if tA == blas.Trans {
+						lenX, lenY = m, n
+					}
+					x := randSlice((lenX-1)*abs(inc.x) + 1)
+					y := randSlice((lenY-1)*abs(inc.y) + 1)
+					want := append([]float64(nil), y...)
+					Blasser.Dgemv(tA, m, n, sc.alpha, dense, n, x, inc.x, sc.beta, want, inc.y)
+
+					Blasser.Dgbmv(tA, m, n, kL, kU, sc.alpha, band, lda, x, inc.x, sc.beta, y, inc.y)
+					for i := range y {
+						if math.Abs(y[i]-want[i]) > 1e-13 {
+							t.Errorf("%+v, tA = %c, inc = %+v, %+v: y[%v] = %v, want %v", test, tA, inc, sc, i, y[i], want[i])
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for _, f := range []func(){
+		func() { Blasser.Dgbmv(blas.NoTrans, 3, 3, -1, 1, 1, nil, 3, nil, 1, 0, nil, 1) },
+		func() { Blasser.Dgbmv(blas.NoTrans, 3, 3, 1, -1, 1, nil, 3, nil, 1, 0, nil, 1) },
+		func() { Blasser.Dgbmv(blas.NoTrans, 3, 3, 1, 1, 1, nil, 2, nil, 1, 0, nil, 1) },
+	} {
+		if !panics(f) {
+			t.Errorf("expected panic")
+		}
+	}
+}
+
+// The following benchmarks multiply a 2000×2000 matrix with three
+// diagonals by a vector, as a band matrix with Dgbmv and as a dense matrix
+// with Dgemv. Dgbmv only traverses the band, so it does about 3/2000 of
+// the work.
+
+func BenchmarkDgbmvTridiagonal(b *testing.B) {
+	const n = 2000
+	a := randSlice(n * 3)
+	x := randSlice(n)
+	y := randSlice(n)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Blasser.Dgbmv(blas.NoTrans, n, n, 1, 1, 1, a, 3, x, 1, 0, y, 1)
+	}
+}
+
+func BenchmarkDgemvTridiagonal(b *testing.B) {
+	const n = 2000
+	a := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		for j := max(0, i-1); j < min(n, i+2); j++ {
+			a[i*n+j] = 1
+		}
+	}
+	x := randSlice(n)
+	y := randSlice(n)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Blasser.Dgemv(blas.NoTrans, n, n, 1, a, n, x, 1, 0, y, 1)
+	}
+}
@@ -6,16 +6,24 @@ package goblas
 
 import (
 	"fmt"
-	"runtime"
 	"sync"
+	"time"
 
 	"github.com/gonum/blas"
 )
 
 const (
-	blockSize   = 64 // b x b matrix
+	blockSize   = 64 // default b x b matrix
 	minParBlock = 4  // minimum number of blocks needed to go parallel
 	buffMul     = 4  // how big is the buffer relative to the number of workers
+
+	minParScale = 1 << 16 // minimum number of elements in c needed to scale in parallel
+
+	minBlockSize  = 16 // smallest block size chosen by adaptiveBlockSize
+	blocksPerWork = 4  // number of blocks of c per worker aimed for by adaptiveBlockSize
+
+	pairwiseMinK = 256 // inner dimension above which the dot product kernel sums pairwise
+	dotChunk     = 64  // number of products summed sequentially by dotPairwise
 )
 
 // Dgemm computes c := beta * C + alpha * A * B. If tA or tB is blas.Trans,
@@ -23,8 +31,136 @@ const (
 // m is the number of rows in A or A transpose
 // n is the number of columns in B or B transpose
 // k is the columns of A and rows of B
-func (Blas) Dgemm(tA, tB blas.Transpose, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
-	var amat, bmat, cmat general
+// If m or n is zero, or alpha is zero and beta is one, Dgemm does nothing. If k
+// or alpha is zero, C is only scaled by beta and A and B are not referenced.
+// If beta is one, C is not scaled, so a C that already holds the scaled
+// values may be accumulated into at no extra cost. If beta is zero, C is
+// overwritten without being read, as in the reference BLAS, so C need not be
+// set on input and any NaN or Inf it holds does not propagate.
+// Empty matrices do not reference their data, so a, b or c may be nil when
+// the corresponding matrix has no elements.
+// With A not transposed, B transposed and k longer than 256, the k products
+// that make up each element of C are summed pairwise, so that the rounding
+// error grows as O(log k) rather than O(k). This holds on the serial and the
+// tiled path; with RecursiveDgemm the halves of k are added to C in turn and
+// only a base case longer than 256 is summed pairwise.
+// Dgemm may be called concurrently with matrices C that do not overlap, but
+// concurrent calls that write the same C race, even with beta equal to one;
+// use DgemmAccumulate to sum products from several goroutines into one C.
+func (bl Blas) Dgemm(tA, tB blas.Transpose, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	bl.dgemmCall(tA, tB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+// dgemmCall checks the parameters of Dgemm, computes the multiplication and
+// records its statistics. It returns the plan the multiplication was
+// computed with.
+func (bl Blas) dgemmCall(tA, tB blas.Transpose, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) dgemmPlan {
+	amat, bmat, cmat := dgemmMats(tA, tB, m, n, k, a, lda, b, ldb, c, ldc)
+	if bl.debug && !finite(alpha, beta) {
+		panic(nonFiniteScalar)
+	}
+	if bl.stats == nil {
+		return bl.dgemm(tA, tB, amat, bmat, cmat, alpha, beta)
+	}
+	start := time.Now()
+	plan := bl.dgemm(tA, tB, amat, bmat, cmat, alpha, beta)
+	bl.stats.record(bl.dgemmStats(m, n, k, alpha, start, plan.parallel()))
+	return plan
+}
+
+// dgemm computes c := beta * c + alpha * a * b for checked matrices and
+// returns the plan the multiplication was computed with.
+func (bl Blas) dgemm(tA, tB blas.Transpose, a, b, c general, alpha, beta float64) dgemmPlan {
+	if c.rows == 0 || c.cols == 0 || (alpha == 0 && beta == 1) {
+		return dgemmPlan{}
+	}
+
+	// scale c
+	if beta != 1 {
+		bl.dgemmScale(c, beta)
+	}
+	if a.rows == 0 || a.cols == 0 || alpha == 0 {
+		return dgemmPlan{}
+	}
+
+	return bl.dgemmMul(tA, tB, a, b, c, alpha)
+}
+
+// DgemmTo computes d := beta * C + alpha * A * B, leaving C unchanged. The
+// remaining parameters have the same meaning as for Dgemm. D must be m×n with
+// stride ldd and must not overlap A or B. D may be the same matrix as C, in
+// which case DgemmTo is equivalent to Dgemm.
+func (bl Blas) DgemmTo(d []float64, ldd int, tA, tB blas.Transpose, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	amat, bmat, cmat := dgemmMats(tA, tB, m, n, k, a, lda, b, ldb, c, ldc)
+	dmat := general{
+		data:   d,
+		rows:   m,
+		cols:   n,
+		stride: ldd,
+	}
+	dmat.mustCheck()
+	if bl.debug && !finite(alpha, beta) {
+		panic(nonFiniteScalar)
+	}
+	if bl.stats == nil {
+		bl.dgemmTo(tA, tB, dmat, amat, bmat, cmat, alpha, beta)
+		return
+	}
+	start := time.Now()
+	plan := bl.dgemmTo(tA, tB, dmat, amat, bmat, cmat, alpha, beta)
+	bl.stats.record(bl.dgemmStats(m, n, k, alpha, start, plan.parallel()))
+}
+
+// dgemmTo computes d := beta * c + alpha * a * b for checked matrices and
+// returns the plan the multiplication was computed with.
+func (bl Blas) dgemmTo(tA, tB blas.Transpose, d, a, b, c general, alpha, beta float64) dgemmPlan {
+	if d.rows == 0 || d.cols == 0 {
+		return dgemmPlan{}
+	}
+
+	bl.dgemmScaleTo(d, c, beta)
+	if a.rows == 0 || a.cols == 0 || alpha == 0 {
+		return dgemmPlan{}
+	}
+
+	return bl.dgemmMul(tA, tB, a, b, d, alpha)
+}
+
+// dgemmMats checks the Dgemm parameters and returns a, b and c as generals
+// with the dimensions implied by the transpose flags.
+func dgemmMats(tA, tB blas.Transpose, m, n, k int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int) (amat, bmat, cmat general) {
+	amat, bmat = dgemmOperands(tA, tB, m, n, k, a, lda, b, ldb)
+	cmat = general{
+		data:   c,
+		rows:   m,
+		cols:   n,
+		stride: ldc,
+	}
+	err := cmat.check()
+	if err != nil {
+		panic(dgemmMatError(err, "c", "", "m", "n", blas.NoTrans, cmat))
+	}
+	return amat, bmat, cmat
+}
+
+// dgemmOperands checks the Dgemm parameters other than c and returns a and b
+// as generals with the dimensions implied by the transpose flags.
+func dgemmOperands(tA, tB blas.Transpose, m, n, k int, a []float64, lda int, b []float64, ldb int) (amat, bmat general) {
+	if tA != blas.Trans && tA != blas.NoTrans {
+		panic(badTranspose)
+	}
+	if tB != blas.Trans && tB != blas.NoTrans {
+		panic(badTranspose)
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if k < 0 {
+		panic(kLT0)
+	}
 	if tA == blas.Trans {
 		amat = general{
 			data:   a,
@@ -42,7 +178,7 @@ func (Blas) Dgemm(tA, tB blas.Transpose, m, n, k int, alpha float64, a []float64
 	}
 	err := amat.check()
 	if err != nil {
-		panic(err)
+		panic(dgemmMatError(err, "a", "tA", "k", "m", tA, amat))
 	}
 	if tB == blas.Trans {
 		bmat = general{
@@ -62,39 +198,176 @@ func (Blas) Dgemm(tA, tB blas.Transpose, m, n, k int, alpha float64, a []float64
 
 	err = bmat.check()
 	if err != nil {
-		panic(err)
+		panic(dgemmMatError(err, "b", "tB", "n", "k", tB, bmat))
 	}
-	cmat = general{
-		data:   c,
-		rows:   m,
-		cols:   n,
-		stride: ldc,
+	return amat, bmat
+}
+
+// dgemmMatError returns a panic message for the error err from checking the
+// Dgemm matrix g, stating the stored shape that the transpose flag implies and
+// the stride and length that shape needs. name is the name of the matrix
+// argument, flag the name of its transpose flag, or empty for c, and tRows and
+// tCols the names of the dimensions of g when it is transposed.
+func dgemmMatError(err error, name, flag, tRows, tCols string, t blas.Transpose, g general) string {
+	shape := fmt.Sprintf("%d×%d", g.rows, g.cols)
+	if flag != "" {
+		trans := "NoTrans"
+		if t == blas.Trans {
+			trans = "Trans"
+			shape = fmt.Sprintf("%s×%s = %s", tRows, tCols, shape)
+		}
+		shape += fmt.Sprintf(" (%s = %s)", flag, trans)
 	}
-	err = cmat.check()
-	if err != nil {
-		panic(err)
+	minStride := max(1, g.cols)
+	stride := max(g.stride, minStride)
+	minLen := 0
+	if g.rows > 0 && g.cols > 0 {
+		minLen = (g.rows-1)*stride + g.cols
 	}
-	if tA != blas.Trans && tA != blas.NoTrans {
-		panic(badTranspose)
+	return fmt.Sprintf("goblas: Dgemm: %v: %s is stored as %s, which needs ld%s >= %d and len(%s) >= %d; got ld%s = %d, len(%s) = %d",
+		err, name, shape, name, minStride, name, minLen, name, g.stride, name, len(g.data))
+}
+
+// parallelRows partitions the rows [0, rows) into contiguous ranges and calls
+// f(i, r) concurrently for each range of r rows starting at row i. If the
+// number of elements rows*cols is too small to be worth going parallel, f is
+// called once for the full range. A panic in f is raised again in the
+// calling goroutine after all of the ranges have finished.
+func (bl Blas) parallelRows(rows, cols int, f func(i, r int)) {
+	if rows == 0 {
+		return
 	}
-	if tB != blas.Trans && tB != blas.NoTrans {
-		panic(badTranspose)
+	nWorkers := bl.workers()
+	if rows < nWorkers {
+		nWorkers = rows
+	}
+	if rows*cols < minParScale || nWorkers < 2 {
+		f(0, rows)
+		return
 	}
+	rowsPer := (rows + nWorkers - 1) / nWorkers
 
-	// scale c
-	if beta != 1 {
-		for i := 0; i < m; i++ {
-			ctmp := cmat.data[i*cmat.stride : i*cmat.stride+cmat.cols]
-			for j := range ctmp {
-				ctmp[j] *= beta
-			}
+	var (
+		wg sync.WaitGroup
+		p  firstPanic
+	)
+	for i := 0; i < rows; i += rowsPer {
+		r := rowsPer
+		if i+r > rows {
+			r = rows - i
 		}
+		wg.Add(1)
+		go func(i, r int) {
+			defer wg.Done()
+			defer func() {
+				if v := recover(); v != nil {
+					p.record(v)
+				}
+			}()
+			f(i, r)
+		}(i, r)
 	}
+	wg.Wait()
+	p.repanic()
+}
+
+// firstPanic records the first panic of a group of worker goroutines so
+// that it can be raised again in the goroutine that started them, where the
+// caller can recover it. A panic that is not recovered in a worker would
+// otherwise end the program.
+type firstPanic struct {
+	mu  sync.Mutex
+	set bool
+	v   interface{}
+}
+
+// record records v if no panic has been recorded yet.
+func (p *firstPanic) record(v interface{}) {
+	p.mu.Lock()
+	if !p.set {
+		p.set, p.v = true, v
+	}
+	p.mu.Unlock()
+}
+
+// panicked reports whether a panic has been recorded.
+func (p *firstPanic) panicked() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.set
+}
+
+// repanic panics with the recorded value, if any. It must be called after
+// all of the workers have finished.
+func (p *firstPanic) repanic() {
+	if p.set {
+		panic(p.v)
+	}
+}
 
-	dgemmParallel(tA, tB, amat, bmat, cmat, alpha)
+// dgemmScale computes c := beta * c. If c is large enough, the rows of c
+// are partitioned among the workers and scaled concurrently.
+func (bl Blas) dgemmScale(c general, beta float64) {
+	if c.rows*c.cols < minParScale {
+		// Scale directly so that small calls do not allocate the closure.
+		dgemmScaleSerial(c, beta)
+		return
+	}
+	bl.parallelRows(c.rows, c.cols, func(i, r int) {
+		dgemmScaleSerial(bl.view(c, i, 0, r, c.cols), beta)
+	})
+}
+
+// dgemmScaleSerial computes c := beta * c in serial. If beta is zero, c is
+// set to zero without being read. A contiguous c is scaled in a single loop
+// over all of its elements, which is faster than a loop per row when the
+// rows are short.
+func dgemmScaleSerial(c general, beta float64) {
+	if c.contiguous() {
+		scaleSlice(c.data[:c.rows*c.cols], beta)
+		return
+	}
+	for i := 0; i < c.rows; i++ {
+		scaleSlice(c.data[i*c.stride:i*c.stride+c.cols], beta)
+	}
 }
 
-func dgemmParallel(tA, tB blas.Transpose, a, b, c general, alpha float64) {
+// scaleSlice computes s := beta * s. If beta is zero, s is set to zero
+// without being read.
+func scaleSlice(s []float64, beta float64) {
+	if beta == 0 {
+		for j := range s {
+			s[j] = 0
+		}
+		return
+	}
+	for j := range s {
+		s[j] *= beta
+	}
+}
+
+// dgemmScaleTo computes d := beta * c, in parallel if c is large enough. If
+// beta is zero, d is set to zero and c is not read.
+func (bl Blas) dgemmScaleTo(d, c general, beta float64) {
+	bl.parallelRows(c.rows, c.cols, func(i, r int) {
+		dSub := bl.view(d, i, 0, r, d.cols)
+		cSub := bl.view(c, i, 0, r, c.cols)
+		for l := 0; l < r; l++ {
+			dtmp := dSub.data[l*dSub.stride : l*dSub.stride+dSub.cols]
+			if beta == 0 {
+				for j := range dtmp {
+					dtmp[j] = 0
+				}
+				continue
+			}
+			for j, v := range cSub.data[l*cSub.stride : l*cSub.stride+cSub.cols] {
+				dtmp[j] = beta * v
+			}
+		}
+	})
+}
+
+func (bl Blas) dgemmParallel(tA, tB blas.Transpose, a, b, c general, alpha float64) dgemmPlan {
 	// dgemmParallel computes a parallel matrix multiplication by partitioning
 	// a and b into sub-blocks, and updating c with the multiplication of the sub-block
 	// In all cases,
@@ -103,7 +376,7 @@ func dgemmParallel(tA, tB blas.Transpose, a, b, c general, alpha float64) {
 	//				...
 	//			A_i1	A_i2 ...	A_ij]
 	//
-	// and same for B. All of the submatrix sizes are blockSize*blockSize except
+	// and same for B. All of the submatrix sizes are bs*bs except
 	// at the edges.
 	// In all cases, there is one dimension for each matrix along which
 	// C must be updated sequentially.
@@ -126,115 +399,341 @@ func dgemmParallel(tA, tB blas.Transpose, a, b, c general, alpha float64) {
 	aTrans := tA == blas.Trans
 	bTrans := tB == blas.Trans
 
-	maxKLen, parBlocks := computeNumBlocks(a, b, aTrans, bTrans)
-	if parBlocks < minParBlock {
+	bs := bl.dgemmBlockSize(c.rows, c.cols)
+	maxKLen, parBlocks := computeNumBlocks(a, b, aTrans, bTrans, bs)
+	if parBlocks < minParBlock || forceSerial {
 		// The matrix multiplication is small in the dimensions where it can be
-		// computed concurrently. Just do it in serial.
-		dgemmSerial(tA, tB, a, b, c, alpha)
+		// computed concurrently, or serial computation has been forced. Just
+		// do it in serial.
+		if bl.debug {
+			dgemmCheckDims(aTrans, bTrans, a, b, c)
+		}
+		bl.progress.begin(1)
+		dgemmSerial(tA, tB, a, b, c, alpha, bl.strict)
+		bl.progress.step()
+		return dgemmPlan{workers: 1, blocks: 1}
+	}
+
+	// Each worker computes A_ik B_kj (or the transposed version) for all k
+	// and stores the result in c_ij for the blocks it receives.
+	crows := c.rows
+	ccols := c.cols
+	bl.progress.begin(parBlocks)
+	workers := bl.runBlocks(parBlocks, func(send func(subMul)) {
+		bl.order.blocks(crows, ccols, bs, func(i, j int) {
+			send(subMul{
+				i: i,
+				j: j,
+			})
+		})
+	}, func(sub subMul) {
+		leni := min(bs, crows-sub.i)
+		lenj := min(bs, ccols-sub.j)
+		bl.dgemmBlock(tA, tB, a, b, c, sub.i, sub.j, leni, lenj, maxKLen, bs, alpha)
+		bl.progress.step()
+	})
+	return dgemmPlan{workers: workers, blocks: parBlocks}
+}
+
+// dgemmBlock adds alpha * op(a) * op(b) to the leni×lenj block of c at row i
+// and column j. The k inner products are accumulated bs at a time, so that
+// the sub-blocks of a and b stay in cache. With a not transposed, b
+// transposed and k longer than pairwiseMinK, the products of the k-blocks
+// are summed pairwise, as dgemmSerialNotTrans sums a whole dot product,
+// rather than added to c one after the other.
+func (bl Blas) dgemmBlock(tA, tB blas.Transpose, a, b, c general, i, j, leni, lenj, k, bs int, alpha float64) {
+	cSub := bl.view(c, i, j, leni, lenj)
+	if tA == blas.NoTrans && tB == blas.Trans && k > pairwiseMinK && k > bs {
+		// One scratch block for the sum and one for each level of the
+		// tree below it.
+		nBlocks := (k + bs - 1) / bs
+		var depth int
+		for l := 1; l < nBlocks; l *= 2 {
+			depth++
+		}
+		tmp := make([]general, depth+1)
+		for d := range tmp {
+			tmp[d] = general{data: make([]float64, leni*lenj), rows: leni, cols: lenj, stride: lenj}
+		}
+		bl.dgemmBlockPairwise(tA, tB, a, b, tmp[0], i, j, 0, nBlocks, bs, k, alpha, tmp[1:])
+		for r := 0; r < leni; r++ {
+			ctmp := cSub.data[r*cSub.stride : r*cSub.stride+lenj]
+			for s, v := range tmp[0].data[r*lenj : r*lenj+lenj] {
+				ctmp[s] += v
+			}
+		}
 		return
 	}
+	for l := 0; l < k; l += bs {
+		bl.dgemmBlockK(tA, tB, a, b, cSub, i, j, l, min(bs, k-l), alpha)
+	}
+}
 
-	nWorkers := runtime.GOMAXPROCS(0)
-	if parBlocks < nWorkers {
-		nWorkers = parBlocks
+// dgemmBlockPairwise sets dst to alpha * op(a) * op(b) restricted to the
+// rows and columns of the block of c at row i and column j and to the
+// k-blocks lo <= l < hi of size bs, the last of which may be shorter to end
+// at k. The range of k-blocks is halved recursively as by pairwiseSum, and
+// tmp holds a scratch block for each remaining level of the recursion.
+func (bl Blas) dgemmBlockPairwise(tA, tB blas.Transpose, a, b, dst general, i, j, lo, hi, bs, k int, alpha float64, tmp []general) {
+	if hi-lo == 1 {
+		for r := range dst.data {
+			dst.data[r] = 0
+		}
+		bl.dgemmBlockK(tA, tB, a, b, dst, i, j, lo*bs, min(bs, k-lo*bs), alpha)
+		return
+	}
+	mid := lo + (hi-lo)/2
+	bl.dgemmBlockPairwise(tA, tB, a, b, dst, i, j, lo, mid, bs, k, alpha, tmp)
+	bl.dgemmBlockPairwise(tA, tB, a, b, tmp[0], i, j, mid, hi, bs, k, alpha, tmp[1:])
+	for r, v := range tmp[0].data {
+		dst.data[r] += v
+	}
+}
+
+// dgemmBlockK adds to cSub, the block of c at row i and column j, the
+// product of the corresponding rows of op(a) and columns of op(b) over the
+// lenl inner indices starting at l.
+func (bl Blas) dgemmBlockK(tA, tB blas.Transpose, a, b, cSub general, i, j, l, lenl int, alpha float64) {
+	aTrans := tA == blas.Trans
+	bTrans := tB == blas.Trans
+	var aSub, bSub general
+	if aTrans {
+		aSub = bl.view(a, l, i, lenl, cSub.rows)
+	} else {
+		aSub = bl.view(a, i, l, cSub.rows, lenl)
+	}
+	if bTrans {
+		bSub = bl.view(b, j, l, cSub.cols, lenl)
+	} else {
+		bSub = bl.view(b, l, j, lenl, cSub.cols)
+	}
+
+	if bl.debug {
+		dgemmCheckDims(aTrans, bTrans, aSub, bSub, cSub)
+	}
+	dgemmSerial(tA, tB, aSub, bSub, cSub, alpha, bl.strict)
+}
+
+// runBlocks computes a blocked Level 3 operation concurrently. gen must call
+// send once for each of the nBlocks blocks of the output, and work computes
+// one block. The blocks are passed over a channel to at most bl.workers()
+// worker goroutines. If gen sends every block once, work is never called
+// concurrently for the same block. runBlocks returns when all blocks have
+// been computed. If gen or work panics, runBlocks panics with the same
+// value in the calling goroutine once all of the workers have exited.
+// runBlocks returns the number of worker goroutines it started, or one if it
+// computed the blocks in the calling goroutine.
+//
+// The Level 3 routines share runBlocks so that each bounds its goroutines in
+// the same way; a routine that only updates one triangle of its output
+// supplies a gen that sends only the blocks of that triangle.
+func (bl Blas) runBlocks(nBlocks int, gen func(send func(subMul)), work func(subMul)) int {
+	nWorkers := bl.workers()
+	if nBlocks < nWorkers {
+		nWorkers = nBlocks
+	}
+	if nWorkers < 2 {
+		// A single worker would only add a goroutine and a channel
+		// round-trip per block, so compute the blocks in the calling
+		// goroutine in the order gen produces them.
+		gen(work)
+		return 1
 	}
 	// There is a tradeoff between the workers having to wait for work
 	// and a large buffer making operations slow.
-	buf := buffMul * nWorkers
-	if buf > parBlocks {
-		buf = parBlocks
+	perWorker := buffMul
+	if bl.dispatch != 0 {
+		perWorker = bl.dispatch
+	}
+	buf := perWorker * nWorkers
+	if buf > nBlocks {
+		buf = nBlocks
 	}
 
 	sendChan := make(chan subMul, buf)
 
-	// Launch workers. A worker receives an {i, j} submatrix of c, and computes
-	// A_ik B_ki (or the transposed version) storing the result in c_ij. When the
-	// channel is finally closed, it signals to the waitgroup that it has finished
-	// computing.
-	var wg sync.WaitGroup
+	// Launch workers. When the channel is finally closed, each worker
+	// signals to the waitgroup that it has finished computing. A worker
+	// whose block panics records the panic and keeps draining the channel,
+	// skipping the remaining blocks, so that gen never blocks on a send.
+	var (
+		wg sync.WaitGroup
+		p  firstPanic
+	)
 	for i := 0; i < nWorkers; i++ {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			// Make local copies of otherwise global variables to reduce shared memory.
-			// This has a noticable effect on benchmarks in some cases.
-			alpha := alpha
-			aTrans := aTrans
-			bTrans := bTrans
-			crows := c.rows
-			ccols := c.cols
 			for sub := range sendChan {
-				i := sub.i
-				j := sub.j
-				leni := blockSize
-				if i+leni > crows {
-					leni = crows - i
-				}
-				lenj := blockSize
-				if j+lenj > ccols {
-					lenj = ccols - j
-				}
-				cSub := c.view(i, j, leni, lenj)
-
-				// Compute A_ik B_kj for all k
-				for k := 0; k < maxKLen; k += blockSize {
-					lenk := blockSize
-					if k+lenk > maxKLen {
-						lenk = maxKLen - k
-					}
-					var aSub, bSub general
-					if aTrans {
-						aSub = a.view(k, i, lenk, leni)
-					} else {
-						aSub = a.view(i, k, leni, lenk)
-					}
-					if bTrans {
-						bSub = b.view(j, k, lenj, lenk)
-					} else {
-						bSub = b.view(k, j, lenk, lenj)
-					}
-
-					dgemmSerial(tA, tB, aSub, bSub, cSub, alpha)
+				if p.panicked() {
+					continue
 				}
+				func() {
+					defer func() {
+						if v := recover(); v != nil {
+							p.record(v)
+						}
+					}()
+					work(sub)
+				}()
 			}
 		}()
 	}
 
-	// Send out all of the {i, j} subblocks for computation.
-	for i := 0; i < c.rows; i += blockSize {
-		for j := 0; j < c.cols; j += blockSize {
-			sendChan <- subMul{
-				i: i,
-				j: j,
+	// Send out all of the blocks for computation. The channel is closed
+	// and the workers are waited for even if gen panics, so that the
+	// workers always exit and no longer write to the output when the panic
+	// reaches the caller.
+	func() {
+		defer func() {
+			close(sendChan)
+			wg.Wait()
+		}()
+		gen(func(sub subMul) {
+			sendChan <- sub
+		})
+	}()
+	p.repanic()
+	return nWorkers
+}
+
+type subMul struct {
+	i, j int // index of block
+}
+
+// BlockOrder is the order in which Dgemm dispatches the blocks of C to its
+// workers. The order does not change the result, only which blocks are
+// computed at the same time and so the pattern of memory access.
+type BlockOrder int
+
+const (
+	// RowMajorBlocks dispatches the blocks row by row.
+	RowMajorBlocks BlockOrder = iota
+	// DiagonalBlocks dispatches the blocks by anti-diagonal, starting at the
+	// top left. Blocks that are computed at the same time share few rows
+	// of A and columns of B.
+	DiagonalBlocks
+)
+
+// blocks calls f with the origin of every bs×bs block of an m×n matrix, in
+// the order given by o.
+func (o BlockOrder) blocks(m, n, bs int, f func(i, j int)) {
+	switch o {
+	case RowMajorBlocks:
+		for i := 0; i < m; i += bs {
+			for j := 0; j < n; j += bs {
+				f(i, j)
 			}
 		}
+	case DiagonalBlocks:
+		mb := numBlocks(m, bs)
+		nb := numBlocks(n, bs)
+		for d := 0; d < mb+nb-1; d++ {
+			for bi := max(0, d-nb+1); bi <= min(d, mb-1); bi++ {
+				f(bi*bs, (d-bi)*bs)
+			}
+		}
+	default:
+		panic("goblas: unknown block order")
 	}
-	close(sendChan)
-	wg.Wait()
 }
 
-type subMul struct {
-	i, j int // index of block
+// DgemmWork estimates the cost of a call to bl.Dgemm with the given transpose
+// flags and dimensions without multiplying any matrices. flops is the number
+// of floating point operations of the multiplication, 2*m*n*k. parBlocks
+// depends on the strategy of bl: for TiledDgemm it is the number of
+// sub-blocks of C that Dgemm would compute concurrently, and if it is less
+// than the parallel threshold, Dgemm performs the multiplication serially;
+// for RecursiveDgemm it is the number of base cases. parBlocks is zero if m,
+// n or k is zero, since Dgemm then only scales C. DgemmWork is not given
+// alpha, so the estimate does not allow for Dgemm skipping the
+// multiplication when alpha is zero. If autotuning is enabled with
+// GOBLAS_AUTOTUNE and no block size is set, the first call to DgemmWork with
+// TiledDgemm runs the timing of the autotuner, as the first Dgemm would.
+func (bl Blas) DgemmWork(tA, tB blas.Transpose, m, n, k int) (flops int64, parBlocks int) {
+	if tA != blas.Trans && tA != blas.NoTrans {
+		panic(badTranspose)
+	}
+	if tB != blas.Trans && tB != blas.NoTrans {
+		panic(badTranspose)
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if k < 0 {
+		panic(kLT0)
+	}
+	flops = 2 * int64(m) * int64(n) * int64(k)
+	if m == 0 || n == 0 || k == 0 {
+		return flops, 0
+	}
+	if bl.strategy == RecursiveDgemm {
+		return flops, recursiveBlocks(m, n, k, bl.recursiveBaseSize())
+	}
+	a := general{rows: m, cols: k}
+	if tA == blas.Trans {
+		a.rows, a.cols = k, m
+	}
+	b := general{rows: k, cols: n}
+	if tB == blas.Trans {
+		b.rows, b.cols = n, k
+	}
+	_, parBlocks = computeNumBlocks(a, b, tA == blas.Trans, tB == blas.Trans, bl.dgemmBlockSize(m, n))
+	return flops, parBlocks
 }
 
-// computeNumBlocks says how many blocks there are to compute. maxKLen says the length of the
-// k dimension, parBlocks is the number of blocks that could be computed in parallel
+// dgemmBlockSize returns the block size used to partition an m×n matrix c.
+// An explicitly configured block size is always used, otherwise the size
+// is chosen by adaptiveBlockSize starting from defaultBlockSize.
+func (bl Blas) dgemmBlockSize(m, n int) int {
+	if bl.bs != 0 {
+		return bl.bs
+	}
+	return adaptiveBlockSize(m, n, bl.workers(), defaultBlockSize())
+}
+
+// adaptiveBlockSize returns a block size for an m×n matrix c that gives each of
+// nWorkers about blocksPerWork blocks to compute. Starting from the default
+// block size bs, the size is halved while there are too few blocks, but it is
+// never reduced below minBlockSize so that blocks remain large enough to make
+// good use of the cache. Large matrices therefore keep the default block size.
+func adaptiveBlockSize(m, n, nWorkers, bs int) int {
+	if nWorkers < 2 {
+		return bs
+	}
+	target := blocksPerWork * nWorkers
+	for bs/2 >= minBlockSize && numBlocks(m, bs)*numBlocks(n, bs) < target {
+		bs /= 2
+	}
+	return bs
+}
+
+// numBlocks returns the number of blocks of size bs needed to cover n.
+func numBlocks(n, bs int) int {
+	return (n + bs - 1) / bs
+}
+
+// computeNumBlocks says how many blocks of size bs there are to compute. maxKLen says the
+// length of the k dimension, parBlocks is the number of blocks that could be computed in parallel
 // (the submatrices in i and j). expect is the full number of blocks that will be computed.
-func computeNumBlocks(a, b general, aTrans, bTrans bool) (maxKLen, parBlocks int) {
-	aRowBlocks := a.rows / blockSize
-	if a.rows%blockSize != 0 {
+func computeNumBlocks(a, b general, aTrans, bTrans bool, bs int) (maxKLen, parBlocks int) {
+	aRowBlocks := a.rows / bs
+	if a.rows%bs != 0 {
 		aRowBlocks++
 	}
-	aColBlocks := a.cols / blockSize
-	if a.cols%blockSize != 0 {
+	aColBlocks := a.cols / bs
+	if a.cols%bs != 0 {
 		aColBlocks++
 	}
-	bRowBlocks := b.rows / blockSize
-	if b.rows%blockSize != 0 {
+	bRowBlocks := b.rows / bs
+	if b.rows%bs != 0 {
 		bRowBlocks++
 	}
-	bColBlocks := b.cols / blockSize
-	if b.cols%blockSize != 0 {
+	bColBlocks := b.cols / bs
+	if b.cols%bs != 0 {
 		bColBlocks++
 	}
 
@@ -259,20 +758,49 @@ func computeNumBlocks(a, b general, aTrans, bTrans bool) (maxKLen, parBlocks int
 	return
 }
 
-// dgemmSerial is serial matrix multiply
-func dgemmSerial(tA, tB blas.Transpose, a, b, c general, alpha float64) {
+// dgemmCheckDims panics if the dimensions of a, b and c are inconsistent
+// for the multiplication c += op(a) * op(b).
+func dgemmCheckDims(aTrans, bTrans bool, a, b, c general) {
+	m, k := a.rows, a.cols
+	if aTrans {
+		m, k = k, m
+	}
+	kb, n := b.rows, b.cols
+	if bTrans {
+		kb, n = n, kb
+	}
+	if k != kb {
+		panic("inner dimension mismatch")
+	}
+	if m != c.rows || n != c.cols {
+		panic("outer dimension mismatch")
+	}
+}
+
+// dgemmSerial is serial matrix multiply.
+//
+// Unless strict is true, the three kernels that update rows of c with
+// multiples of rows of b (all transpose cases except a not transposed and b
+// transposed) skip the update exactly when alpha*a[i][l] compares equal to
+// zero. That covers a zero or negative zero element of a and a product that
+// underflows to zero; the elements of b are never tested. A skipped update
+// would only have added zeros to c if b were finite, so skipping changes the
+// result only when b holds an Inf or NaN, which then does not propagate, or
+// when an element of c is -0 and would have become +0. The kernel for a not
+// transposed and b transposed forms dot products and never skips.
+func dgemmSerial(tA, tB blas.Transpose, a, b, c general, alpha float64, strict bool) {
 	switch {
 	case tA == blas.NoTrans && tB == blas.NoTrans:
-		dgemmSerialNotNot(a, b, c, alpha)
+		dgemmSerialNotNot(a, b, c, alpha, strict)
 		return
 	case tA == blas.Trans && tB == blas.NoTrans:
-		dgemmSerialTransNot(a, b, c, alpha)
+		dgemmSerialTransNot(a, b, c, alpha, strict)
 		return
 	case tA == blas.NoTrans && tB == blas.Trans:
-		dgemmSerialNotTrans(a, b, c, alpha)
+		dgemmSerialNotTrans(a, b, c, alpha, strict)
 		return
 	case tA == blas.Trans && tB == blas.Trans:
-		dgemmSerialTransTrans(a, b, c, alpha)
+		dgemmSerialTransTrans(a, b, c, alpha, strict)
 		return
 	default:
 		panic("unreachable")
@@ -280,7 +808,7 @@ func dgemmSerial(tA, tB blas.Transpose, a, b, c general, alpha float64) {
 }
 
 // dgemmSerial where neither a nor b are transposed
-func dgemmSerialNotNot(a, b, c general, alpha float64) {
+func dgemmSerialNotNot(a, b, c general, alpha float64, strict bool) {
 	if debug {
 		if a.cols != b.rows {
 			panic("inner dimension mismatch")
@@ -299,7 +827,7 @@ func dgemmSerialNotNot(a, b, c general, alpha float64) {
 		ctmp := c.data[i*c.stride : i*c.stride+c.cols]
 		for l, v := range a.data[i*a.stride : i*a.stride+a.cols] {
 			tmp := alpha * v
-			if tmp != 0 {
+			if tmp != 0 || strict {
 				for j, w := range b.data[l*b.stride : l*b.stride+b.cols] {
 					ctmp[j] += tmp * w
 				}
@@ -309,7 +837,7 @@ func dgemmSerialNotNot(a, b, c general, alpha float64) {
 }
 
 // dgemmSerial where neither a is transposed and b is not
-func dgemmSerialTransNot(a, b, c general, alpha float64) {
+func dgemmSerialTransNot(a, b, c general, alpha float64, strict bool) {
 	if debug {
 		if a.rows != b.rows {
 			fmt.Println(a.rows, b.rows)
@@ -330,7 +858,7 @@ func dgemmSerialTransNot(a, b, c general, alpha float64) {
 		for i, v := range a.data[l*a.stride : l*a.stride+a.cols] {
 			tmp := alpha * v
 			ctmp := c.data[i*c.stride : i*c.stride+c.cols]
-			if tmp != 0 {
+			if tmp != 0 || strict {
 				for j, w := range btmp {
 					ctmp[j] += tmp * w
 				}
@@ -339,8 +867,10 @@ func dgemmSerialTransNot(a, b, c general, alpha float64) {
 	}
 }
 
-// dgemmSerial where neither a is not transposed and b is
-func dgemmSerialNotTrans(a, b, c general, alpha float64) {
+// dgemmSerial where neither a is not transposed and b is. Each element of c
+// is updated with a dot product, which is summed pairwise if the inner
+// dimension is longer than pairwiseMinK.
+func dgemmSerialNotTrans(a, b, c general, alpha float64, strict bool) {
 	if debug {
 		if a.cols != b.cols {
 			panic("inner dimension mismatch")
@@ -355,6 +885,18 @@ func dgemmSerialNotTrans(a, b, c general, alpha float64) {
 
 	// This style is used instead of the literal [i*stride +j]) is used because
 	// approximately 5 times faster as of go 1.3.
+	if a.cols > pairwiseMinK {
+		// Sum the long dot products pairwise, so that their rounding error
+		// grows as O(log k) rather than O(k).
+		for i := 0; i < a.rows; i++ {
+			atmp := a.data[i*a.stride : i*a.stride+a.cols]
+			ctmp := c.data[i*c.stride : i*c.stride+c.cols]
+			for j := 0; j < b.rows; j++ {
+				ctmp[j] += alpha * dotPairwise(atmp, b.data[j*b.stride:j*b.stride+b.cols])
+			}
+		}
+		return
+	}
 	for i := 0; i < a.rows; i++ {
 		atmp := a.data[i*a.stride : i*a.stride+a.cols]
 		ctmp := c.data[i*c.stride : i*c.stride+c.cols]
@@ -369,8 +911,20 @@ func dgemmSerialNotTrans(a, b, c general, alpha float64) {
 
 }
 
+// dotPairwise returns the dot product of x and y, which have the same length,
+// summed by pairwiseSum with leaves of at most dotChunk products.
+func dotPairwise(x, y []float64) float64 {
+	return pairwiseSum(0, len(x), dotChunk, func(lo, hi int) float64 {
+		var sum float64
+		for i, v := range x[lo:hi] {
+			sum += v * y[lo+i]
+		}
+		return sum
+	})
+}
+
 // dgemmSerial where both are transposed
-func dgemmSerialTransTrans(a, b, c general, alpha float64) {
+func dgemmSerialTransTrans(a, b, c general, alpha float64, strict bool) {
 	if debug {
 		if a.rows != b.cols {
 			panic("inner dimension mismatch")
@@ -388,8 +942,8 @@ func dgemmSerialTransTrans(a, b, c general, alpha float64) {
 	for l := 0; l < a.rows; l++ {
 		for i, v := range a.data[l*a.stride : l*a.stride+a.cols] {
 			ctmp := c.data[i*c.stride : i*c.stride+c.cols]
-			if v != 0 {
-				tmp := alpha * v
+			tmp := alpha * v
+			if tmp != 0 || strict {
 				for j := 0; j < b.rows; j++ {
 					ctmp[j] += tmp * b.data[j*b.stride+l]
 				}
@@ -0,0 +1,78 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import "github.com/gonum/blas"
+
+// Dgemm2 computes the sum of two matrix products
+//
+//	E := alpha * op(A) * op(B) + gamma * op(C) * op(D) + beta * E,
+//
+// where E is m×n with stride lde, op(A) is m×k1 and op(B) is k1×n with
+// transposes tA and tB, and op(C) is m×k2 and op(D) is k2×n with transposes
+// tC and tD. The result is that of Dgemm for A and B followed by Dgemm for
+// C and D with beta one, but E is partitioned into blocks once and each
+// block is scaled by beta and receives both products while it is in cache,
+// so E is traversed and the workers are dispatched once. As for Dgemm, if
+// beta is zero E is not read, and a product whose scalar or inner dimension
+// is zero is not computed and its operands are not referenced.
+//
+// Dgemm2 always partitions E as the TiledDgemm strategy does, and does not
+// record stats.
+func (bl Blas) Dgemm2(tA, tB, tC, tD blas.Transpose, m, n, k1, k2 int, alpha float64, a []float64, lda int, b []float64, ldb int, gamma float64, c []float64, ldc int, d []float64, ldd int, beta float64, e []float64, lde int) {
+	amat, bmat := dgemmOperands(tA, tB, m, n, k1, a, lda, b, ldb)
+	cmat, dmat := dgemmOperands(tC, tD, m, n, k2, c, ldc, d, ldd)
+	emat := general{
+		data:   e,
+		rows:   m,
+		cols:   n,
+		stride: lde,
+	}
+	emat.mustCheck()
+	if bl.debug && !(finite(alpha, beta) && finite(gamma, 0)) {
+		panic(nonFiniteScalar)
+	}
+	first := alpha != 0 && k1 != 0
+	second := gamma != 0 && k2 != 0
+	if m == 0 || n == 0 || (!first && !second && beta == 1) {
+		return
+	}
+
+	bs := bl.dgemmBlockSize(m, n)
+	nBlocks := numBlocks(m, bs) * numBlocks(n, bs)
+	serial := nBlocks < minParBlock || forceSerial
+	if serial {
+		// E is too small to be worth partitioning, so it is computed as
+		// a single block.
+		bs = max(m, max(n, max(k1, k2)))
+	}
+
+	// block computes the leni×lenj block of E at row i and column j.
+	block := func(i, j, leni, lenj int) {
+		if beta != 1 {
+			dgemmScaleSerial(bl.view(emat, i, j, leni, lenj), beta)
+		}
+		if first {
+			bl.dgemmBlock(tA, tB, amat, bmat, emat, i, j, leni, lenj, k1, bs, alpha)
+		}
+		if second {
+			bl.dgemmBlock(tC, tD, cmat, dmat, emat, i, j, leni, lenj, k2, bs, gamma)
+		}
+	}
+	if serial {
+		block(0, 0, m, n)
+		return
+	}
+	bl.runBlocks(nBlocks, func(send func(subMul)) {
+		bl.order.blocks(m, n, bs, func(i, j int) {
+			send(subMul{
+				i: i,
+				j: j,
+			})
+		})
+	}, func(sub subMul) {
+		block(sub.i, sub.j, min(bs, m-sub.i), min(bs, n-sub.j))
+	})
+}
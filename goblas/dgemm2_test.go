@@ -0,0 +1,91 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestDgemm2(t *testing.T) {
+	// opMat returns a random matrix that is rows×cols after the transpose t.
+	opMat := func(t blas.Transpose, rows, cols int) general {
+		if t == blas.Trans {
+			rows, cols = cols, rows
+		}
+		return randmat(rows, cols, cols+1)
+	}
+	for _, bl := range []Blas{
+		Blasser,
+		New(WithBlockSize(8), WithMaxWorkers(4)),
+		New(WithBlockSize(8), WithDebug(true)),
+	} {
+		for _, test := range []struct {
+			m, n, k1, k2       int
+			alpha, gamma, beta float64
+		}{
+			{0, 4, 3, 2, 1, 1, 0},
+			{3, 4, 0, 0, 1, 1, 2},
+			{3, 4, 5, 0, 1, 1, 0},
+			{3, 4, 5, 6, 0, 2, 0.5},
+			{3, 4, 5, 6, 2, -1, 0},
+			{40, 30, 20, 50, 1.5, 0.5, -1},
+			{40, 30, 20, 50, 1, 1, 1},
+		} {
+			for _, tAB := range [][2]blas.Transpose{{blas.NoTrans, blas.NoTrans}, {blas.Trans, blas.NoTrans}} {
+				for _, tCD := range [][2]blas.Transpose{{blas.NoTrans, blas.Trans}, {blas.Trans, blas.Trans}} {
+					m, n, k1, k2 := test.m, test.n, test.k1, test.k2
+					a := opMat(tAB[0], m, k1)
+					b := opMat(tAB[1], k1, n)
+					c := opMat(tCD[0], m, k2)
+					d := opMat(tCD[1], k2, n)
+					e := randmat(m, n, n+2)
+					if test.beta == 0 {
+						for i := range e.data {
+							e.data[i] = math.NaN()
+						}
+					}
+					want := e.clone()
+					Blasser.Dgemm(tAB[0], tAB[1], m, n, k1, test.alpha, a.data, a.stride, b.data, b.stride, test.beta, want.data, want.stride)
+					Blasser.Dgemm(tCD[0], tCD[1], m, n, k2, test.gamma, c.data, c.stride, d.data, d.stride, 1, want.data, want.stride)
+
+					bl.Dgemm2(tAB[0], tAB[1], tCD[0], tCD[1], m, n, k1, k2,
+						test.alpha, a.data, a.stride, b.data, b.stride,
+						test.gamma, c.data, c.stride, d.data, d.stride,
+						test.beta, e.data, e.stride)
+					for i := 0; i < m; i++ {
+						for j := 0; j < e.stride; j++ {
+							got, w := e.data[i*e.stride+j], want.data[i*e.stride+j]
+							if math.Abs(got-w) > 1e-12 || math.IsNaN(got) != math.IsNaN(w) {
+								t.Errorf("%+v, tAB = %c, tCD = %c: e[%d,%d] = %v, want %v", test, tAB, tCD, i, j, got, w)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// The operands of each product are checked against its own inner
+	// dimension.
+	if !panics(func() {
+		Blasser.Dgemm2(blas.NoTrans, blas.NoTrans, blas.NoTrans, blas.NoTrans, 2, 2, 1, 3,
+			1, make([]float64, 2), 1, make([]float64, 2), 2,
+			1, make([]float64, 6), 3, make([]float64, 4), 2,
+			0, make([]float64, 4), 2)
+	}) {
+		t.Errorf("expected panic for short D")
+	}
+	if !panics(func() {
+		New(WithDebug(true)).Dgemm2(blas.NoTrans, blas.NoTrans, blas.NoTrans, blas.NoTrans, 1, 1, 1, 1,
+			1, []float64{1}, 1, []float64{1}, 1,
+			math.NaN(), []float64{1}, 1, []float64{1}, 1,
+			0, []float64{1}, 1)
+	}) {
+		t.Errorf("expected panic for NaN gamma in debug mode")
+	}
+}
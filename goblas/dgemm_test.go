@@ -0,0 +1,595 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestDgemmTo(t *testing.T) {
+	for i, test := range []struct {
+		m, n, k int
+		beta    float64
+	}{
+		{3, 4, 2, 0.5},
+		{3, 4, 2, 0},
+		{3, 4, 2, 1},
+		{blockSize*minParBlock + 1, blockSize + 3, blockSize - 1, -2},
+	} {
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, tB := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				rowA, colA := test.m, test.k
+				if tA == blas.Trans {
+					rowA, colA = colA, rowA
+				}
+				rowB, colB := test.k, test.n
+				if tB == blas.Trans {
+					rowB, colB = colB, rowB
+				}
+				a := randmat(rowA, colA, colA)
+				b := randmat(rowB, colB, colB+2)
+				c := randmat(test.m, test.n, test.n)
+				d := randmat(test.m, test.n, test.n+3)
+				cClone := c.clone()
+				want := c.clone()
+
+				Blasser.DgemmTo(d.data, d.stride, tA, tB, test.m, test.n, test.k, 2.5, a.data, a.stride, b.data, b.stride, test.beta, c.data, c.stride)
+				Blasser.Dgemm(tA, tB, test.m, test.n, test.k, 2.5, a.data, a.stride, b.data, b.stride, test.beta, want.data, want.stride)
+
+				if !c.equal(cClone) {
+					t.Errorf("Case %v: c changed during call to DgemmTo", i)
+				}
+				if !generalEqualWithinAbs(d, want, 1e-12) {
+					t.Errorf("Case %v: answer mismatch for tA = %v, tB = %v", i, tA, tB)
+				}
+			}
+		}
+	}
+}
+
+// generalEqualWithinAbs returns whether the logical elements of a and b are
+// within tol of each other, irrespective of the strides of a and b.
+func generalEqualWithinAbs(a, b general, tol float64) bool {
+	if a.rows != b.rows || a.cols != b.cols {
+		return false
+	}
+	for i := 0; i < a.rows; i++ {
+		for j := 0; j < a.cols; j++ {
+			if math.Abs(a.at(i, j)-b.at(i, j)) > tol {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestDgemmWork(t *testing.T) {
+	for i, test := range []struct {
+		tA, tB    blas.Transpose
+		m, n, k   int
+		flops     int64
+		parBlocks int
+	}{
+		{blas.NoTrans, blas.NoTrans, 3, 4, 2, 48, 1},
+		{blas.Trans, blas.NoTrans, blockSize + 1, blockSize, 7, 2 * (blockSize + 1) * blockSize * 7, 2},
+		{blas.NoTrans, blas.Trans, 3 * blockSize, 2*blockSize + 1, 1, 2 * 3 * blockSize * (2*blockSize + 1), 9},
+		{blas.Trans, blas.Trans, 0, 5, 5, 0, 0},
+		{blas.NoTrans, blas.NoTrans, 3 * blockSize, blockSize, 0, 0, 0},
+	} {
+		flops, parBlocks := New(WithBlockSize(blockSize)).DgemmWork(test.tA, test.tB, test.m, test.n, test.k)
+		if flops != test.flops {
+			t.Errorf("Case %v: flops mismatch. Want %v, got %v", i, test.flops, flops)
+		}
+		if parBlocks != test.parBlocks {
+			t.Errorf("Case %v: parBlocks mismatch. Want %v, got %v", i, test.parBlocks, parBlocks)
+		}
+	}
+
+	// With RecursiveDgemm, parBlocks is the number of base cases, which
+	// DgemmInfo reports as blocks.
+	bl := New(WithDgemmStrategy(RecursiveDgemm), WithBlockSize(16))
+	m, n, k := 70, 40, 50
+	_, parBlocks := bl.DgemmWork(blas.NoTrans, blas.NoTrans, m, n, k)
+	a, b, c := randmat(m, k, k), randmat(k, n, n), randmat(m, n, n)
+	if _, _, blocks := bl.DgemmInfo(blas.NoTrans, blas.NoTrans, m, n, k, 1, a.data, a.stride, b.data, b.stride, 0, c.data, c.stride); parBlocks != blocks {
+		t.Errorf("recursive: parBlocks = %v, want %v", parBlocks, blocks)
+	}
+}
+
+// TestDgemmPaddedStrides checks that Dgemm gives the same result when a, b and c
+// are sub-blocks of larger arrays as when they are tightly packed. The padding
+// is filled with NaN so that any read of it corrupts the answer.
+func TestDgemmPaddedStrides(t *testing.T) {
+	for i, test := range []struct {
+		m, n, k int
+	}{
+		{3, 4, 2},
+		{blockSize + 3, blockSize - 1, blockSize*2 + 5},
+		{blockSize*minParBlock + 1, blockSize*2 + 3, blockSize + 5},
+		{blockSize*2 + 7, blockSize*minParBlock - 1, 13},
+	} {
+		for _, pad := range []int{1, 5, blockSize + 1} {
+			for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				for _, tB := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+					rowA, colA := test.m, test.k
+					if tA == blas.Trans {
+						rowA, colA = colA, rowA
+					}
+					rowB, colB := test.k, test.n
+					if tB == blas.Trans {
+						rowB, colB = colB, rowB
+					}
+					a := randmat(rowA, colA, colA)
+					b := randmat(rowB, colB, colB)
+					c := randmat(test.m, test.n, test.n)
+					aPad := padGeneral(a, pad)
+					bPad := padGeneral(b, 2*pad)
+					cPad := padGeneral(c, pad+1)
+
+					Blasser.Dgemm(tA, tB, test.m, test.n, test.k, 2.5, a.data, a.stride, b.data, b.stride, 0.5, c.data, c.stride)
+					Blasser.Dgemm(tA, tB, test.m, test.n, test.k, 2.5, aPad.data, aPad.stride, bPad.data, bPad.stride, 0.5, cPad.data, cPad.stride)
+
+					if !generalEqualWithinAbs(c, cPad, 1e-12) {
+						t.Errorf("Case %v: answer mismatch for pad = %v, tA = %v, tB = %v", i, pad, tA, tB)
+					}
+					for r := 0; r < cPad.rows-1; r++ {
+						for _, v := range cPad.data[r*cPad.stride+cPad.cols : (r+1)*cPad.stride] {
+							if !math.IsNaN(v) {
+								t.Errorf("Case %v: padding of c modified for pad = %v, tA = %v, tB = %v", i, pad, tA, tB)
+								break
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// padGeneral returns a copy of g with stride g.cols+pad. The padding elements
+// are set to NaN and the data slice has no trailing padding after the last row.
+func padGeneral(g general, pad int) general {
+	stride := g.cols + pad
+	data := make([]float64, (g.rows-1)*stride+g.cols)
+	for i := range data {
+		data[i] = math.NaN()
+	}
+	for i := 0; i < g.rows; i++ {
+		copy(data[i*stride:i*stride+g.cols], g.data[i*g.stride:i*g.stride+g.cols])
+	}
+	return general{
+		data:   data,
+		rows:   g.rows,
+		cols:   g.cols,
+		stride: stride,
+	}
+}
+
+func TestDgemmZeroDims(t *testing.T) {
+	for i, test := range []struct {
+		m, n, k int
+	}{
+		{0, 0, 0},
+		{0, 3, 4},
+		{3, 0, 4},
+		{3, 4, 0},
+		{blockSize * minParBlock, blockSize * minParBlock, 0},
+	} {
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, tB := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				var a, b []float64
+				if test.k != 0 {
+					a = make([]float64, test.m*test.k)
+					b = make([]float64, test.k*test.n)
+				}
+				lda := max(1, test.k)
+				if tA == blas.Trans {
+					lda = max(1, test.m)
+				}
+				ldb := max(1, test.n)
+				if tB == blas.Trans {
+					ldb = max(1, test.k)
+				}
+				c := make([]float64, test.m*test.n)
+				for j := range c {
+					c[j] = float64(j)
+				}
+				Blasser.Dgemm(tA, tB, test.m, test.n, test.k, 2, a, lda, b, ldb, 0.5, c, max(1, test.n))
+				for j, v := range c {
+					if v != 0.5*float64(j) {
+						t.Errorf("Case %v: unexpected c for tA = %v, tB = %v", i, tA, tB)
+						break
+					}
+				}
+			}
+		}
+	}
+	if !panics(func() { Blasser.Dgemm(blas.NoTrans, blas.NoTrans, -1, 0, 0, 1, nil, 1, nil, 1, 1, nil, 1) }) {
+		t.Errorf("Expected panic for m < 0")
+	}
+}
+
+// panics returns whether f panics.
+func panics(f func()) (b bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			b = true
+		}
+	}()
+	f()
+	return
+}
+
+func TestDgemmAlphaZero(t *testing.T) {
+	const m, n, k = 5, 4, 3
+	a := make([]float64, m*k)
+	b := make([]float64, k*n)
+	for i := range a {
+		a[i] = math.NaN()
+	}
+	for i := range b {
+		b[i] = math.Inf(1)
+	}
+	for _, beta := range []float64{1, 0.5} {
+		c := make([]float64, m*n)
+		for i := range c {
+			c[i] = float64(i)
+		}
+		Blasser.Dgemm(blas.NoTrans, blas.NoTrans, m, n, k, 0, a, k, b, n, beta, c, n)
+		for i, v := range c {
+			if v != beta*float64(i) {
+				t.Errorf("Unexpected c for alpha = 0, beta = %v. Want %v, got %v", beta, beta*float64(i), v)
+				break
+			}
+		}
+	}
+	if !panics(func() {
+		Blasser.Dgemm(blas.NoTrans, blas.NoTrans, m, n, k, 0, a[:1], k, b, n, 1, make([]float64, m*n), n)
+	}) {
+		t.Errorf("Expected panic for short a with alpha = 0, beta = 1")
+	}
+}
+
+// TestDgemmBeta documents the result of Dgemm and DgemmTo for each
+// combination of beta and initial C, with P = A * B:
+//
+//	            C = 0    C = NaN   C arbitrary
+//	beta = 0    P        P         P
+//	beta = 1    P        NaN       C + P
+//	beta = 0.5  P        NaN       0.5*C + P
+//	beta = -1   P        NaN       -C + P
+//
+// Passing beta = 0 with a C that is already zero is therefore equivalent to
+// passing beta = 1, and is how a scratch C of unknown contents is cleared.
+func TestDgemmBeta(t *testing.T) {
+	for _, dims := range []struct{ m, n, k int }{
+		{3, 4, 2},
+		// Large enough to scale C in parallel.
+		{minParScale / 128, 128, 1},
+	} {
+		m, n, k := dims.m, dims.n, dims.k
+		// Small integers keep P exact, so that every cell can be compared
+		// for equality.
+		a := make([]float64, m*k)
+		for i := range a {
+			a[i] = float64(i%5 - 2)
+		}
+		b := make([]float64, k*n)
+		for i := range b {
+			b[i] = float64(i%3 + 1)
+		}
+		p := make([]float64, m*n)
+		Blasser.DgemmReference(blas.NoTrans, blas.NoTrans, m, n, k, 1, a, k, b, n, 0, p, n)
+
+		for _, init := range []string{"zero", "NaN", "arbitrary"} {
+			for _, beta := range []float64{0, 1, 0.5, -1} {
+				c0 := make([]float64, m*n)
+				for i := range c0 {
+					switch init {
+					case "NaN":
+						c0[i] = math.NaN()
+					case "arbitrary":
+						c0[i] = float64(i%7) - 3
+					}
+				}
+				want := make([]float64, m*n)
+				for i := range want {
+					if beta == 0 {
+						want[i] = p[i]
+					} else {
+						want[i] = beta*c0[i] + p[i]
+					}
+				}
+				c := make([]float64, m*n)
+				copy(c, c0)
+				Blasser.Dgemm(blas.NoTrans, blas.NoTrans, m, n, k, 1, a, k, b, n, beta, c, n)
+				d := make([]float64, m*n)
+				for i := range d {
+					d[i] = math.NaN()
+				}
+				Blasser.DgemmTo(d, n, blas.NoTrans, blas.NoTrans, m, n, k, 1, a, k, b, n, beta, c0, n)
+				for name, got := range map[string][]float64{"Dgemm": c, "DgemmTo": d} {
+					for i, v := range got {
+						if v != want[i] && !(math.IsNaN(v) && math.IsNaN(want[i])) {
+							t.Errorf("%s, m = %d, beta = %v, C %s: mismatch at %d: got %v, want %v", name, m, beta, init, i, v, want[i])
+							break
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestDgemmNotTransAccuracy checks that the dot products of the A * B^T
+// kernel are summed pairwise for a long inner dimension, on the serial path,
+// on the tiled path across the k-blocks and in a recursive base case. The
+// crafted case sums k copies of 0.1 into every element of C, whose exact sum
+// k*0.1 is representable since k is a power of two. A sequential sum
+// accumulates a rounding error at each of the k additions.
+func TestDgemmNotTransAccuracy(t *testing.T) {
+	const k = 1 << 18
+	var naive float64
+	for i := 0; i < k; i++ {
+		naive += 0.1
+	}
+	want := 0.1 * k
+	naiveErr := math.Abs(naive - want)
+	tiled := New(WithBlockSize(2))
+	tiled.nWorkers = 4
+	for _, test := range []struct {
+		bl       Blas
+		m        int
+		parallel bool
+	}{
+		{Blasser, 1, false},
+		{tiled, 4, true},
+		{New(WithDgemmStrategy(RecursiveDgemm), WithBlockSize(k)), 1, false},
+	} {
+		a := make([]float64, test.m*k)
+		b := make([]float64, test.m*k)
+		for i := range a {
+			a[i] = 1
+			b[i] = 0.1
+		}
+		c := make([]float64, test.m*test.m)
+		plan := test.bl.dgemmCall(blas.NoTrans, blas.Trans, test.m, test.m, k, 1, a, k, b, k, 0, c, test.m)
+		if plan.parallel() != test.parallel {
+			t.Errorf("%+v: parallel = %v, want %v", test.bl, plan.parallel(), test.parallel)
+		}
+		for _, v := range c {
+			if err := math.Abs(v - want); err > naiveErr/1000 {
+				t.Errorf("%+v: error %v is not much smaller than sequential error %v", test.bl, err, naiveErr)
+				break
+			}
+		}
+	}
+}
+
+func TestAdaptiveBlockSize(t *testing.T) {
+	for _, test := range []struct {
+		m, n, nWorkers int
+		want           int
+	}{
+		// Serial execution keeps the default.
+		{200, 200, 1, blockSize},
+		// Mid-sized matrices are split into enough blocks for every worker.
+		{200, 200, 8, 32},
+		{100, 100, 8, 16},
+		{200, 200, 2, blockSize},
+		// Block sizes are not reduced below the minimum.
+		{10, 10, 8, minBlockSize},
+		// Large matrices keep the default.
+		{1000, 1000, 8, blockSize},
+		{2000, 100, 8, blockSize},
+	} {
+		bs := adaptiveBlockSize(test.m, test.n, test.nWorkers, blockSize)
+		if bs != test.want {
+			t.Errorf("m = %v, n = %v, nWorkers = %v: block size mismatch. Want %v, got %v",
+				test.m, test.n, test.nWorkers, test.want, bs)
+		}
+	}
+}
+
+func TestBlockOrder(t *testing.T) {
+	for _, o := range []BlockOrder{RowMajorBlocks, DiagonalBlocks} {
+		for _, test := range []struct{ m, n, bs int }{
+			{0, 5, 2},
+			{1, 1, 4},
+			{7, 3, 2},
+			{3, 7, 2},
+			{10, 10, 3},
+			{64, 16, 16},
+		} {
+			seen := make(map[[2]int]int)
+			o.blocks(test.m, test.n, test.bs, func(i, j int) {
+				if i%test.bs != 0 || j%test.bs != 0 || i >= test.m || j >= test.n {
+					t.Errorf("order %v, m = %v, n = %v, bs = %v: bad block origin {%v, %v}", o, test.m, test.n, test.bs, i, j)
+				}
+				seen[[2]int{i, j}]++
+			})
+			want := numBlocks(test.m, test.bs) * numBlocks(test.n, test.bs)
+			if len(seen) != want {
+				t.Errorf("order %v, m = %v, n = %v, bs = %v: block count mismatch. Want %v, got %v", o, test.m, test.n, test.bs, want, len(seen))
+			}
+			for b, count := range seen {
+				if count != 1 {
+					t.Errorf("order %v, m = %v, n = %v, bs = %v: block %v visited %v times", o, test.m, test.n, test.bs, b, count)
+				}
+			}
+		}
+	}
+	if !panics(func() { WithBlockOrder(-1) }) {
+		t.Errorf("Expected panic for unknown block order")
+	}
+}
+
+func TestDgemmMatError(t *testing.T) {
+	for _, test := range []struct {
+		f    func()
+		want string
+	}{
+		{
+			// op(A) = A^T is 3×5, so A is stored as 5×3 and needs lda >= 3.
+			f: func() {
+				Blasser.Dgemm(blas.Trans, blas.NoTrans, 3, 2, 5, 1, make([]float64, 15), 2, make([]float64, 10), 2, 0, make([]float64, 6), 2)
+			},
+			want: "goblas: Dgemm: general: illegal stride: a is stored as k×m = 5×3 (tA = Trans), which needs lda >= 3 and len(a) >= 15; got lda = 2, len(a) = 15",
+		},
+		{
+			f: func() {
+				Blasser.Dgemm(blas.NoTrans, blas.Trans, 3, 2, 5, 1, make([]float64, 15), 5, make([]float64, 9), 5, 0, make([]float64, 6), 2)
+			},
+			want: "goblas: Dgemm: general: insufficient length: b is stored as n×k = 2×5 (tB = Trans), which needs ldb >= 5 and len(b) >= 10; got ldb = 5, len(b) = 9",
+		},
+		{
+			f: func() {
+				Blasser.Dgemm(blas.NoTrans, blas.NoTrans, 3, 2, 5, 1, make([]float64, 15), 5, make([]float64, 10), 2, 0, make([]float64, 6), 1)
+			},
+			want: "goblas: Dgemm: general: illegal stride: c is stored as 3×2, which needs ldc >= 2 and len(c) >= 6; got ldc = 1, len(c) = 6",
+		},
+	} {
+		got := panicMessage(test.f)
+		if got != test.want {
+			t.Errorf("panic message mismatch.\nWant %q\ngot  %q", test.want, got)
+		}
+	}
+}
+
+// panicMessage returns the value f panics with as a string, or the empty
+// string if f does not panic.
+func panicMessage(f func()) (msg string) {
+	defer func() {
+		if r := recover(); r != nil {
+			msg = fmt.Sprint(r)
+		}
+	}()
+	f()
+	return ""
+}
+
+// TestDgemmDifferential compares Dgemm in several configurations against
+// DgemmReference for random shapes, transposes and padded strides.
+func TestDgemmDifferential(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	impls := []struct {
+		name string
+		bl   Blas
+	}{
+		{"default", Blasser},
+		{"bs=3", New(WithBlockSize(3))},
+		{"bs=16,diagonal", New(WithBlockSize(16), WithBlockOrder(DiagonalBlocks))},
+		{"strict,debug", New(WithStrictIEEE(true), WithDebug(true))},
+		{"recursive", New(WithDgemmStrategy(RecursiveDgemm))},
+		{"recursive,bs=5,debug", New(WithDgemmStrategy(RecursiveDgemm), WithBlockSize(5), WithDebug(true))},
+		{"recursive,bs=7,workers=3", New(WithDgemmStrategy(RecursiveDgemm), WithBlockSize(7), WithMaxWorkers(3))},
+	}
+	nCases := 200
+	if testing.Short() {
+		nCases = 20
+	}
+	for c := 0; c < nCases; c++ {
+		m := rnd.Intn(80)
+		n := rnd.Intn(80)
+		k := rnd.Intn(80)
+		tA := []blas.Transpose{blas.NoTrans, blas.Trans}[rnd.Intn(2)]
+		tB := []blas.Transpose{blas.NoTrans, blas.Trans}[rnd.Intn(2)]
+		alpha := []float64{0, 1, -2.5, rnd.NormFloat64()}[rnd.Intn(4)]
+		beta := []float64{0, 1, 0.5, rnd.NormFloat64()}[rnd.Intn(4)]
+		rowA, colA := m, k
+		if tA == blas.Trans {
+			rowA, colA = k, m
+		}
+		rowB, colB := k, n
+		if tB == blas.Trans {
+			rowB, colB = n, k
+		}
+		a := randmatPad(rnd, rowA, colA, rnd.Intn(3))
+		b := randmatPad(rnd, rowB, colB, rnd.Intn(3))
+		c0 := randmatPad(rnd, m, n, rnd.Intn(3))
+		want := c0.clone()
+		Blasser.DgemmReference(tA, tB, m, n, k, alpha, a.data, a.stride, b.data, b.stride, beta, want.data, want.stride)
+		for _, impl := range impls {
+			got := c0.clone()
+			impl.bl.Dgemm(tA, tB, m, n, k, alpha, a.data, a.stride, b.data, b.stride, beta, got.data, got.stride)
+			if !generalEqualWithinAbs(got, want, 1e-12) {
+				t.Errorf("%v: m = %v, n = %v, k = %v, tA = %v, tB = %v, alpha = %v, beta = %v: mismatch with DgemmReference",
+					impl.name, m, n, k, tA, tB, alpha, beta)
+			}
+		}
+	}
+}
+
+// randmatPad returns a random r×c general with stride c+pad.
+func randmatPad(rnd *rand.Rand, r, c, pad int) general {
+	stride := max(1, c+pad)
+	data := make([]float64, r*stride)
+	for i := range data {
+		data[i] = rnd.NormFloat64()
+	}
+	return general{
+		data:   data,
+		rows:   r,
+		cols:   c,
+		stride: stride,
+	}
+}
+
+func TestRunBlocksSingleWorker(t *testing.T) {
+	// With one worker, the blocks are computed in the calling goroutine in
+	// the order they are generated.
+	var got []int
+	New(WithMaxWorkers(1)).runBlocks(10, func(send func(subMul)) {
+		for i := 9; i >= 0; i-- {
+			send(subMul{i: i})
+		}
+	}, func(sub subMul) {
+		got = append(got, sub.i)
+	})
+	for k, i := range got {
+		if i != 9-k {
+			t.Fatalf("blocks computed out of order: %v", got)
+		}
+	}
+	if len(got) != 10 {
+		t.Errorf("computed %v blocks, want 10", len(got))
+	}
+}
+
+func TestRunBlocksWorkers(t *testing.T) {
+	// runBlocks reports the number of workers it started, which is bounded
+	// by both the configured workers and the number of blocks.
+	for _, test := range []struct {
+		workers, blocks, want int
+	}{
+		{1, 10, 1},
+		{4, 10, 4},
+		{4, 3, 3},
+		{4, 1, 1},
+		{4, 0, 1},
+	} {
+		bl := Blas{nWorkers: test.workers}
+		var computed int64
+		got := bl.runBlocks(test.blocks, func(send func(subMul)) {
+			for i := 0; i < test.blocks; i++ {
+				send(subMul{i: i})
+			}
+		}, func(subMul) {
+			atomic.AddInt64(&computed, 1)
+		})
+		if got != test.want {
+			t.Errorf("workers = %v, blocks = %v: runBlocks started %v workers, want %v", test.workers, test.blocks, got, test.want)
+		}
+		if int(computed) != test.blocks {
+			t.Errorf("workers = %v, blocks = %v: computed %v blocks", test.workers, test.blocks, computed)
+		}
+	}
+}
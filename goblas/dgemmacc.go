@@ -0,0 +1,47 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"sync"
+
+	"github.com/gonum/blas"
+)
+
+// DgemmAccumulate computes C += alpha * A * B where C may be updated by other
+// goroutines at the same time, as when several producers each add a partial
+// product alpha_i * A_i * B_i into one C. The parameters other than mu have
+// the same meaning as for Dgemm with beta equal to one.
+//
+// Concurrent Dgemm calls that write the same C race, even with beta equal to
+// one, because each reads and writes every element of C without
+// synchronization. DgemmAccumulate instead computes the product into a
+// temporary m×n matrix, concurrently with the other callers, and only holds
+// mu while adding it into C. Every goroutine that writes C at the same time
+// must hold the same mu while doing so, for example by calling
+// DgemmAccumulate with it. C must be scaled, if at all, before the producers
+// start.
+//
+// The product takes O(m*n*k) time outside the lock and the addition O(m*n)
+// time under it, at the cost of allocating m*n elements per call. If m, n,
+// k or alpha is zero, DgemmAccumulate returns without taking the lock.
+func (bl Blas) DgemmAccumulate(mu sync.Locker, tA, tB blas.Transpose, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, c []float64, ldc int) {
+	amat, bmat, cmat := dgemmMats(tA, tB, m, n, k, a, lda, b, ldb, c, ldc)
+	if mu == nil {
+		panic("goblas: nil lock")
+	}
+	if bl.debug && !finite(alpha, 1) {
+		panic(nonFiniteScalar)
+	}
+	if m == 0 || n == 0 || k == 0 || alpha == 0 {
+		return
+	}
+	p := newGeneral(m, n)
+	bl.dgemmMul(tA, tB, amat, bmat, p, alpha)
+
+	mu.Lock()
+	defer mu.Unlock()
+	cmat.add(p)
+}
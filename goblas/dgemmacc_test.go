@@ -0,0 +1,71 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestDgemmAccumulate(t *testing.T) {
+	const producers = 8
+	for _, test := range []struct {
+		tA, tB  blas.Transpose
+		m, n, k int
+	}{
+		{blas.NoTrans, blas.NoTrans, 3, 4, 5},
+		{blas.Trans, blas.NoTrans, 70, 90, 33},
+		{blas.NoTrans, blas.Trans, 130, 129, 17},
+		{blas.Trans, blas.Trans, 6, 1, 0},
+	} {
+		m, n, k := test.m, test.n, test.k
+		as := make([]general, producers)
+		bs := make([]general, producers)
+		c := randmat(m, n, n+1)
+		want := c.clone()
+		for p := range as {
+			as[p] = randmat(m, k, k+1)
+			if test.tA == blas.Trans {
+				as[p] = randmat(k, m, m+1)
+			}
+			bs[p] = randmat(k, n, n+1)
+			if test.tB == blas.Trans {
+				bs[p] = randmat(n, k, k+1)
+			}
+			alpha := float64(p) - 2.5
+			Blasser.DgemmReference(test.tA, test.tB, m, n, k, alpha, as[p].data, as[p].stride, bs[p].data, bs[p].stride, 1, want.data, want.stride)
+		}
+
+		var (
+			mu sync.Mutex
+			wg sync.WaitGroup
+		)
+		for p := range as {
+			wg.Add(1)
+			go func(p int) {
+				defer wg.Done()
+				alpha := float64(p) - 2.5
+				Blasser.DgemmAccumulate(&mu, test.tA, test.tB, m, n, k, alpha, as[p].data, as[p].stride, bs[p].data, bs[p].stride, c.data, c.stride)
+			}(p)
+		}
+		wg.Wait()
+		if !generalEqualWithinAbs(c, want, 1e-12) {
+			t.Errorf("%+v: accumulated C mismatch", test)
+		}
+		// The padding of C is not written.
+		for i := 0; i < m; i++ {
+			if c.data[i*c.stride+n] != want.data[i*want.stride+n] {
+				t.Errorf("%+v: padding of row %v modified", test, i)
+			}
+		}
+	}
+	if !panics(func() {
+		Blasser.DgemmAccumulate(nil, blas.NoTrans, blas.NoTrans, 1, 1, 1, 1, []float64{1}, 1, []float64{1}, 1, []float64{1}, 1)
+	}) {
+		t.Errorf("no panic for nil lock")
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"fmt"
+
+	"github.com/gonum/blas"
+)
+
+// BatchPolicy says what a batched routine does with an element of the batch
+// whose matrices are invalid.
+type BatchPolicy int
+
+const (
+	// BatchPanic panics on the first invalid element before any element
+	// is computed.
+	BatchPanic BatchPolicy = iota
+	// BatchSkip computes the valid elements, leaves the matrices of the
+	// invalid ones unchanged and reports their indices.
+	BatchSkip
+)
+
+// DgemmBatch computes
+//
+//	C_i := beta * C_i + alpha * op(A_i) * op(B_i)
+//
+// for each element i of the batch as, bs, cs, with the transposes,
+// dimensions and strides shared by all elements as in Dgemm. The elements
+// are computed one after the other, each with the workers of bl as for
+// Dgemm. C_i must not overlap the matrices of another element.
+//
+// The parameters shared by the batch are checked first and DgemmBatch panics
+// if they are invalid, or if as, bs and cs have different lengths, whatever
+// the policy. The matrices of each element are then checked against the
+// shared dimensions and strides before any element is computed. With
+// BatchPanic, DgemmBatch panics with a message naming the first invalid
+// element. With BatchSkip, the invalid elements are skipped and their
+// indices are returned in increasing order; failed is nil if every element
+// is valid.
+func (bl Blas) DgemmBatch(tA, tB blas.Transpose, m, n, k int, alpha float64, as [][]float64, lda int, bs [][]float64, ldb int, beta float64, cs [][]float64, ldc int, policy BatchPolicy) (failed []int) {
+	if policy != BatchPanic && policy != BatchSkip {
+		panic("goblas: unknown batch policy")
+	}
+	if len(as) != len(bs) || len(as) != len(cs) {
+		panic("goblas: as, bs and cs have different lengths")
+	}
+	dgemmBatchShared(tA, tB, m, n, k, lda, ldb, ldc)
+
+	type mats struct{ a, b, c general }
+	batch := make([]mats, len(as))
+	valid := make([]bool, len(as))
+	for i := range as {
+		a, b, c, err := dgemmBatchElem(tA, tB, m, n, k, as[i], lda, bs[i], ldb, cs[i], ldc)
+		if err == nil {
+			batch[i] = mats{a, b, c}
+			valid[i] = true
+			continue
+		}
+		if policy == BatchPanic {
+			panic(fmt.Sprintf("goblas: DgemmBatch: element %d: %v", i, err))
+		}
+		failed = append(failed, i)
+	}
+	if bl.debug && !finite(alpha, beta) {
+		panic(nonFiniteScalar)
+	}
+
+	for i, e := range batch {
+		if valid[i] {
+			bl.dgemm(tA, tB, e.a, e.b, e.c, alpha, beta)
+		}
+	}
+	return failed
+}
+
+// dgemmBatchShared panics if the parameters that DgemmBatch shares among
+// the elements of the batch are invalid.
+func dgemmBatchShared(tA, tB blas.Transpose, m, n, k, lda, ldb, ldc int) {
+	if tA != blas.Trans && tA != blas.NoTrans {
+		panic(badTranspose)
+	}
+	if tB != blas.Trans && tB != blas.NoTrans {
+		panic(badTranspose)
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if k < 0 {
+		panic(kLT0)
+	}
+	aCols, bCols := k, n
+	if tA == blas.Trans {
+		aCols = m
+	}
+	if tB == blas.Trans {
+		bCols = k
+	}
+	for _, ld := range []struct {
+		name      string
+		ld, width int
+	}{{"lda", lda, aCols}, {"ldb", ldb, bCols}, {"ldc", ldc, n}} {
+		if ld.ld < max(1, ld.width) {
+			panic(fmt.Sprintf("goblas: DgemmBatch: %s = %d, want >= %d", ld.name, ld.ld, max(1, ld.width)))
+		}
+	}
+}
+
+// dgemmBatchElem returns the matrices of one element of a DgemmBatch call
+// whose shared parameters have been checked, or an error describing the
+// first matrix that does not hold enough data.
+func dgemmBatchElem(tA, tB blas.Transpose, m, n, k int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int) (amat, bmat, cmat general, err error) {
+	amat = general{data: a, rows: m, cols: k, stride: lda}
+	if tA == blas.Trans {
+		amat.rows, amat.cols = k, m
+	}
+	bmat = general{data: b, rows: k, cols: n, stride: ldb}
+	if tB == blas.Trans {
+		bmat.rows, bmat.cols = n, k
+	}
+	cmat = general{data: c, rows: m, cols: n, stride: ldc}
+	for _, g := range []struct {
+		name string
+		g    general
+	}{{"a", amat}, {"b", bmat}, {"c", cmat}} {
+		if err := g.g.check(); err != nil {
+			return amat, bmat, cmat, fmt.Errorf("%s: %v: need len(%s) >= %d, got %d", g.name, err, g.name, (g.g.rows-1)*g.g.stride+g.g.cols, len(g.g.data))
+		}
+	}
+	return amat, bmat, cmat, nil
+}
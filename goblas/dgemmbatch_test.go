@@ -0,0 +1,88 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestDgemmBatch(t *testing.T) {
+	const m, n, k = 4, 3, 5
+	const lda, ldb, ldc = k + 1, n, n + 2
+	newBatch := func(size int) (as, bs, cs [][]float64) {
+		for i := 0; i < size; i++ {
+			as = append(as, randmat(m, k, lda).data)
+			bs = append(bs, randmat(k, n, ldb).data)
+			cs = append(cs, randmat(m, n, ldc).data)
+		}
+		return as, bs, cs
+	}
+	as, bs, cs := newBatch(5)
+	// Elements 1 and 3 are malformed: a short A and a missing C.
+	as[1] = as[1][:lda*(m-1)]
+	cs[3] = nil
+	want := make([][]float64, len(cs))
+	for i := range cs {
+		want[i] = append([]float64(nil), cs[i]...)
+		if i != 1 && i != 3 {
+			Blasser.Dgemm(blas.NoTrans, blas.NoTrans, m, n, k, 2, as[i], lda, bs[i], ldb, 0.5, want[i], ldc)
+		}
+	}
+
+	// With the default policy nothing is computed.
+	orig := make([][]float64, len(cs))
+	for i := range cs {
+		orig[i] = append([]float64(nil), cs[i]...)
+	}
+	msg := panicMessage(func() {
+		Blasser.DgemmBatch(blas.NoTrans, blas.NoTrans, m, n, k, 2, as, lda, bs, ldb, 0.5, cs, ldc, BatchPanic)
+	})
+	if want := "goblas: DgemmBatch: element 1: a: general: insufficient length: need len(a) >= 23, got 18"; msg != want {
+		t.Errorf("unexpected panic: got %q, want %q", msg, want)
+	}
+	if !reflect.DeepEqual(cs, orig) {
+		t.Errorf("BatchPanic modified C before panicking")
+	}
+
+	failed := Blasser.DgemmBatch(blas.NoTrans, blas.NoTrans, m, n, k, 2, as, lda, bs, ldb, 0.5, cs, ldc, BatchSkip)
+	if !reflect.DeepEqual(failed, []int{1, 3}) {
+		t.Errorf("unexpected failed elements %v", failed)
+	}
+	for i := range cs {
+		g := general{data: cs[i], rows: m, cols: n, stride: ldc}
+		w := general{data: want[i], rows: m, cols: n, stride: ldc}
+		if len(cs[i]) != len(want[i]) || (len(cs[i]) > 0 && !g.equalWithinAbs(w, 1e-14)) {
+			t.Errorf("element %d: result differs from Dgemm", i)
+		}
+	}
+
+	as, bs, cs = newBatch(3)
+	if failed := Blasser.DgemmBatch(blas.NoTrans, blas.NoTrans, m, n, k, 1, as, lda, bs, ldb, 0, cs, ldc, BatchSkip); failed != nil {
+		t.Errorf("valid batch: unexpected failed elements %v", failed)
+	}
+
+	// Errors in the shared parameters panic whatever the policy.
+	for _, f := range []func(){
+		func() {
+			Blasser.DgemmBatch(blas.NoTrans, blas.NoTrans, m, n, k, 1, as, lda, bs[:2], ldb, 0, cs, ldc, BatchSkip)
+		},
+		func() {
+			Blasser.DgemmBatch(blas.NoTrans, blas.NoTrans, -1, n, k, 1, as, lda, bs, ldb, 0, cs, ldc, BatchSkip)
+		},
+		func() {
+			Blasser.DgemmBatch(blas.NoTrans, blas.NoTrans, m, n, k, 1, as, k-1, bs, ldb, 0, cs, ldc, BatchSkip)
+		},
+		func() {
+			Blasser.DgemmBatch(blas.NoTrans, blas.NoTrans, m, n, k, 1, as, lda, bs, ldb, 0, cs, ldc, BatchPolicy(7))
+		},
+	} {
+		if !panics(f) {
+			t.Errorf("expected panic")
+		}
+	}
+}
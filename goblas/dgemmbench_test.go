@@ -116,3 +116,108 @@ func BenchmarkDgemmMedMedMedNTNT(b *testing.B) {
 		blas.NoTrans,
 	)
 }
+
+// The following benchmarks compare the adaptive block size to the fixed
+// default on a matrix that is too small to give every worker a block at the
+// default size.
+
+func BenchmarkDgemm200Adaptive(b *testing.B) {
+	testblas.DgemmBenchmark(b, Blas{}, 200, 200, 200, blas.NoTrans, blas.NoTrans)
+}
+
+func BenchmarkDgemm200Fixed(b *testing.B) {
+	testblas.DgemmBenchmark(b, New(WithBlockSize(blockSize)), 200, 200, 200, blas.NoTrans, blas.NoTrans)
+}
+
+// The following benchmarks compare the tiled and recursive Dgemm strategies
+// on square matrices of increasing size.
+
+func BenchmarkDgemmTiled64(b *testing.B) {
+	benchmarkDgemmStrategy(b, TiledDgemm, 64)
+}
+
+func BenchmarkDgemmRecursive64(b *testing.B) {
+	benchmarkDgemmStrategy(b, RecursiveDgemm, 64)
+}
+
+func BenchmarkDgemmTiled256(b *testing.B) {
+	benchmarkDgemmStrategy(b, TiledDgemm, 256)
+}
+
+func BenchmarkDgemmRecursive256(b *testing.B) {
+	benchmarkDgemmStrategy(b, RecursiveDgemm, 256)
+}
+
+func BenchmarkDgemmTiled512(b *testing.B) {
+	benchmarkDgemmStrategy(b, TiledDgemm, 512)
+}
+
+func BenchmarkDgemmRecursive512(b *testing.B) {
+	benchmarkDgemmStrategy(b, RecursiveDgemm, 512)
+}
+
+func BenchmarkDgemmTiled1000(b *testing.B) {
+	benchmarkDgemmStrategy(b, TiledDgemm, 1000)
+}
+
+func BenchmarkDgemmRecursive1000(b *testing.B) {
+	benchmarkDgemmStrategy(b, RecursiveDgemm, 1000)
+}
+
+func benchmarkDgemmStrategy(b *testing.B, s DgemmStrategy, n int) {
+	testblas.DgemmBenchmark(b, New(WithDgemmStrategy(s)), n, n, n, blas.NoTrans, blas.NoTrans)
+}
+
+// The following benchmarks compare alpha == 1 with a general alpha. The
+// kernels apply alpha once per element of A (or of C when only B is
+// transposed), outside their inner loops, so the two should run at the
+// same speed.
+
+func BenchmarkDgemmAlphaOneNN(b *testing.B) {
+	benchmarkDgemmAlpha(b, 1, blas.NoTrans, blas.NoTrans)
+}
+
+func BenchmarkDgemmAlphaOtherNN(b *testing.B) {
+	benchmarkDgemmAlpha(b, 3, blas.NoTrans, blas.NoTrans)
+}
+
+func BenchmarkDgemmAlphaOneNT(b *testing.B) {
+	benchmarkDgemmAlpha(b, 1, blas.NoTrans, blas.Trans)
+}
+
+func BenchmarkDgemmAlphaOtherNT(b *testing.B) {
+	benchmarkDgemmAlpha(b, 3, blas.NoTrans, blas.Trans)
+}
+
+func benchmarkDgemmAlpha(b *testing.B, alpha float64, tA, tB blas.Transpose) {
+	const n = testblas.DgemmMedium
+	a := randSlice(n * n)
+	bm := randSlice(n * n)
+	c := randSlice(n * n)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Blasser.Dgemm(tA, tB, n, n, n, alpha, a, n, bm, n, 1, c, n)
+	}
+}
+
+// The dispatch benchmarks measure the cost of handing blocks to the workers
+// with the smallest and a large dispatch buffer, for a multiplication split
+// into many tiny blocks and one split into a few large ones. They only show
+// a difference when run with GOMAXPROCS > 1.
+
+func benchmarkDispatch(b *testing.B, bs, perWorker int) {
+	const m, n, k = 256, 256, 8
+	bl := New(WithBlockSize(bs), WithDispatchBuffer(perWorker))
+	a := randmat(m, k, k)
+	bm := randmat(k, n, n)
+	c := randmat(m, n, n)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bl.Dgemm(blas.NoTrans, blas.NoTrans, m, n, k, 1, a.data, a.stride, bm.data, bm.stride, 1, c.data, c.stride)
+	}
+}
+
+func BenchmarkDispatchTinyBlocksBuf1(b *testing.B)   { benchmarkDispatch(b, 4, 1) }
+func BenchmarkDispatchTinyBlocksBuf64(b *testing.B)  { benchmarkDispatch(b, 4, 64) }
+func BenchmarkDispatchLargeBlocksBuf1(b *testing.B)  { benchmarkDispatch(b, 128, 1) }
+func BenchmarkDispatchLargeBlocksBuf64(b *testing.B) { benchmarkDispatch(b, 128, 64) }
@@ -0,0 +1,27 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import "github.com/gonum/blas"
+
+// DgemmBlock computes C += alpha * op(A) * op(B) with the serial kernel that
+// Dgemm runs on each of its blocks. The parameters have the same meaning as
+// for Dgemm, and the slices are typically sub-matrices of larger matrices,
+// for example a[i*lda+l:] for the block of A at row i and column l.
+//
+// DgemmBlock is a building block for callers that schedule the blocks of a
+// multiplication themselves: it does not partition the matrices, start
+// goroutines or scale C, and it is safe to call concurrently for blocks
+// that write disjoint parts of C. As for Dgemm, the update with a row or
+// column of B is skipped when the multiplying element of alpha*A is zero,
+// unless bl was configured with WithStrictIEEE. If m, n, k or alpha is zero,
+// DgemmBlock does nothing and A and B are not referenced.
+func (bl Blas) DgemmBlock(tA, tB blas.Transpose, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, c []float64, ldc int) {
+	amat, bmat, cmat := dgemmMats(tA, tB, m, n, k, a, lda, b, ldb, c, ldc)
+	if m == 0 || n == 0 || k == 0 || alpha == 0 {
+		return
+	}
+	dgemmSerial(tA, tB, amat, bmat, cmat, alpha, bl.strict)
+}
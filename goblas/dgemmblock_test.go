@@ -0,0 +1,84 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+// TestDgemmBlock computes a product by calling DgemmBlock concurrently on
+// the blocks of C, accumulating over the blocks of the inner dimension, and
+// compares it with Dgemm.
+func TestDgemmBlock(t *testing.T) {
+	const (
+		m, n, k = 75, 61, 53
+		bs      = 16
+	)
+	for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, tB := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			var a, b general
+			if tA == blas.NoTrans {
+				a = randmat(m, k, k+1)
+			} else {
+				a = randmat(k, m, m+2)
+			}
+			if tB == blas.NoTrans {
+				b = randmat(k, n, n+3)
+			} else {
+				b = randmat(n, k, k)
+			}
+			c := randmat(m, n, n+1)
+			want := c.clone()
+			Blasser.Dgemm(tA, tB, m, n, k, 1.5, a.data, a.stride, b.data, b.stride, 1, want.data, want.stride)
+
+			var wg sync.WaitGroup
+			for i := 0; i < m; i += bs {
+				for j := 0; j < n; j += bs {
+					wg.Add(1)
+					go func(i, j int) {
+						defer wg.Done()
+						mb, nb := min(bs, m-i), min(bs, n-j)
+						for l := 0; l < k; l += bs {
+							kb := min(bs, k-l)
+							ao := i*a.stride + l
+							if tA == blas.Trans {
+								ao = l*a.stride + i
+							}
+							bo := l*b.stride + j
+							if tB == blas.Trans {
+								bo = j*b.stride + l
+							}
+							Blasser.DgemmBlock(tA, tB, mb, nb, kb, 1.5, a.data[ao:], a.stride, b.data[bo:], b.stride, c.data[i*c.stride+j:], c.stride)
+						}
+					}(i, j)
+				}
+			}
+			wg.Wait()
+			for i := 0; i < m; i++ {
+				for j := 0; j < n; j++ {
+					if math.Abs(c.at(i, j)-want.at(i, j)) > 1e-12 {
+						t.Errorf("tA = %c, tB = %c: mismatch at (%d, %d): got %v, want %v", tA, tB, i, j, c.at(i, j), want.at(i, j))
+					}
+				}
+			}
+		}
+	}
+
+	// alpha == 0 does not reference A or B.
+	c := []float64{1, 2}
+	Blasser.DgemmBlock(blas.NoTrans, blas.NoTrans, 1, 2, 1, 0, []float64{math.NaN()}, 1, []float64{math.NaN(), math.NaN()}, 2, c, 2)
+	if c[0] != 1 || c[1] != 2 {
+		t.Errorf("alpha = 0 changed C: got %v", c)
+	}
+	if !panics(func() {
+		Blasser.DgemmBlock(blas.NoTrans, blas.NoTrans, 2, 2, 2, 1, make([]float64, 3), 2, make([]float64, 4), 2, make([]float64, 4), 2)
+	}) {
+		t.Errorf("Expected panic for short a")
+	}
+}
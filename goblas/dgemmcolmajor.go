@@ -0,0 +1,77 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"fmt"
+
+	"github.com/gonum/blas"
+)
+
+// DgemmColMajor computes C := beta * C + alpha * op(A) * op(B) for matrices
+// stored in column-major order, as in the reference Fortran BLAS: element
+// (i, j) of A is a[i+j*lda], and likewise for B and C. The parameters have
+// the same meaning as for the Fortran DGEMM, so op(A) is m×k, op(B) is k×n
+// and C is m×n, and each leading dimension is at least the number of rows of
+// its matrix as stored.
+//
+// A column-major matrix is the row-major storage of its transpose, and
+// (op(A) * op(B))^T = op(B)^T * op(A)^T, so DgemmColMajor calls
+//
+//	Dgemm(tB, tA, n, m, k, alpha, b, ldb, a, lda, beta, c, ldc)
+//
+// which computes C^T in row-major order, that is C in column-major order.
+// The transpose flags are unchanged and only the operands and the outer
+// dimensions are swapped. The arguments are checked first in column-major
+// terms, so that a panic names the matrices and dimensions as given here.
+func (bl Blas) DgemmColMajor(tA, tB blas.Transpose, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	if tA != blas.NoTrans && tA != blas.Trans {
+		panic(badTranspose)
+	}
+	if tB != blas.NoTrans && tB != blas.Trans {
+		panic(badTranspose)
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if k < 0 {
+		panic(kLT0)
+	}
+	aRows, aCols := m, k
+	if tA == blas.Trans {
+		aRows, aCols = k, m
+	}
+	bRows, bCols := k, n
+	if tB == blas.Trans {
+		bRows, bCols = n, k
+	}
+	checkColMajor("a", a, aRows, aCols, lda)
+	checkColMajor("b", b, bRows, bCols, ldb)
+	checkColMajor("c", c, m, n, ldc)
+
+	bl.Dgemm(tB, tA, n, m, k, alpha, b, ldb, a, lda, beta, c, ldc)
+}
+
+// checkColMajor panics with a message in column-major terms if data with
+// leading dimension ld cannot hold a rows×cols column-major matrix.
+func checkColMajor(name string, data []float64, rows, cols, ld int) {
+	// The column-major matrix is the row-major cols×rows matrix with
+	// stride ld.
+	g := general{data: data, rows: cols, cols: rows, stride: ld}
+	err := g.check()
+	if err == nil {
+		return
+	}
+	minLd := max(1, rows)
+	minLen := 0
+	if rows > 0 && cols > 0 {
+		minLen = (cols-1)*max(ld, minLd) + rows
+	}
+	panic(fmt.Sprintf("goblas: DgemmColMajor: %v: %s is %d×%d in column-major order, which needs ld%s >= %d and len(%s) >= %d; got ld%s = %d, len(%s) = %d",
+		err, name, rows, cols, name, minLd, name, minLen, name, ld, name, len(data)))
+}
@@ -0,0 +1,75 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+// colMajor returns the r×c matrix g in column-major order with leading
+// dimension ld.
+func colMajor(g general, ld int) []float64 {
+	d := make([]float64, max(0, (g.cols-1)*ld+g.rows))
+	for i := 0; i < g.rows; i++ {
+		for j := 0; j < g.cols; j++ {
+			d[i+j*ld] = g.at(i, j)
+		}
+	}
+	return d
+}
+
+func TestDgemmColMajor(t *testing.T) {
+	for _, test := range []struct {
+		m, n, k int
+		pad     int // added to each leading dimension
+	}{
+		{1, 1, 1, 0},
+		{3, 4, 5, 0},
+		{5, 3, 4, 2},
+		{7, 1, 0, 1},
+		{70, 90, 40, 3}, // Large enough for Dgemm to use several blocks.
+	} {
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, tB := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				m, n, k := test.m, test.n, test.k
+				// The stored matrices, in row-major order.
+				a := randmat(m, k, max(1, k))
+				if tA == blas.Trans {
+					a = randmat(k, m, max(1, m))
+				}
+				b := randmat(k, n, max(1, n))
+				if tB == blas.Trans {
+					b = randmat(n, k, max(1, k))
+				}
+				c := randmat(m, n, n)
+				want := c.clone()
+				Blasser.DgemmReference(tA, tB, m, n, k, 2, a.data, a.stride, b.data, b.stride, -0.5, want.data, want.stride)
+
+				lda, ldb, ldc := a.rows+test.pad, b.rows+test.pad, m+test.pad
+				cc := colMajor(c, ldc)
+				Blasser.DgemmColMajor(tA, tB, m, n, k, 2, colMajor(a, lda), max(1, lda), colMajor(b, ldb), max(1, ldb), -0.5, cc, ldc)
+				for i := 0; i < m; i++ {
+					for j := 0; j < n; j++ {
+						if got := cc[i+j*ldc]; math.Abs(got-want.at(i, j)) > 1e-12 {
+							t.Errorf("m = %v, n = %v, k = %v, tA = %c, tB = %c: C[%v][%v] = %v, want %v", m, n, k, tA, tB, i, j, got, want.at(i, j))
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// lda is checked against the rows of A in column-major order.
+	msg := panicMessage(func() {
+		Blasser.DgemmColMajor(blas.NoTrans, blas.NoTrans, 3, 2, 2, 1, make([]float64, 6), 2, make([]float64, 4), 2, 0, make([]float64, 6), 3)
+	})
+	if !strings.Contains(msg, "a is 3×2 in column-major order, which needs lda >= 3") {
+		t.Errorf("unexpected panic message %q", msg)
+	}
+}
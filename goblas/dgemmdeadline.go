@@ -0,0 +1,104 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"time"
+
+	"github.com/gonum/blas"
+)
+
+// DgemmBlocks records which blocks of C a call to DgemmDeadline computed. C
+// is divided into blocks of BlockSize×BlockSize elements, with smaller blocks
+// at the bottom and right edges, so that block (i, j) starts at row
+// i*BlockSize and column j*BlockSize of C.
+type DgemmBlocks struct {
+	BlockSize  int // side of the blocks of C
+	Rows, Cols int // number of blocks in each dimension of C
+
+	done []bool
+}
+
+// Done reports whether block (i, j) of C was computed.
+func (b DgemmBlocks) Done(i, j int) bool {
+	if i < 0 || i >= b.Rows || j < 0 || j >= b.Cols {
+		panic("goblas: block index out of range")
+	}
+	return b.done[i*b.Cols+j]
+}
+
+// Valid reports whether element (r, c) of C was computed.
+func (b DgemmBlocks) Valid(r, c int) bool {
+	return b.Done(r/b.BlockSize, c/b.BlockSize)
+}
+
+// Count returns the number of blocks of C that were computed.
+func (b DgemmBlocks) Count() int {
+	var n int
+	for _, d := range b.done {
+		if d {
+			n++
+		}
+	}
+	return n
+}
+
+// Complete reports whether all of C was computed.
+func (b DgemmBlocks) Complete() bool {
+	return b.Count() == len(b.done)
+}
+
+// DgemmDeadline computes C := beta * C + alpha * A * B as Dgemm does, but
+// stops starting new blocks of C once deadline has passed. Each block is
+// either computed completely, including its scaling by beta, or left
+// unchanged, so the returned DgemmBlocks tells which parts of C hold the
+// result and which still hold their value on input. A block that is started
+// before the deadline is finished, so DgemmDeadline may return after the
+// deadline by up to the time taken to compute one block per worker.
+//
+// C is partitioned as by the TiledDgemm strategy whatever the strategy of bl.
+// DgemmDeadline does not record stats.
+func (bl Blas) DgemmDeadline(deadline time.Time, tA, tB blas.Transpose, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) DgemmBlocks {
+	amat, bmat, cmat := dgemmMats(tA, tB, m, n, k, a, lda, b, ldb, c, ldc)
+	if bl.debug && !finite(alpha, beta) {
+		panic(nonFiniteScalar)
+	}
+	bs := bl.dgemmBlockSize(m, n)
+	blocks := DgemmBlocks{
+		BlockSize: bs,
+		Rows:      numBlocks(m, bs),
+		Cols:      numBlocks(n, bs),
+	}
+	blocks.done = make([]bool, blocks.Rows*blocks.Cols)
+	if len(blocks.done) == 0 {
+		return blocks
+	}
+
+	bl.runBlocks(len(blocks.done), func(send func(subMul)) {
+		bl.order.blocks(m, n, bs, func(i, j int) {
+			send(subMul{
+				i: i,
+				j: j,
+			})
+		})
+	}, func(sub subMul) {
+		if !time.Now().Before(deadline) {
+			return
+		}
+		i := sub.i
+		j := sub.j
+		leni := min(bs, m-i)
+		lenj := min(bs, n-j)
+		if beta != 1 {
+			dgemmScaleSerial(bl.view(cmat, i, j, leni, lenj), beta)
+		}
+		if alpha != 0 {
+			bl.dgemmBlock(tA, tB, amat, bmat, cmat, i, j, leni, lenj, k, bs, alpha)
+		}
+		// Each block is sent once, so no other worker writes this element.
+		blocks.done[i/bs*blocks.Cols+j/bs] = true
+	})
+	return blocks
+}
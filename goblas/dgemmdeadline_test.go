@@ -0,0 +1,82 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/gonum/blas"
+)
+
+func TestDgemmDeadline(t *testing.T) {
+	for _, bl := range []Blas{
+		New(WithBlockSize(16)),
+		New(WithBlockSize(16), WithMaxWorkers(4), WithBlockOrder(DiagonalBlocks)),
+	} {
+		for _, test := range []struct {
+			tA, tB      blas.Transpose
+			m, n, k     int
+			alpha, beta float64
+			deadline    time.Duration
+		}{
+			{blas.NoTrans, blas.NoTrans, 40, 35, 20, 2, 0.5, time.Hour},
+			{blas.Trans, blas.Trans, 40, 35, 20, 2, 0, time.Hour},
+			{blas.NoTrans, blas.Trans, 33, 17, 0, 1, 3, time.Hour},
+			{blas.Trans, blas.NoTrans, 33, 17, 9, 0, 3, time.Hour},
+			{blas.NoTrans, blas.NoTrans, 40, 35, 20, 1, 1, -time.Second},
+			// The deadline may pass part way through.
+			{blas.NoTrans, blas.NoTrans, 400, 300, 200, 1, -1, time.Millisecond},
+		} {
+			m, n, k := test.m, test.n, test.k
+			var a, b general
+			if test.tA == blas.NoTrans {
+				a = randmat(m, k, k+1)
+			} else {
+				a = randmat(k, m, m)
+			}
+			if test.tB == blas.NoTrans {
+				b = randmat(k, n, n)
+			} else {
+				b = randmat(n, k, k+1)
+			}
+			c := randmat(m, n, n+2)
+			orig := c.clone()
+			want := c.clone()
+			bl.Dgemm(test.tA, test.tB, m, n, k, test.alpha, a.data, a.stride, b.data, b.stride, test.beta, want.data, want.stride)
+
+			blocks := bl.DgemmDeadline(time.Now().Add(test.deadline), test.tA, test.tB, m, n, k, test.alpha, a.data, a.stride, b.data, b.stride, test.beta, c.data, c.stride)
+			if blocks.Rows != (m+15)/16 || blocks.Cols != (n+15)/16 {
+				t.Errorf("m=%d n=%d: unexpected %d×%d blocks", m, n, blocks.Rows, blocks.Cols)
+			}
+			switch {
+			case test.deadline == time.Hour && !blocks.Complete():
+				t.Errorf("m=%d n=%d: only %d blocks computed before a distant deadline", m, n, blocks.Count())
+			case test.deadline < 0 && blocks.Count() != 0:
+				t.Errorf("m=%d n=%d: %d blocks computed after the deadline", m, n, blocks.Count())
+			}
+			for i := 0; i < m; i++ {
+				for j := 0; j < c.stride; j++ {
+					w := orig.data[i*c.stride+j]
+					if j < n && blocks.Valid(i, j) {
+						w = want.at(i, j)
+					}
+					if got := c.data[i*c.stride+j]; math.Abs(got-w) > 1e-12 {
+						t.Fatalf("m=%d n=%d: c[%d,%d] = %v, want %v", m, n, i, j, got, w)
+					}
+				}
+			}
+		}
+	}
+
+	blocks := Blasser.DgemmDeadline(time.Now(), blas.NoTrans, blas.NoTrans, 0, 3, 2, 1, nil, 2, make([]float64, 6), 3, 1, nil, 3)
+	if !blocks.Complete() || blocks.Count() != 0 {
+		t.Errorf("empty C: got %d blocks, complete = %t", blocks.Count(), blocks.Complete())
+	}
+	if !panics(func() { blocks.Done(0, 0) }) {
+		t.Errorf("expected panic for block out of range")
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import "github.com/gonum/blas"
+
+// DgemmInfo computes C := beta * C + alpha * A * B exactly as Dgemm does and
+// reports how the multiplication was scheduled. workers is the number of
+// goroutines, including the calling one, that computed parts of C
+// concurrently, and parallel reports whether it is more than one. blocks is
+// the number of sub-multiplications the work was split into: for
+// TiledDgemm the number of blocks of C, or one if C was computed as a whole,
+// and for RecursiveDgemm the number of base cases. If the multiplication is
+// skipped because m, n, k or alpha is zero, workers and blocks are zero.
+// Scaling C by beta is not described.
+//
+// Unlike WithStats, DgemmInfo does not time the call or take a lock.
+func (bl Blas) DgemmInfo(tA, tB blas.Transpose, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) (parallel bool, workers, blocks int) {
+	plan := bl.dgemmCall(tA, tB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+	return plan.parallel(), plan.workers, plan.blocks
+}
+
+// dgemmPlan describes how a multiplication was scheduled. It is returned by
+// dgemmParallel and dgemmRecursive, which make the scheduling decisions, so
+// that DgemmInfo and WithStats report what was done rather than re-deriving
+// it. The zero value describes a skipped multiplication.
+type dgemmPlan struct {
+	workers int // number of goroutines that computed parts of c concurrently
+	blocks  int // number of sub-multiplications
+}
+
+// parallel reports whether the multiplication was computed concurrently.
+func (p dgemmPlan) parallel() bool {
+	return p.workers > 1
+}
@@ -0,0 +1,86 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestDgemmInfo(t *testing.T) {
+	for _, opts := range [][]Option{
+		{WithBlockSize(32)},
+		{WithBlockSize(32), WithMaxWorkers(1)},
+		{WithBlockSize(32), WithMaxWorkers(3)},
+		{WithDgemmStrategy(RecursiveDgemm)},
+		{WithDgemmStrategy(RecursiveDgemm), WithBlockSize(32), WithMaxWorkers(1)},
+		{WithDgemmStrategy(RecursiveDgemm), WithBlockSize(32), WithMaxWorkers(5)},
+	} {
+		bl := New(opts...)
+		stats := New(append(opts, WithStats(true))...)
+		for _, test := range []struct {
+			m, n, k int
+			alpha   float64
+		}{
+			{3, 4, 5, 1},
+			{200, 150, 10, 2},
+			{70, 300, 90, 1},
+			{200, 150, 10, 0},
+			{0, 150, 10, 1},
+			{20, 15, 0, 1},
+		} {
+			m, n, k := test.m, test.n, test.k
+			a := randmat(m, k, k+1)
+			b := randmat(k, n, n+1)
+			c := randmat(m, n, n+1)
+			want := c.clone()
+			stats.Dgemm(blas.NoTrans, blas.NoTrans, m, n, k, test.alpha, a.data, a.stride, b.data, b.stride, 0.5, want.data, want.stride)
+
+			parallel, workers, blocks := bl.DgemmInfo(blas.NoTrans, blas.NoTrans, m, n, k, test.alpha, a.data, a.stride, b.data, b.stride, 0.5, c.data, c.stride)
+			if !c.equalWithinAbs(want, 0) {
+				t.Errorf("%+v, m = %d, n = %d, k = %d: result differs from Dgemm", bl, m, n, k)
+			}
+			if got := stats.LastStats().Parallel; parallel != got {
+				t.Errorf("%+v, m = %d, n = %d, k = %d: parallel = %v, but Dgemm reported %v", bl, m, n, k, parallel, got)
+			}
+			if parallel != (workers > 1) {
+				t.Errorf("%+v, m = %d, n = %d, k = %d: parallel = %v with %d workers", bl, m, n, k, parallel, workers)
+			}
+			if workers > bl.workers() || workers > max(blocks, 1) {
+				t.Errorf("%+v, m = %d, n = %d, k = %d: %d workers for %d blocks", bl, m, n, k, workers, blocks)
+			}
+			skipped := m == 0 || n == 0 || k == 0 || test.alpha == 0
+			if skipped != (blocks == 0) || skipped != (workers == 0) {
+				t.Errorf("%+v, m = %d, n = %d, k = %d: unexpected %d workers and %d blocks", bl, m, n, k, workers, blocks)
+			}
+		}
+	}
+
+	// The tiled blocks of a 200×150 C with block size 32.
+	bl := New(WithBlockSize(32))
+	a := randmat(200, 10, 10)
+	b := randmat(10, 150, 150)
+	c := randmat(200, 150, 150)
+	_, workers, blocks := bl.DgemmInfo(blas.NoTrans, blas.NoTrans, 200, 150, 10, 1, a.data, a.stride, b.data, b.stride, 0, c.data, c.stride)
+	wantBlocks, wantWorkers := 7*5, min(bl.workers(), 7*5)
+	if forceSerial {
+		wantBlocks, wantWorkers = 1, 1
+	}
+	if blocks != wantBlocks || workers != wantWorkers {
+		t.Errorf("tiled: got %d workers and %d blocks, want %d and %d", workers, blocks, wantWorkers, wantBlocks)
+	}
+
+	// A 128×128×128 recursive multiplication with base case 64 has eight
+	// base cases, since k is split first, and with one worker no
+	// concurrency.
+	bl = New(WithDgemmStrategy(RecursiveDgemm), WithMaxWorkers(1))
+	a = randmat(128, 128, 128)
+	c = randmat(128, 128, 128)
+	parallel, workers, blocks := bl.DgemmInfo(blas.NoTrans, blas.NoTrans, 128, 128, 128, 1, a.data, a.stride, a.data, a.stride, 0, c.data, c.stride)
+	if parallel || workers != 1 || blocks != 8 {
+		t.Errorf("recursive: got parallel = %v, %d workers and %d blocks, want false, 1 and 8", parallel, workers, blocks)
+	}
+}
@@ -0,0 +1,24 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import "github.com/gonum/blas"
+
+// DgemmN computes C := beta * C + alpha * A * B as Dgemm does, but with up to
+// nWorkers worker goroutines whatever the value of GOMAXPROCS and of
+// WithMaxWorkers. The block size is chosen for nWorkers workers as well. As
+// for Dgemm, fewer workers are used if there are fewer blocks than
+// workers, and none are started if GOBLAS_SERIAL is set.
+//
+// DgemmN only controls the number of goroutines. The Go runtime runs at most
+// GOMAXPROCS of them at the same time, so for the workers to run in parallel
+// beyond that the caller must raise GOMAXPROCS for the duration of the call.
+func (bl Blas) DgemmN(nWorkers int, tA, tB blas.Transpose, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	if nWorkers < 1 {
+		panic("goblas: nWorkers < 1")
+	}
+	bl.nWorkers = nWorkers
+	bl.Dgemm(tA, tB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
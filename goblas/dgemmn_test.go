@@ -0,0 +1,42 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestDgemmN(t *testing.T) {
+	const m, n, k = 130, 90, 40
+	a := randmat(m, k, k)
+	b := randmat(k, n, n)
+	c := randmat(m, n, n)
+	want := c.clone()
+	Blasser.Dgemm(blas.NoTrans, blas.NoTrans, m, n, k, 2, a.data, k, b.data, n, 0.5, want.data, n)
+
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+	for _, nWorkers := range []int{1, 3, 8} {
+		bl := New(WithMaxWorkers(1), WithStats(true))
+		got := c.clone()
+		bl.DgemmN(nWorkers, blas.NoTrans, blas.NoTrans, m, n, k, 2, a.data, k, b.data, n, 0.5, got.data, n)
+		if !got.equalWithinAbs(want, 1e-12) {
+			t.Errorf("nWorkers = %v: result differs from Dgemm", nWorkers)
+		}
+		// GOMAXPROCS and WithMaxWorkers are both 1, so the call is only
+		// concurrent if DgemmN overrides them.
+		if par := bl.LastStats().Parallel; par != (nWorkers > 1 && !forceSerial) {
+			t.Errorf("nWorkers = %v: parallel = %t", nWorkers, par)
+		}
+	}
+
+	if !panics(func() {
+		Blasser.DgemmN(0, blas.NoTrans, blas.NoTrans, 1, 1, 1, 1, []float64{1}, 1, []float64{1}, 1, 0, []float64{1}, 1)
+	}) {
+		t.Errorf("expected panic for zero workers")
+	}
+}
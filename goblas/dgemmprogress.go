@@ -0,0 +1,59 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"sync"
+
+	"github.com/gonum/blas"
+)
+
+// DgemmProgress computes C := beta * C + alpha * A * B exactly as Dgemm does
+// and calls progress each time a sub-multiplication completes. done is the
+// number of sub-multiplications completed so far and total is the number
+// DgemmInfo reports as blocks, so the last call has done == total. The calls
+// are made one at a time with done increasing by one, but may come from any
+// of the worker goroutines, so progress should return quickly; the workers
+// wait for it. progress is not called if the multiplication is skipped
+// because m, n, k or alpha is zero. If progress is nil, DgemmProgress is
+// equivalent to Dgemm.
+func (bl Blas) DgemmProgress(tA, tB blas.Transpose, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int, progress func(done, total int)) {
+	if progress != nil {
+		bl.progress = &progressReporter{fn: progress}
+	}
+	bl.Dgemm(tA, tB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+// progressReporter counts the completed sub-multiplications of a
+// DgemmProgress call. It is referenced by pointer so that Blas stays a
+// comparable value type, and a nil reporter ignores all steps.
+type progressReporter struct {
+	mu    sync.Mutex
+	done  int
+	total int
+	fn    func(done, total int)
+}
+
+// begin sets the number of sub-multiplications of the call. It is called by
+// the scheduler once it has split the multiplication, before the first step.
+func (p *progressReporter) begin(total int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.total = total
+}
+
+// step records the completion of one sub-multiplication.
+func (p *progressReporter) step() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done++
+	p.fn(p.done, p.total)
+}
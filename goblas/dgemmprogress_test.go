@@ -0,0 +1,67 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestDgemmProgress(t *testing.T) {
+	for _, bl := range []Blas{
+		Blasser,
+		New(WithMaxWorkers(4), WithBlockSize(16)),
+		New(WithDgemmStrategy(RecursiveDgemm), WithBlockSize(16)),
+		New(WithMaxWorkers(4), WithDgemmStrategy(RecursiveDgemm), WithBlockSize(16)),
+	} {
+		for _, test := range []struct {
+			m, n, k int
+			alpha   float64
+		}{
+			{3, 4, 5, 1},
+			{70, 90, 50, 2},
+			{300, 200, 100, -1},
+			{300, 200, 0, 1},
+			{300, 200, 100, 0},
+		} {
+			m, n, k := test.m, test.n, test.k
+			a := randmat(m, k, k+1)
+			b := randmat(k, n, n)
+			c := randmat(m, n, n)
+			want := c.clone()
+			bl.Dgemm(blas.NoTrans, blas.NoTrans, m, n, k, test.alpha, a.data, a.stride, b.data, b.stride, 0.5, want.data, want.stride)
+
+			var calls, last, total int
+			bl.DgemmProgress(blas.NoTrans, blas.NoTrans, m, n, k, test.alpha, a.data, a.stride, b.data, b.stride, 0.5, c.data, c.stride, func(done, tot int) {
+				// Calls are serialized, so no lock is needed here.
+				calls++
+				if done != last+1 {
+					t.Errorf("m=%d n=%d k=%d: done went from %d to %d", m, n, k, last, done)
+				}
+				last, total = done, tot
+			})
+			if !c.equalWithinAbs(want, 1e-12) {
+				t.Errorf("m=%d n=%d k=%d: result differs from Dgemm", m, n, k)
+			}
+			_, _, blocks := bl.DgemmInfo(blas.NoTrans, blas.NoTrans, m, n, k, test.alpha, a.data, a.stride, b.data, b.stride, 1, c.data, c.stride)
+			if calls != blocks || (calls > 0 && (last != total || total != blocks)) {
+				t.Errorf("m=%d n=%d k=%d: got %d calls ending at %d of %d, want %d blocks", m, n, k, calls, last, total, blocks)
+			}
+		}
+	}
+
+	// A nil callback is allowed.
+	a := randmat(3, 3, 3)
+	c := randmat(3, 3, 3)
+	Blasser.DgemmProgress(blas.NoTrans, blas.NoTrans, 3, 3, 3, 1, a.data, 3, a.data, 3, 1, c.data, 3, nil)
+
+	// Parameters are checked before progress is set up.
+	if !panics(func() {
+		Blasser.DgemmProgress(blas.NoTrans, blas.NoTrans, -1, 3, 3, 1, nil, 3, nil, 3, 1, nil, 3, func(int, int) {})
+	}) {
+		t.Errorf("expected panic for m < 0")
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"sync"
+
+	"github.com/gonum/blas"
+)
+
+// DgemmStrategy is the algorithm Dgemm uses to partition the multiplication.
+type DgemmStrategy int
+
+const (
+	// TiledDgemm partitions C into square blocks of a fixed size that are
+	// computed concurrently.
+	TiledDgemm DgemmStrategy = iota
+	// RecursiveDgemm halves the largest of m, n and k until the
+	// sub-multiplication is no larger than a base case. The halves are
+	// cache-oblivious: every level of the memory hierarchy eventually sees
+	// sub-problems that fit, without tuning the block size.
+	RecursiveDgemm
+)
+
+// recursiveBase is the default largest dimension of a base case of
+// dgemmRecursive.
+const recursiveBase = 64
+
+// dgemmMul computes c += alpha * a * b with the configured strategy and
+// returns the plan the multiplication was computed with.
+func (bl Blas) dgemmMul(tA, tB blas.Transpose, a, b, c general, alpha float64) dgemmPlan {
+	if bl.strategy == RecursiveDgemm {
+		if bl.progress != nil {
+			k := a.cols
+			if tA == blas.Trans {
+				k = a.rows
+			}
+			bl.progress.begin(recursiveBlocks(c.rows, c.cols, k, bl.recursiveBaseSize()))
+		}
+		return bl.dgemmRecursive(tA, tB, a, b, c, alpha, bl.workers())
+	}
+	return bl.dgemmParallel(tA, tB, a, b, c, alpha)
+}
+
+// dgemmRecursive computes c += alpha * a * b by splitting the largest of the
+// dimensions m, n and k of the multiplication in half until all of them are
+// at most the base case size, which is the configured block size if one is
+// set and recursiveBase otherwise. Halves of m or n update disjoint parts of
+// c and are computed concurrently while more than one of the nWorkers
+// workers remains available to the sub-problem. Halves of k update the same
+// part of c and are computed one after the other. dgemmRecursive returns
+// the plan of the sub-problems it computed.
+func (bl Blas) dgemmRecursive(tA, tB blas.Transpose, a, b, c general, alpha float64, nWorkers int) dgemmPlan {
+	aTrans := tA == blas.Trans
+	bTrans := tB == blas.Trans
+	m, n := c.rows, c.cols
+	k := a.cols
+	if aTrans {
+		k = a.rows
+	}
+	split := recursiveSplit(m, n, k, bl.recursiveBaseSize())
+	if split == splitNone {
+		if bl.debug {
+			dgemmCheckDims(aTrans, bTrans, a, b, c)
+		}
+		dgemmSerial(tA, tB, a, b, c, alpha, bl.strict)
+		bl.progress.step()
+		return dgemmPlan{workers: 1, blocks: 1}
+	}
+
+	// opView returns the r×s view of op(g) at row i and column j, where g is
+	// transposed if trans is set.
+	opView := func(g general, trans bool, i, j, r, s int) general {
+		if trans {
+			return bl.view(g, j, i, s, r)
+		}
+		return bl.view(g, i, j, r, s)
+	}
+
+	var a1, a2, b1, b2, c1, c2 general
+	switch split {
+	case splitK:
+		h := k / 2
+		a1 = opView(a, aTrans, 0, 0, m, h)
+		a2 = opView(a, aTrans, 0, h, m, k-h)
+		b1 = opView(b, bTrans, 0, 0, h, n)
+		b2 = opView(b, bTrans, h, 0, k-h, n)
+		p1 := bl.dgemmRecursive(tA, tB, a1, b1, c, alpha, nWorkers)
+		p2 := bl.dgemmRecursive(tA, tB, a2, b2, c, alpha, nWorkers)
+		return dgemmPlan{workers: max(p1.workers, p2.workers), blocks: p1.blocks + p2.blocks}
+	case splitM:
+		h := m / 2
+		a1 = opView(a, aTrans, 0, 0, h, k)
+		a2 = opView(a, aTrans, h, 0, m-h, k)
+		b1, b2 = b, b
+		c1 = bl.view(c, 0, 0, h, n)
+		c2 = bl.view(c, h, 0, m-h, n)
+	default:
+		h := n / 2
+		a1, a2 = a, a
+		b1 = opView(b, bTrans, 0, 0, k, h)
+		b2 = opView(b, bTrans, 0, h, k, n-h)
+		c1 = bl.view(c, 0, 0, m, h)
+		c2 = bl.view(c, 0, h, m, n-h)
+	}
+	if nWorkers < 2 {
+		p1 := bl.dgemmRecursive(tA, tB, a1, b1, c1, alpha, 1)
+		p2 := bl.dgemmRecursive(tA, tB, a2, b2, c2, alpha, 1)
+		return dgemmPlan{workers: 1, blocks: p1.blocks + p2.blocks}
+	}
+	var (
+		wg     sync.WaitGroup
+		p      firstPanic
+		p1, p2 dgemmPlan
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer func() {
+			if v := recover(); v != nil {
+				p.record(v)
+			}
+		}()
+		p1 = bl.dgemmRecursive(tA, tB, a1, b1, c1, alpha, nWorkers/2)
+	}()
+	func() {
+		// Wait for the first half even if the second panics.
+		defer wg.Wait()
+		p2 = bl.dgemmRecursive(tA, tB, a2, b2, c2, alpha, nWorkers-nWorkers/2)
+	}()
+	p.repanic()
+	return dgemmPlan{workers: p1.workers + p2.workers, blocks: p1.blocks + p2.blocks}
+}
+
+// recursiveSplit values are the dimensions dgemmRecursive halves.
+const (
+	splitNone = iota // a base case, which is not split
+	splitK
+	splitM
+	splitN
+)
+
+// recursiveSplit returns the dimension in which dgemmRecursive halves an
+// m×n×k multiplication with base case size base.
+func recursiveSplit(m, n, k, base int) int {
+	switch {
+	case m <= base && n <= base && k <= base:
+		return splitNone
+	case k >= m && k >= n:
+		return splitK
+	case m >= n:
+		return splitM
+	}
+	return splitN
+}
+
+// recursiveBlocks returns the number of base cases dgemmRecursive computes
+// for an m×n×k multiplication with base case size base. It is known before
+// the multiplication starts, so that DgemmProgress can report the total.
+func recursiveBlocks(m, n, k, base int) int {
+	switch recursiveSplit(m, n, k, base) {
+	case splitK:
+		return recursiveBlocks(m, n, k/2, base) + recursiveBlocks(m, n, k-k/2, base)
+	case splitM:
+		return recursiveBlocks(m/2, n, k, base) + recursiveBlocks(m-m/2, n, k, base)
+	case splitN:
+		return recursiveBlocks(m, n/2, k, base) + recursiveBlocks(m, n-n/2, k, base)
+	}
+	return 1
+}
+
+// recursiveBaseSize returns the largest dimension of a base case of
+// dgemmRecursive.
+func (bl Blas) recursiveBaseSize() int {
+	if bl.bs != 0 {
+		return bl.bs
+	}
+	return recursiveBase
+}
@@ -0,0 +1,45 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import "github.com/gonum/blas"
+
+// DgemmReference computes C := beta * C + alpha * op(A) * op(B) like Dgemm,
+// with the same parameters and panics, using the textbook triple loop. It has
+// no blocking, no concurrency and no short-circuits: every element of C is
+// computed as alpha times the sum over l of op(A)[i][l]*op(B)[l][j] in order of
+// increasing l, plus beta times its old value, even when alpha is zero or
+// alpha or beta is one. Non-finite values therefore propagate where Dgemm may
+// skip them. As for Dgemm, the old value is not read if beta is zero.
+//
+// DgemmReference is slow and intended only for verifying Dgemm and other
+// implementations.
+func (Blas) DgemmReference(tA, tB blas.Transpose, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	amat, bmat, cmat := dgemmMats(tA, tB, m, n, k, a, lda, b, ldb, c, ldc)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			var sum float64
+			for l := 0; l < k; l++ {
+				var av, bv float64
+				if tA == blas.NoTrans {
+					av = amat.at(i, l)
+				} else {
+					av = amat.at(l, i)
+				}
+				if tB == blas.NoTrans {
+					bv = bmat.at(l, j)
+				} else {
+					bv = bmat.at(j, l)
+				}
+				sum += av * bv
+			}
+			if beta == 0 {
+				c[i*cmat.stride+j] = alpha * sum
+				continue
+			}
+			c[i*cmat.stride+j] = alpha*sum + beta*c[i*cmat.stride+j]
+		}
+	}
+}
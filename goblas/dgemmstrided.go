@@ -0,0 +1,104 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gonum/blas"
+)
+
+// DgemmStridedBatchCheck validates the parameters of a strided batched
+// multiplication
+//
+//	C_i := beta * C_i + alpha * op(A_i) * op(B_i),  i = 0, ..., batchCount-1,
+//
+// where A_i is the matrix with stride lda starting at a[i*strideA], and B_i
+// and C_i likewise, without computing anything. The transposes and
+// dimensions have the same meaning as for Dgemm, so that the batch may be
+// computed with one Dgemm call per element, or with DgemmBatch on the
+// sub-slices, once it has been validated in a single pass.
+//
+// DgemmStridedBatchCheck returns an error describing the first of the
+// following that does not hold:
+//   - tA and tB are valid and m, n, k and batchCount are not negative;
+//   - each leading dimension is at least the number of columns of its
+//     matrix, and at least one;
+//   - each batch stride is not negative and, if there is more than one
+//     element, a nonzero stride is at least the number of elements spanned
+//     by one matrix, so that each matrix fits within its stride window;
+//   - strideC is not zero if there is more than one non-empty C, since the
+//     outputs would overlap; strideA and strideB may be zero to use the same
+//     A or B for every element;
+//   - a, b and c are long enough to hold the last matrix of the batch.
+func (Blas) DgemmStridedBatchCheck(tA, tB blas.Transpose, m, n, k int, a []float64, lda, strideA int, b []float64, ldb, strideB int, c []float64, ldc, strideC int, batchCount int) error {
+	const prefix = "goblas: DgemmStridedBatch: "
+	if tA != blas.NoTrans && tA != blas.Trans {
+		return errors.New(prefix + "illegal transpose tA")
+	}
+	if tB != blas.NoTrans && tB != blas.Trans {
+		return errors.New(prefix + "illegal transpose tB")
+	}
+	for _, d := range []struct {
+		name string
+		v    int
+	}{{"m", m}, {"n", n}, {"k", k}, {"batchCount", batchCount}} {
+		if d.v < 0 {
+			return fmt.Errorf(prefix+"%s = %d < 0", d.name, d.v)
+		}
+	}
+
+	aRows, aCols := m, k
+	if tA == blas.Trans {
+		aRows, aCols = k, m
+	}
+	bRows, bCols := k, n
+	if tB == blas.Trans {
+		bRows, bCols = n, k
+	}
+	for _, w := range []struct {
+		name, arg  string
+		data       []float64
+		rows, cols int
+		ld, stride int
+	}{
+		{"A", "a", a, aRows, aCols, lda, strideA},
+		{"B", "b", b, bRows, bCols, ldb, strideB},
+		{"C", "c", c, m, n, ldc, strideC},
+	} {
+		if w.ld < max(1, w.cols) {
+			return fmt.Errorf(prefix+"ld%s = %d, want >= %d", w.arg, w.ld, max(1, w.cols))
+		}
+		if w.stride < 0 {
+			return fmt.Errorf(prefix+"stride%s = %d < 0", w.name, w.stride)
+		}
+		if w.rows == 0 || w.cols == 0 || batchCount == 0 {
+			// Empty matrices do not reference their data.
+			continue
+		}
+		if w.rows-1 > (maxInt-w.cols)/w.ld {
+			return fmt.Errorf(prefix+"%d×%d %s with ld%s = %d overflows int", w.rows, w.cols, w.name, w.arg, w.ld)
+		}
+		size := (w.rows-1)*w.ld + w.cols
+		if batchCount > 1 {
+			if w.stride == 0 && w.name == "C" {
+				return fmt.Errorf(prefix+"strideC = 0 with %d elements: the C matrices overlap", batchCount)
+			}
+			if w.stride != 0 && w.stride < size {
+				return fmt.Errorf(prefix+"stride%s = %d, want 0 or >= %d, the span of a %d×%d %s with ld%s = %d",
+					w.name, w.stride, size, w.rows, w.cols, w.name, w.arg, w.ld)
+			}
+			if w.stride != 0 && batchCount-1 > (maxInt-size)/w.stride {
+				return fmt.Errorf(prefix+"%d elements with stride%s = %d overflow int", batchCount, w.name, w.stride)
+			}
+		}
+		if need := (batchCount-1)*w.stride + size; len(w.data) < need {
+			return fmt.Errorf(prefix+"len(%s) = %d, want >= %d for %d elements with stride%s = %d",
+				w.arg, len(w.data), need, batchCount, w.name, w.stride)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestDgemmStridedBatchCheck(t *testing.T) {
+	type params struct {
+		tA, tB                    blas.Transpose
+		m, n, k                   int
+		lenA, lda, strideA        int
+		lenB, ldb, strideB        int
+		lenC, ldc, strideC, count int
+	}
+	// A is 2×3, B is 3×4 and C is 2×4, each dense, for three elements.
+	ok := params{blas.NoTrans, blas.NoTrans, 2, 4, 3, 18, 3, 6, 36, 4, 12, 24, 4, 8, 3}
+	for _, test := range []struct {
+		name string
+		mod  func(p *params)
+		want string // substring of the error, or empty if valid
+	}{
+		{"valid", func(p *params) {}, ""},
+		{"shared A and B", func(p *params) { p.strideA, p.strideB, p.lenA, p.lenB = 0, 0, 6, 12 }, ""},
+		{"padded windows", func(p *params) { p.strideC, p.lenC = 10, 28 }, ""},
+		{"transposed A", func(p *params) { p.tA, p.lda = blas.Trans, 2 }, ""},
+		{"empty batch", func(p *params) { p.count, p.lenA, p.lenB, p.lenC = 0, 0, 0, 0 }, ""},
+		{"k is zero", func(p *params) { p.k, p.lenA, p.lenB, p.lda = 0, 0, 0, 1 }, ""},
+		{"single element overlapping stride", func(p *params) { p.count, p.strideC = 1, 1 }, ""},
+
+		{"bad transpose", func(p *params) { p.tB = 0 }, "illegal transpose tB"},
+		{"negative count", func(p *params) { p.count = -1 }, "batchCount = -1 < 0"},
+		{"short lda", func(p *params) { p.lda = 2 }, "lda = 2, want >= 3"},
+		{"negative stride", func(p *params) { p.strideB = -12 }, "strideB = -12 < 0"},
+		{"overlapping inputs", func(p *params) { p.strideA = 5 }, "strideA = 5, want 0 or >= 6"},
+		{"overlapping outputs", func(p *params) { p.strideC = 7 }, "strideC = 7, want 0 or >= 8"},
+		{"shared output", func(p *params) { p.strideC = 0 }, "strideC = 0 with 3 elements"},
+		{"short c", func(p *params) { p.lenC = 23 }, "len(c) = 23, want >= 24 for 3 elements"},
+		{"short b", func(p *params) { p.strideB, p.lenB = 0, 11 }, "len(b) = 11, want >= 12"},
+	} {
+		p := ok
+		test.mod(&p)
+		err := Blasser.DgemmStridedBatchCheck(p.tA, p.tB, p.m, p.n, p.k,
+			make([]float64, p.lenA), p.lda, p.strideA,
+			make([]float64, p.lenB), p.ldb, p.strideB,
+			make([]float64, p.lenC), p.ldc, p.strideC, p.count)
+		switch {
+		case test.want == "" && err != nil:
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		case test.want != "" && (err == nil || !strings.Contains(err.Error(), test.want)):
+			t.Errorf("%s: got error %v, want one containing %q", test.name, err, test.want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"time"
+
+	"github.com/gonum/blas"
+)
+
+// DgemmTransC computes C^T := beta * C^T + alpha * A * B, that is, it stores
+// the transpose of the m×n result of Dgemm in c. The parameters other than c
+// and ldc have the same meaning as for Dgemm. C is stored as an n×m matrix
+// with stride ldc >= max(1, m), so that element (i, j) of the product A * B
+// is c[j*ldc+i]. Equivalently, c holds the m×n product in column-major order
+// with leading dimension ldc.
+//
+// No transpose is performed: since (A * B)^T = B^T * A^T, DgemmTransC calls
+// the Dgemm kernel with the operands exchanged and their transpose flags
+// inverted, so it costs the same as the corresponding call to Dgemm. The
+// cases in which C, A and B are not referenced are the same as for Dgemm.
+func (bl Blas) DgemmTransC(tA, tB blas.Transpose, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	amat, bmat := dgemmOperands(tA, tB, m, n, k, a, lda, b, ldb)
+	cmat := general{
+		data:   c,
+		rows:   n,
+		cols:   m,
+		stride: ldc,
+	}
+	err := cmat.check()
+	if err != nil {
+		panic(dgemmMatError(err, "c", "", "n", "m", blas.NoTrans, cmat))
+	}
+	if bl.debug && !finite(alpha, beta) {
+		panic(nonFiniteScalar)
+	}
+	tA, tB = invertTrans(tA), invertTrans(tB)
+	if bl.stats == nil {
+		bl.dgemm(tB, tA, bmat, amat, cmat, alpha, beta)
+		return
+	}
+	start := time.Now()
+	plan := bl.dgemm(tB, tA, bmat, amat, cmat, alpha, beta)
+	bl.stats.record(bl.dgemmStats(m, n, k, alpha, start, plan.parallel()))
+}
+
+// invertTrans returns blas.Trans for blas.NoTrans and blas.NoTrans for
+// blas.Trans.
+func invertTrans(t blas.Transpose) blas.Transpose {
+	if t == blas.NoTrans {
+		return blas.Trans
+	}
+	return blas.NoTrans
+}
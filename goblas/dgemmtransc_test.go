@@ -0,0 +1,89 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestDgemmTransC(t *testing.T) {
+	for i, test := range []struct {
+		m, n, k     int
+		pad         int
+		alpha, beta float64
+	}{
+		{0, 3, 2, 1, 1, 0},
+		{3, 0, 2, 1, 1, 0},
+		{1, 1, 1, 0, 2, 0},
+		{3, 4, 0, 1, 1, 0.5},
+		{3, 4, 5, 0, 1, 0},
+		{5, 3, 4, 2, -1.5, 0.5},
+		{7, 2, 9, 1, 0, 2},
+		{130, 70, 90, 3, 0.5, -1},
+	} {
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, tB := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				m, n, k := test.m, test.n, test.k
+				var a, b general
+				if tA == blas.NoTrans {
+					a = randmat(m, k, k+test.pad)
+				} else {
+					a = randmat(k, m, m+test.pad)
+				}
+				if tB == blas.NoTrans {
+					b = randmat(k, n, n+test.pad)
+				} else {
+					b = randmat(n, k, k+test.pad)
+				}
+				c := randmat(m, n, n+test.pad)
+				ct := randmat(n, m, m+test.pad)
+				for r := 0; r < m; r++ {
+					for j := 0; j < n; j++ {
+						ct.data[j*ct.stride+r] = c.at(r, j)
+					}
+				}
+				ctCopy := ct.clone()
+
+				Blasser.Dgemm(tA, tB, m, n, k, test.alpha, a.data, a.stride, b.data, b.stride, test.beta, c.data, c.stride)
+				Blasser.DgemmTransC(tA, tB, m, n, k, test.alpha, a.data, a.stride, b.data, b.stride, test.beta, ct.data, ct.stride)
+				for j := 0; j < n; j++ {
+					for r := 0; r < ct.stride; r++ {
+						want := ctCopy.data[j*ct.stride+r]
+						if r < m {
+							want = c.at(r, j)
+						}
+						if math.Abs(ct.data[j*ct.stride+r]-want) > 1e-12 {
+							t.Errorf("Case %v, tA = %c, tB = %c: mismatch at (%v, %v): got %v, want %v", i, tA, tB, r, j, ct.data[j*ct.stride+r], want)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// c is n×m, so an ldc of n < m is too small.
+	msg := panicMessage(func() {
+		Blasser.DgemmTransC(blas.NoTrans, blas.NoTrans, 3, 2, 1, 1, make([]float64, 3), 1, make([]float64, 2), 2, 0, make([]float64, 6), 2)
+	})
+	if want := "goblas: Dgemm: general: illegal stride: c is stored as 2×3, which needs ldc >= 3 and len(c) >= 6; got ldc = 2, len(c) = 6"; msg != want {
+		t.Errorf("unexpected panic for short ldc: got %q, want %q", msg, want)
+	}
+	for _, f := range []func(){
+		func() { Blasser.DgemmTransC(blas.NoTrans, blas.NoTrans, -1, 2, 1, 1, nil, 1, nil, 2, 0, nil, 1) },
+		func() {
+			Blasser.DgemmTransC(blas.NoTrans, blas.NoTrans, 3, 2, 2, 1, make([]float64, 6), 1, make([]float64, 4), 2, 0, make([]float64, 6), 3)
+		},
+		func() {
+			Blasser.DgemmTransC(blas.NoTrans, blas.NoTrans, 3, 2, 1, 1, make([]float64, 3), 1, make([]float64, 2), 2, 0, make([]float64, 5), 3)
+		},
+	} {
+		if !panics(f) {
+			t.Errorf("Expected panic")
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import "github.com/gonum/blas"
+
+// DgemvDot computes y as Dgemv does and returns the dot product of the
+// updated y with z, which has the same length as y and increment incZ. z is
+// only read, so it may be the same vector as x, as in the Rayleigh quotient
+// step of power iteration. If tA is blas.NoTrans, each element of y is
+// complete once its row of A has been traversed, so the dot product is
+// accumulated in the same pass. With A transposed y is only complete at the
+// end and the dot product takes one more pass over y and z, which is cheap
+// compared to the pass over A.
+func (b Blas) DgemvDot(tA blas.Transpose, m, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int, z []float64, incZ int) float64 {
+	b.checkDgemv(tA, m, n, alpha, lda, incX, beta, incY)
+	if incZ == 0 {
+		panic(zeroInc)
+	}
+	lenX, lenY := m, n
+	if tA == blas.NoTrans {
+		lenX, lenY = n, m
+	}
+	if tA != blas.NoTrans || m == 0 || n == 0 || alpha == 0 {
+		b.Dgemv(tA, m, n, alpha, a, lda, x, incX, beta, y, incY)
+		return b.Ddot(lenY, y, incY, z, incZ)
+	}
+
+	if b.debug && vecOverlap(lenX, x, incX, lenY, y, incY) {
+		panic(badOverlap)
+	}
+	var kx, ky, kz int
+	if incX < 0 {
+		kx = -(lenX - 1) * incX
+	}
+	if incY < 0 {
+		ky = -(lenY - 1) * incY
+	}
+	if incZ < 0 {
+		kz = -(lenY - 1) * incZ
+	}
+
+	var dot float64
+	iy, iz := ky, kz
+	for i := 0; i < m; i++ {
+		jx := kx
+		var temp float64
+		for _, v := range a[lda*i : lda*i+n] {
+			temp += v * x[jx]
+			jx += incX
+		}
+		temp *= alpha
+		switch beta {
+		case 0:
+		case 1:
+			temp += y[iy]
+		default:
+			temp += beta * y[iy]
+		}
+		y[iy] = temp
+		dot += temp * z[iz]
+		iy += incY
+		iz += incZ
+	}
+	return dot
+}
@@ -0,0 +1,75 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestDgemvDot(t *testing.T) {
+	for _, test := range []struct {
+		m, n        int
+		alpha, beta float64
+	}{
+		{0, 3, 1, 0.5},
+		{3, 0, 1, 0.5},
+		{1, 1, 2, 0},
+		{5, 7, 2, 0},
+		{5, 7, -1, 1},
+		{7, 5, 0.5, -2},
+		{7, 5, 0, 3},
+	} {
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, inc := range []struct{ x, y, z int }{{1, 1, 1}, {2, -1, 3}, {-2, 3, -1}} {
+				m, n := test.m, test.n
+				lenX, lenY := n, m
+				if tA == blas.Trans {
+					lenX, lenY = m, n
+				}
+				a := randmat(m, n, n+1)
+				x := randSlice(max(0, (lenX-1)*abs(inc.x)+1))
+				y := randSlice(max(0, (lenY-1)*abs(inc.y)+1))
+				z := randSlice(max(0, (lenY-1)*abs(inc.z)+1))
+				want := append([]float64(nil), y...)
+				Blasser.Dgemv(tA, m, n, test.alpha, a.data, a.stride, x, inc.x, test.beta, want, inc.y)
+				wantDot := Blasser.Ddot(lenY, want, inc.y, z, inc.z)
+
+				dot := Blasser.DgemvDot(tA, m, n, test.alpha, a.data, a.stride, x, inc.x, test.beta, y, inc.y, z, inc.z)
+				for i := range y {
+					if math.Abs(y[i]-want[i]) > 1e-14 {
+						t.Errorf("m = %v, n = %v, tA = %c, inc = %v: y[%v] = %v, want %v", m, n, tA, inc, i, y[i], want[i])
+					}
+				}
+				if math.Abs(dot-wantDot) > 1e-13 {
+					t.Errorf("m = %v, n = %v, tA = %c, inc = %v: dot = %v, want %v", m, n, tA, inc, dot, wantDot)
+				}
+			}
+		}
+	}
+
+	// z may be x, as in the Rayleigh quotient x^T A x.
+	a := randmat(4, 4, 4)
+	x := randSlice(4)
+	y := make([]float64, 4)
+	got := Blasser.DgemvDot(blas.NoTrans, 4, 4, 1, a.data, 4, x, 1, 0, y, 1, x, 1)
+	var want float64
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			want += x[i] * a.at(i, j) * x[j]
+		}
+	}
+	if math.Abs(got-want) > 1e-14 {
+		t.Errorf("Rayleigh quotient: got %v, want %v", got, want)
+	}
+
+	if !panics(func() {
+		Blasser.DgemvDot(blas.NoTrans, 1, 1, 1, []float64{1}, 1, []float64{1}, 1, 0, []float64{1}, 1, []float64{1}, 0)
+	}) {
+		t.Errorf("expected panic for zero incZ")
+	}
+}
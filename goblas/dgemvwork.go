@@ -0,0 +1,129 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"sync"
+
+	"github.com/gonum/blas"
+)
+
+const shortWork = "goblas: work too short"
+
+// DgemvWorkLen returns the length of the workspace that DgemvWork needs for
+// a multiplication with the given transpose flag and dimensions. It is zero
+// if tA is blas.NoTrans, and otherwise n times the number of workers of bl,
+// which depends on GOMAXPROCS at the time of the call.
+func (bl Blas) DgemvWorkLen(tA blas.Transpose, m, n int) int {
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if tA == blas.NoTrans {
+		return 0
+	}
+	return bl.workers() * n
+}
+
+// DgemvWork computes the same result as Dgemv, partitioning the rows of A
+// among the workers of bl if A is large enough. With A not transposed, each
+// worker computes its own elements of y. With A transposed, every row of A
+// contributes to all of y, so each worker accumulates its rows into a
+// private n-element partial sum that is added to y at the end, in the order
+// set by WithReduceStrategy. The partial sums are stored in work, which must
+// have at least DgemvWorkLen(tA, m, n) elements and is overwritten, so that
+// a caller in a loop can reuse it instead of DgemvWork allocating it on each
+// call. Starting the workers still allocates a small amount that does not
+// depend on m or n.
+func (bl Blas) DgemvWork(tA blas.Transpose, m, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int, work []float64) {
+	bl.checkDgemv(tA, m, n, alpha, lda, incX, beta, incY)
+	if len(work) < bl.DgemvWorkLen(tA, m, n) {
+		panic(shortWork)
+	}
+	nWorkers := min(bl.workers(), m)
+	if m*n < minParScale || nWorkers < 2 {
+		bl.Dgemv(tA, m, n, alpha, a, lda, x, incX, beta, y, incY)
+		return
+	}
+
+	lenX, lenY := m, n
+	if tA == blas.NoTrans {
+		lenX, lenY = n, m
+	}
+	if bl.debug && vecOverlap(lenX, x, incX, lenY, y, incY) {
+		panic(badOverlap)
+	}
+	var kx, ky int
+	if incX < 0 {
+		kx = -(lenX - 1) * incX
+	}
+	if incY < 0 {
+		ky = -(lenY - 1) * incY
+	}
+	if incY > 0 {
+		bl.scaleVec(lenY, beta, y, incY)
+	} else {
+		bl.scaleVec(lenY, beta, y, -incY)
+	}
+	if alpha == 0 {
+		return
+	}
+
+	if tA == blas.NoTrans {
+		bl.parallelRows(m, n, func(i, r int) {
+			for ; r > 0; i, r = i+1, r-1 {
+				var temp float64
+				jx := kx
+				for _, v := range a[i*lda : i*lda+n] {
+					temp += v * x[jx]
+					jx += incX
+				}
+				y[ky+i*incY] += alpha * temp
+			}
+		})
+		return
+	}
+
+	rowsPer := (m + nWorkers - 1) / nWorkers
+	nWorkers = (m + rowsPer - 1) / rowsPer
+	var (
+		wg sync.WaitGroup
+		p  firstPanic
+	)
+	for w := 0; w < nWorkers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			defer func() {
+				if v := recover(); v != nil {
+					p.record(v)
+				}
+			}()
+			partial := work[w*n : (w+1)*n]
+			for j := range partial {
+				partial[j] = 0
+			}
+			for i := w * rowsPer; i < min((w+1)*rowsPer, m); i++ {
+				tmp := alpha * x[kx+i*incX]
+				for j, v := range a[i*lda : i*lda+n] {
+					partial[j] += v * tmp
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	p.repanic()
+
+	jy := ky
+	for j := 0; j < n; j++ {
+		y[jy] += bl.reduce.reduce(work, n, nWorkers, j)
+		jy += incY
+	}
+}
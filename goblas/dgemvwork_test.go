@@ -0,0 +1,77 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestDgemvWork(t *testing.T) {
+	for _, bl := range []Blas{Blasser, New(WithMaxWorkers(1)), New(WithMaxWorkers(3))} {
+		for _, test := range []struct {
+			m, n, lda int
+		}{
+			{3, 4, 5},
+			{minParScale / 64, 64, 70},
+			{3, minParScale / 2, minParScale / 2},
+		} {
+			for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				for _, inc := range [][2]int{{1, 1}, {2, -1}, {-3, 2}} {
+					incX, incY := inc[0], inc[1]
+					m, n := test.m, test.n
+					lenX, lenY := n, m
+					if tA == blas.Trans {
+						lenX, lenY = m, n
+					}
+					a := randmat(m, n, test.lda)
+					x := randSlice(1 + (lenX-1)*abs(incX))
+					y := randSlice(1 + (lenY-1)*abs(incY))
+					want := append([]float64(nil), y...)
+					bl.Dgemv(tA, m, n, 1.5, a.data, a.stride, x, incX, 0.5, want, incY)
+
+					// Fill work with NaN to check that it is overwritten.
+					work := make([]float64, bl.DgemvWorkLen(tA, m, n))
+					for i := range work {
+						work[i] = math.NaN()
+					}
+					bl.DgemvWork(tA, m, n, 1.5, a.data, a.stride, x, incX, 0.5, y, incY, work)
+					for i := range y {
+						if math.Abs(y[i]-want[i]) > 1e-10 {
+							t.Errorf("workers = %d, m = %d, n = %d, tA = %c, incX = %d, incY = %d: mismatch at %d: got %v, want %v",
+								bl.workers(), m, n, tA, incX, incY, i, y[i], want[i])
+							break
+						}
+					}
+				}
+			}
+		}
+	}
+
+	bl := New(WithMaxWorkers(3))
+	if got, want := bl.DgemvWorkLen(blas.Trans, 10, 7), 7*bl.workers(); got != want {
+		t.Errorf("unexpected work length for Trans: got %d, want %d", got, want)
+	}
+	if got := bl.DgemvWorkLen(blas.NoTrans, 10, 7); got != 0 {
+		t.Errorf("unexpected work length for NoTrans: got %d, want 0", got)
+	}
+	a := make([]float64, 12)
+	for _, f := range []func(){
+		func() {
+			bl.DgemvWork(blas.Trans, 3, 4, 1, a, 4, make([]float64, 3), 1, 0, make([]float64, 4), 1, make([]float64, 4*bl.workers()-1))
+		},
+		func() { bl.DgemvWork(blas.Trans, 3, 4, 1, a, 4, make([]float64, 3), 1, 0, make([]float64, 4), 1, nil) },
+		func() { bl.DgemvWork('x', 3, 4, 1, a, 4, make([]float64, 4), 1, 0, make([]float64, 3), 1, nil) },
+		func() { bl.DgemvWorkLen(blas.Trans, -1, 4) },
+	} {
+		if !panics(f) {
+			t.Errorf("Expected panic")
+		}
+	}
+	// A NoTrans multiplication needs no workspace.
+	bl.DgemvWork(blas.NoTrans, 3, 4, 1, a, 4, make([]float64, 4), 1, 0, make([]float64, 3), 1, nil)
+}
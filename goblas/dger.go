@@ -0,0 +1,115 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+const badBatch = "goblas: xs and ys have different lengths"
+
+// DgerBatch performs the sequence of rank one updates
+//
+//	A := alpha*xs[b]*ys[b]^T + A,  b = 0, ..., len(xs)-1,
+//
+// where A is an m×n matrix with stride lda and every xs[b] and ys[b] are
+// tightly packed vectors of at least m and n elements. The result is the same
+// as calling Dger once for each pair, but A is traversed only once, with all
+// updates applied to a row while it is in cache. The rows of A are partitioned
+// among the workers, so no two goroutines update the same element.
+func (bl Blas) DgerBatch(m, n int, alpha float64, xs, ys [][]float64, a []float64, lda int) {
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if len(xs) != len(ys) {
+		panic(badBatch)
+	}
+	for b := range xs {
+		if len(xs[b]) < m {
+			panic("goblas: x too short")
+		}
+		if len(ys[b]) < n {
+			panic("goblas: y too short")
+		}
+	}
+	amat := general{
+		data:   a,
+		rows:   m,
+		cols:   n,
+		stride: lda,
+	}
+	amat.mustCheck()
+	if m == 0 || n == 0 || alpha == 0 || len(xs) == 0 {
+		return
+	}
+
+	bl.parallelRows(m, n*len(xs), func(i, r int) {
+		for l := i; l < i+r; l++ {
+			atmp := a[l*lda : l*lda+n]
+			for b, x := range xs {
+				tmp := alpha * x[l]
+				if tmp == 0 && !bl.strict {
+					continue
+				}
+				for j, v := range ys[b][:n] {
+					atmp[j] += tmp * v
+				}
+			}
+		}
+	})
+}
+
+// DgerSym performs the symmetric rank one update
+//
+//	A := alpha*x*x^T + A,
+//
+// where A is an n×n general matrix with stride lda. Unlike Dsyr, which only
+// references one triangle, both triangles of A are updated. Each product
+// alpha*x[i]*x[j] is computed once and added to both A[i][j] and A[j][i], so
+// a symmetric A stays exactly symmetric. In debug mode, a downdate with
+// alpha < 0 panics if it leaves a positive diagonal element non-positive.
+func (bl Blas) DgerSym(n int, alpha float64, x []float64, incX int, a []float64, lda int) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if incX == 0 {
+		panic(zeroInc)
+	}
+	if lda < max(1, n) {
+		panic(badLdaRow)
+	}
+	if n == 0 || alpha == 0 {
+		return
+	}
+
+	var before []bool
+	if bl.debug && alpha < 0 {
+		before = positiveDiag(n, 1, a, lda)
+	}
+
+	var kx int
+	if incX < 0 {
+		kx = -(n - 1) * incX
+	}
+	ix := kx
+	for i := 0; i < n; i++ {
+		if x[ix] == 0 && !bl.strict {
+			ix += incX
+			continue
+		}
+		tmp := alpha * x[ix]
+		a[i*lda+i] += tmp * x[ix]
+		jx := ix + incX
+		for j := i + 1; j < n; j++ {
+			v := tmp * x[jx]
+			a[i*lda+j] += v
+			a[j*lda+i] += v
+			jx += incX
+		}
+		ix += incX
+	}
+	if before != nil {
+		checkDowndate("DgerSym", before, a, lda)
+	}
+}
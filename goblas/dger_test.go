@@ -0,0 +1,158 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestDgerIncrements compares Dger with a direct computation for all signs
+// of the increments, at sizes that are updated serially and concurrently.
+func TestDgerIncrements(t *testing.T) {
+	for _, test := range []struct {
+		m, n, lda int
+	}{
+		{3, 4, 5},
+		{minParScale / 16, 17, 20},
+	} {
+		for _, incX := range []int{1, 2, -1, -3} {
+			for _, incY := range []int{1, 3, -2} {
+				m, n := test.m, test.n
+				x := randSlice(1 + (m-1)*abs(incX))
+				y := randSlice(1 + (n-1)*abs(incY))
+				// Exercise the zero skip.
+				x[0] = 0
+				a := randmat(m, n, test.lda)
+				want := a.clone()
+				for i := 0; i < m; i++ {
+					xi := x[i*abs(incX)]
+					if incX < 0 {
+						xi = x[(m-1-i)*-incX]
+					}
+					for j := 0; j < n; j++ {
+						yj := y[j*abs(incY)]
+						if incY < 0 {
+							yj = y[(n-1-j)*-incY]
+						}
+						want.data[i*want.stride+j] += 1.5 * xi * yj
+					}
+				}
+				for _, impl := range []Blas{Blasser, New(WithMaxWorkers(1))} {
+					got := a.clone()
+					impl.Dger(m, n, 1.5, x, incX, y, incY, got.data, got.stride)
+					if !got.equalWithinAbs(want, 1e-14) {
+						t.Errorf("m = %d, incX = %d, incY = %d, workers = %d: result mismatch", m, incX, incY, impl.maxWorkers)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestDgerBatch(t *testing.T) {
+	for i, test := range []struct {
+		m, n, lda, batch int
+	}{
+		{0, 3, 3, 2},
+		{3, 0, 1, 2},
+		{3, 4, 4, 0},
+		{1, 1, 1, 1},
+		{3, 4, 4, 5},
+		{5, 3, 7, 3},
+		{minParScale / 64, 16, 20, 4},
+	} {
+		xs := make([][]float64, test.batch)
+		ys := make([][]float64, test.batch)
+		for b := range xs {
+			xs[b] = randSlice(test.m)
+			ys[b] = randSlice(test.n)
+		}
+		// Exercise the zero skip.
+		if test.batch > 0 && test.m > 0 {
+			xs[0][0] = 0
+		}
+		a := randmat(test.m, test.n, test.lda)
+		want := a.clone()
+		for b := range xs {
+			Blasser.Dger(test.m, test.n, 1.5, xs[b], 1, ys[b], 1, want.data, want.stride)
+		}
+		Blasser.DgerBatch(test.m, test.n, 1.5, xs, ys, a.data, a.stride)
+		if !a.equalWithinAbs(want, 1e-12) {
+			t.Errorf("Case %v: answer mismatch", i)
+		}
+	}
+
+	for _, f := range []func(){
+		func() { Blasser.DgerBatch(-1, 2, 1, nil, nil, nil, 2) },
+		func() { Blasser.DgerBatch(2, -1, 1, nil, nil, nil, 1) },
+		func() { Blasser.DgerBatch(2, 2, 1, make([][]float64, 1), nil, make([]float64, 4), 2) },
+		func() { Blasser.DgerBatch(2, 2, 1, [][]float64{{1}}, [][]float64{{1, 2}}, make([]float64, 4), 2) },
+		func() { Blasser.DgerBatch(2, 2, 1, [][]float64{{1, 2}}, [][]float64{{1}}, make([]float64, 4), 2) },
+		func() { Blasser.DgerBatch(2, 2, 1, nil, nil, make([]float64, 4), 1) },
+		func() { Blasser.DgerBatch(2, 2, 1, nil, nil, make([]float64, 3), 2) },
+	} {
+		if !panics(f) {
+			t.Errorf("Expected panic")
+		}
+	}
+}
+
+func randSlice(n int) []float64 {
+	s := make([]float64, n)
+	for i := range s {
+		s[i] = rand.Float64()
+	}
+	return s
+}
+
+func TestDgerSym(t *testing.T) {
+	for i, test := range []struct {
+		n, lda, incX int
+	}{
+		{0, 1, 1},
+		{1, 1, 1},
+		{3, 3, 1},
+		{4, 6, 2},
+		{5, 5, -3},
+	} {
+		n := test.n
+		x := randSlice(max(0, (n-1)*abs(test.incX)+1))
+		if n > 1 {
+			// Exercise the zero skip.
+			x[0] = 0
+		}
+		a := randmat(n, n, test.lda)
+		// Make A symmetric so that the result must be symmetric too.
+		for r := 0; r < n; r++ {
+			for c := 0; c < r; c++ {
+				a.data[r*a.stride+c] = a.data[c*a.stride+r]
+			}
+		}
+		want := a.clone()
+		Blasser.Dger(n, n, 0.5, x, test.incX, x, test.incX, want.data, want.stride)
+		Blasser.DgerSym(n, 0.5, x, test.incX, a.data, a.stride)
+		if !a.equalWithinAbs(want, 1e-14) {
+			t.Errorf("Case %v: answer mismatch", i)
+		}
+		for r := 0; r < n; r++ {
+			for c := 0; c < r; c++ {
+				if a.at(r, c) != a.at(c, r) {
+					t.Errorf("Case %v: result not symmetric at (%v, %v)", i, r, c)
+				}
+			}
+		}
+	}
+
+	for _, f := range []func(){
+		func() { Blasser.DgerSym(-1, 1, nil, 1, nil, 1) },
+		func() { Blasser.DgerSym(2, 1, make([]float64, 2), 0, make([]float64, 4), 2) },
+		func() { Blasser.DgerSym(2, 1, make([]float64, 2), 1, make([]float64, 4), 1) },
+	} {
+		if !panics(f) {
+			t.Errorf("Expected panic")
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package goblas
+
+import "testing"
+
+// The following benchmarks perform a rank one update of a 50000×500 matrix,
+// with the rows of A partitioned among the default number of workers and
+// with a single worker.
+
+func BenchmarkDger50000x500(b *testing.B) {
+	benchmarkDger(b, Blas{}, 50000, 500)
+}
+
+func BenchmarkDger50000x500Serial(b *testing.B) {
+	benchmarkDger(b, New(WithMaxWorkers(1)), 50000, 500)
+}
+
+func benchmarkDger(b *testing.B, impl Blas, m, n int) {
+	a := randSlice(m * n)
+	x := randSlice(m)
+	y := randSlice(n)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		impl.Dger(m, n, 1e-6, x, 1, y, 1, a, n)
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import "github.com/gonum/blas"
+
+// DgramTransposed computes the Gram matrix of the columns of A,
+//
+//	G := A^T * A,
+//
+// where A is an m×n matrix with stride lda and G is an n×n matrix with
+// stride ldg. The upper triangle is computed by Dsyrk, at about half the
+// cost of the equivalent Dgemm, and then mirrored into the lower triangle,
+// so both triangles of G are set and G is exactly symmetric. G must not
+// overlap A.
+func (bl Blas) DgramTransposed(m, n int, a []float64, lda int, g []float64, ldg int) {
+	bl.Dsyrk(blas.Upper, blas.Trans, n, m, 1, a, lda, 0, g, ldg)
+	for i := 1; i < n; i++ {
+		for j := 0; j < i; j++ {
+			g[i*ldg+j] = g[j*ldg+i]
+		}
+	}
+}
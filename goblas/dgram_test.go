@@ -0,0 +1,53 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestDgramTransposed(t *testing.T) {
+	for i, test := range []struct {
+		m, n, lda, ldg int
+	}{
+		{0, 3, 3, 3},
+		{3, 0, 1, 1},
+		{1, 1, 1, 1},
+		{5, 3, 3, 3},
+		{3, 5, 7, 6},
+		{200, 100, 100, 101},
+	} {
+		a := randmat(test.m, test.n, test.lda)
+		g := randmat(test.n, test.n, test.ldg)
+		want := g.clone()
+		Blasser.DgemmReference(blas.Trans, blas.NoTrans, test.n, test.n, test.m, 1, a.data, a.stride, a.data, a.stride, 0, want.data, want.stride)
+		Blasser.DgramTransposed(test.m, test.n, a.data, a.stride, g.data, g.stride)
+		if !generalEqualWithinAbs(g, want, 1e-12) {
+			t.Errorf("Case %v: answer mismatch", i)
+		}
+		for r := 0; r < test.n; r++ {
+			for c := 0; c < r; c++ {
+				if g.at(r, c) != g.at(c, r) {
+					t.Errorf("Case %v: not symmetric at (%v, %v)", i, r, c)
+				}
+			}
+			// The padding must be untouched.
+			for c := test.n; c < test.ldg; c++ {
+				if g.data[r*g.stride+c] != want.data[r*want.stride+c] {
+					t.Errorf("Case %v: padding modified at (%v, %v)", i, r, c)
+				}
+			}
+		}
+	}
+	// Stale NaNs in G are overwritten.
+	g := []float64{math.NaN(), math.NaN(), math.NaN(), math.NaN()}
+	Blasser.DgramTransposed(1, 2, []float64{1, 2}, 2, g, 2)
+	if g[0] != 1 || g[1] != 2 || g[2] != 2 || g[3] != 4 {
+		t.Errorf("NaN input not overwritten: %v", g)
+	}
+}
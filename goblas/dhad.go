@@ -0,0 +1,42 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+// Dhad computes the Hadamard (element-wise) product
+//
+//	C[i][j] := A[i][j] * B[i][j],
+//
+// where A, B and C are m×n matrices with strides lda, ldb and ldc. C may be
+// the same matrix as A or B, but must not otherwise overlap them. For large
+// matrices the rows are partitioned among the workers.
+func (bl Blas) Dhad(m, n int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int) {
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	for _, g := range []general{
+		{data: a, rows: m, cols: n, stride: lda},
+		{data: b, rows: m, cols: n, stride: ldb},
+		{data: c, rows: m, cols: n, stride: ldc},
+	} {
+		g.mustCheck()
+	}
+	if m == 0 || n == 0 {
+		return
+	}
+
+	bl.parallelRows(m, n, func(i, r int) {
+		for l := i; l < i+r; l++ {
+			atmp := a[l*lda : l*lda+n]
+			btmp := b[l*ldb : l*ldb+n]
+			ctmp := c[l*ldc : l*ldc+n]
+			for j, v := range atmp {
+				ctmp[j] = v * btmp[j]
+			}
+		}
+	})
+}
@@ -0,0 +1,57 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import "testing"
+
+func TestDhad(t *testing.T) {
+	for i, test := range []struct {
+		m, n, lda, ldb, ldc int
+	}{
+		{0, 3, 3, 3, 3},
+		{3, 0, 1, 1, 1},
+		{1, 1, 1, 1, 1},
+		{3, 4, 4, 5, 6},
+		{5, 3, 7, 3, 4},
+		{minParScale / 16, 16, 20, 17, 16},
+	} {
+		a := randmat(test.m, test.n, test.lda)
+		b := randmat(test.m, test.n, test.ldb)
+		c := randmat(test.m, test.n, test.ldc)
+		cCopy := c.clone()
+		Blasser.Dhad(test.m, test.n, a.data, a.stride, b.data, b.stride, c.data, c.stride)
+		for r := 0; r < test.m; r++ {
+			for j := 0; j < test.ldc; j++ {
+				want := cCopy.data[r*c.stride+j]
+				if j < test.n {
+					want = a.at(r, j) * b.at(r, j)
+				}
+				if c.data[r*c.stride+j] != want {
+					t.Errorf("Case %v: mismatch at (%v, %v)", i, r, j)
+				}
+			}
+		}
+
+		// In-place product with c aliasing a.
+		want := c.clone()
+		Blasser.Dhad(test.m, test.n, c.data, c.stride, c.data, c.stride, want.data, want.stride)
+		Blasser.Dhad(test.m, test.n, c.data, c.stride, c.data, c.stride, c.data, c.stride)
+		if !c.equalWithinAbs(want, 0) {
+			t.Errorf("Case %v: in-place answer mismatch", i)
+		}
+	}
+
+	for _, f := range []func(){
+		func() { Blasser.Dhad(-1, 2, nil, 2, nil, 2, nil, 2) },
+		func() { Blasser.Dhad(2, -1, nil, 1, nil, 1, nil, 1) },
+		func() { Blasser.Dhad(2, 2, make([]float64, 4), 1, make([]float64, 4), 2, make([]float64, 4), 2) },
+		func() { Blasser.Dhad(2, 2, make([]float64, 4), 2, make([]float64, 3), 2, make([]float64, 4), 2) },
+		func() { Blasser.Dhad(2, 2, make([]float64, 4), 2, make([]float64, 4), 2, make([]float64, 4), 1) },
+	} {
+		if !panics(f) {
+			t.Errorf("Expected panic")
+		}
+	}
+}
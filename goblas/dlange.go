@@ -0,0 +1,105 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import "math"
+
+// Dlange returns a norm of the m×n matrix A with stride lda, in the manner
+// of the LAPACK routine of the same name. norm selects the norm:
+//
+//	'M':      max |A[i][j]|, the largest absolute value
+//	'1', 'O': max_j sum_i |A[i][j]|, the largest absolute column sum
+//	'I':      max_i sum_j |A[i][j]|, the largest absolute row sum
+//	'F', 'E': sqrt(sum_ij A[i][j]^2), the Frobenius norm
+//
+// The Frobenius norm is accumulated with the same scaling as Dnrm2, so it
+// does not overflow or underflow unless the result does. Dlange returns 0 if
+// m or n is zero. A NaN element makes the result NaN.
+func (Blas) Dlange(norm byte, m, n int, a []float64, lda int) float64 {
+	switch norm {
+	case 'M', '1', 'O', 'I', 'F', 'E':
+	default:
+		panic("goblas: illegal norm")
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	amat := general{
+		data:   a,
+		rows:   m,
+		cols:   n,
+		stride: lda,
+	}
+	amat.mustCheck()
+	if m == 0 || n == 0 {
+		return 0
+	}
+
+	var value float64
+	switch norm {
+	case 'M':
+		for i := 0; i < m; i++ {
+			for _, v := range a[i*lda : i*lda+n] {
+				v = math.Abs(v)
+				if v > value || math.IsNaN(v) {
+					value = v
+				}
+			}
+			if math.IsNaN(value) {
+				return value
+			}
+		}
+	case '1', 'O':
+		sums := make([]float64, n)
+		for i := 0; i < m; i++ {
+			for j, v := range a[i*lda : i*lda+n] {
+				sums[j] += math.Abs(v)
+			}
+		}
+		for _, v := range sums {
+			if v > value || math.IsNaN(v) {
+				value = v
+			}
+		}
+	case 'I':
+		for i := 0; i < m; i++ {
+			var sum float64
+			for _, v := range a[i*lda : i*lda+n] {
+				sum += math.Abs(v)
+			}
+			if sum > value || math.IsNaN(sum) {
+				value = sum
+			}
+			if math.IsNaN(value) {
+				return value
+			}
+		}
+	case 'F', 'E':
+		scale := 0.0
+		sumSquares := 1.0
+		for i := 0; i < m; i++ {
+			for _, v := range a[i*lda : i*lda+n] {
+				if v == 0 {
+					continue
+				}
+				absxi := math.Abs(v)
+				if math.IsNaN(absxi) {
+					return math.NaN()
+				}
+				if scale < absxi {
+					sumSquares = 1 + sumSquares*(scale/absxi)*(scale/absxi)
+					scale = absxi
+				} else {
+					sumSquares = sumSquares + (absxi/scale)*(absxi/scale)
+				}
+			}
+		}
+		value = scale * math.Sqrt(sumSquares)
+	}
+	return value
+}
@@ -0,0 +1,67 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDlange(t *testing.T) {
+	// A is 2×3 with stride 4; the padding must be ignored.
+	a := []float64{
+		1, -2, 3, 100,
+		-4, 5, -6, 100,
+	}
+	for _, test := range []struct {
+		norm byte
+		want float64
+	}{
+		{'M', 6},
+		{'1', 9},
+		{'O', 9},
+		{'I', 15},
+		{'F', math.Sqrt(91)},
+		{'E', math.Sqrt(91)},
+	} {
+		got := Blasser.Dlange(test.norm, 2, 3, a, 4)
+		if math.Abs(got-test.want) > 1e-14 {
+			t.Errorf("norm %c: want %v, got %v", test.norm, test.want, got)
+		}
+	}
+
+	for _, norm := range []byte{'M', '1', 'I', 'F'} {
+		if v := Blasser.Dlange(norm, 0, 3, nil, 3); v != 0 {
+			t.Errorf("norm %c: empty matrix has norm %v", norm, v)
+		}
+
+		// The Frobenius norm must neither overflow nor underflow.
+		for _, s := range []float64{1e300, 1e-300} {
+			b := []float64{3 * s, 4 * s, 0, 0}
+			got := Blasser.Dlange(norm, 2, 2, b, 2)
+			want := map[byte]float64{'M': 4 * s, '1': 4 * s, 'I': 7 * s, 'F': 5 * s}[norm]
+			if math.Abs(got-want) > 1e-14*want {
+				t.Errorf("norm %c, scale %v: want %v, got %v", norm, s, want, got)
+			}
+		}
+
+		c := []float64{1, math.NaN(), 2, 3}
+		if v := Blasser.Dlange(norm, 2, 2, c, 2); !math.IsNaN(v) {
+			t.Errorf("norm %c: NaN not propagated, got %v", norm, v)
+		}
+	}
+
+	for _, f := range []func(){
+		func() { Blasser.Dlange('X', 2, 2, make([]float64, 4), 2) },
+		func() { Blasser.Dlange('M', -1, 2, nil, 2) },
+		func() { Blasser.Dlange('M', 2, -1, nil, 1) },
+		func() { Blasser.Dlange('M', 2, 2, make([]float64, 4), 1) },
+		func() { Blasser.Dlange('M', 2, 2, make([]float64, 3), 2) },
+	} {
+		if !panics(f) {
+			t.Errorf("Expected panic")
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import "math"
+
+const (
+	// safmin is the smallest normalized float64, and 1/safmin does not
+	// overflow.
+	safmin = 0x1p-1022
+	safmax = 1 / safmin
+)
+
+// Dlascl multiplies the m×n matrix A with stride lda by cto/cfrom, in the
+// manner of the LAPACK routine of the same name. The ratio is not formed
+// directly: if cto/cfrom would overflow or underflow, A is multiplied in
+// several steps by factors no larger than 1/safmin or smaller than safmin,
+// so that the result is computed without overflow or underflow as long as
+// the final elements are representable. cfrom must be nonzero and neither
+// cfrom nor cto may be NaN.
+func (Blas) Dlascl(m, n int, cfrom, cto float64, a []float64, lda int) {
+	if cfrom == 0 || math.IsNaN(cfrom) {
+		panic("goblas: cfrom is zero or NaN")
+	}
+	if math.IsNaN(cto) {
+		panic("goblas: cto is NaN")
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	amat := general{
+		data:   a,
+		rows:   m,
+		cols:   n,
+		stride: lda,
+	}
+	amat.mustCheck()
+	if m == 0 || n == 0 {
+		return
+	}
+
+	cfromc, ctoc := cfrom, cto
+	for done := false; !done; {
+		var mul float64
+		cfrom1 := cfromc * safmin
+		if cfrom1 == cfromc {
+			// cfromc is an infinity, so the ratio is zero or NaN.
+			mul = ctoc / cfromc
+			done = true
+		} else {
+			cto1 := ctoc / safmax
+			switch {
+			case cto1 == ctoc:
+				// ctoc is zero or an infinity, and multiplying by it
+				// directly gives the correctly signed result.
+				mul = ctoc
+				done = true
+			case math.Abs(cfrom1) > math.Abs(ctoc) && ctoc != 0:
+				mul = safmin
+				cfromc = cfrom1
+			case math.Abs(cto1) > math.Abs(cfromc):
+				mul = safmax
+				ctoc = cto1
+			default:
+				mul = ctoc / cfromc
+				done = true
+				if mul == 1 {
+					return
+				}
+			}
+		}
+		dgemmScaleSerial(amat, mul)
+	}
+}
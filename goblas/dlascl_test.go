@@ -0,0 +1,71 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDlascl(t *testing.T) {
+	for _, test := range []struct {
+		cfrom, cto float64
+		a, want    float64
+	}{
+		{2, 6, 1.5, 4.5},
+		{4, 4, 3, 3},
+		{5, 0, 3, -0},
+		// cto/cfrom overflows but the scaled elements do not.
+		{1e-300, 1e300, 1e-300, 1e300},
+		// cto/cfrom underflows but the scaled elements do not.
+		{1e300, 1e-300, 1e300, 1e-300},
+		{1e-300, 1e-10, 1e-295, 1e-5},
+		{math.Inf(1), 3, 7, 0},
+	} {
+		// A is 2×3 with stride 4; the padding must be left unchanged.
+		a := []float64{
+			test.a, -test.a, test.a, 100,
+			-test.a, test.a, -test.a, 100,
+		}
+		Blasser.Dlascl(2, 3, test.cfrom, test.cto, a, 4)
+		for i := 0; i < 2; i++ {
+			for j := 0; j < 3; j++ {
+				want := test.want
+				if (i+j)%2 == 1 {
+					want = -want
+				}
+				got := a[i*4+j]
+				if math.Abs(got-want) > 1e-14*math.Abs(want) {
+					t.Errorf("cfrom=%v cto=%v: a[%d,%d] = %v, want %v",
+						test.cfrom, test.cto, i, j, got, want)
+				}
+			}
+			if a[i*4+3] != 100 {
+				t.Errorf("cfrom=%v cto=%v: padding modified in row %d", test.cfrom, test.cto, i)
+			}
+		}
+	}
+
+	// An empty matrix is a no-op.
+	Blasser.Dlascl(0, 3, 1, 2, nil, 3)
+
+	for _, test := range []struct {
+		name       string
+		m, n, lda  int
+		cfrom, cto float64
+	}{
+		{"zero cfrom", 2, 2, 2, 0, 1},
+		{"NaN cfrom", 2, 2, 2, math.NaN(), 1},
+		{"NaN cto", 2, 2, 2, 1, math.NaN()},
+		{"m < 0", -1, 2, 2, 1, 2},
+		{"n < 0", 2, -1, 2, 1, 2},
+		{"lda < n", 2, 2, 1, 1, 2},
+	} {
+		a := make([]float64, 4)
+		if !panics(func() { Blasser.Dlascl(test.m, test.n, test.cfrom, test.cto, a, test.lda) }) {
+			t.Errorf("%s: expected panic", test.name)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+// Dlasr applies a sequence of plane rotations to the m×n matrix A with stride
+// lda, in the manner of the LAPACK routine of the same name.
+//
+// If side is 'L', A := P*A, and if side is 'R', A := A*P^T, where P is the
+// product of z-1 plane rotations and z is m or n respectively. Rotation k,
+// with cosine c[k] and sine s[k], acts in the plane (p, q) given by pivot:
+//
+//	'V' (variable): (k, k+1)
+//	'T' (top):      (0, k+1)
+//	'B' (bottom):   (k, z-1)
+//
+// and, for side 'L', replaces rows p and q of A by
+//
+//	c[k]*A[p] + s[k]*A[q] and c[k]*A[q] - s[k]*A[p],
+//
+// exactly as Drot(n, A[p], 1, A[q], 1, c[k], s[k]) would. For side 'R' it acts
+// on columns p and q in the same way. If direct is 'F', the rotations are
+// applied in the order k = 0, 1, ..., z-2, and if direct is 'B' in the reverse
+// order. Rotations with c[k] == 1 and s[k] == 0 are skipped.
+func (Blas) Dlasr(side, pivot, direct byte, m, n int, c, s []float64, a []float64, lda int) {
+	if side != 'L' && side != 'R' {
+		panic("goblas: illegal side")
+	}
+	if pivot != 'V' && pivot != 'T' && pivot != 'B' {
+		panic("goblas: illegal pivot")
+	}
+	if direct != 'F' && direct != 'B' {
+		panic("goblas: illegal direct")
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if lda < max(1, n) {
+		panic(badLda)
+	}
+	z := m
+	if side == 'R' {
+		z = n
+	}
+	if z < 2 || (side == 'L' && n == 0) || (side == 'R' && m == 0) {
+		return
+	}
+	if len(c) < z-1 || len(s) < z-1 {
+		panic("goblas: insufficient length of c or s")
+	}
+
+	for l := 0; l < z-1; l++ {
+		k := l
+		if direct == 'B' {
+			k = z - 2 - l
+		}
+		ct, st := c[k], s[k]
+		if ct == 1 && st == 0 {
+			continue
+		}
+		var p, q int
+		switch pivot {
+		case 'V':
+			p, q = k, k+1
+		case 'T':
+			p, q = 0, k+1
+		case 'B':
+			p, q = k, z-1
+		}
+		if side == 'L' {
+			ap := a[p*lda : p*lda+n]
+			aq := a[q*lda : q*lda+n]
+			for j, vp := range ap {
+				vq := aq[j]
+				ap[j], aq[j] = ct*vp+st*vq, ct*vq-st*vp
+			}
+			continue
+		}
+		for i := 0; i < m; i++ {
+			vp := a[i*lda+p]
+			vq := a[i*lda+q]
+			a[i*lda+p], a[i*lda+q] = ct*vp+st*vq, ct*vq-st*vp
+		}
+	}
+}
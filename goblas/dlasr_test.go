@@ -0,0 +1,84 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDlasr(t *testing.T) {
+	for _, side := range []byte{'L', 'R'} {
+		for _, pivot := range []byte{'V', 'T', 'B'} {
+			for _, direct := range []byte{'F', 'B'} {
+				for _, dims := range []struct{ m, n, lda int }{{0, 3, 3}, {1, 1, 1}, {4, 3, 3}, {3, 5, 7}, {6, 6, 6}} {
+					m, n, lda := dims.m, dims.n, dims.lda
+					z := m
+					if side == 'R' {
+						z = n
+					}
+					c := make([]float64, max(0, z-1))
+					s := make([]float64, max(0, z-1))
+					for k := range c {
+						theta := float64(k+1) * 0.7
+						c[k], s[k] = math.Cos(theta), math.Sin(theta)
+					}
+					if len(c) > 1 {
+						// An identity rotation is skipped.
+						c[1], s[1] = 1, 0
+					}
+					a := randmat(m, n, lda)
+					want := a.clone()
+
+					// Apply the rotations one at a time with Drot.
+					for l := 0; l < z-1; l++ {
+						k := l
+						if direct == 'B' {
+							k = z - 2 - l
+						}
+						p, q := k, k+1
+						switch pivot {
+						case 'T':
+							p = 0
+						case 'B':
+							q = z - 1
+						}
+						if side == 'L' {
+							Blasser.Drot(n, want.data[p*lda:], 1, want.data[q*lda:], 1, c[k], s[k])
+						} else if m > 0 {
+							Blasser.Drot(m, want.data[p:], lda, want.data[q:], lda, c[k], s[k])
+						}
+					}
+
+					Blasser.Dlasr(side, pivot, direct, m, n, c, s, a.data, lda)
+					if !a.equalWithinAbs(want, 1e-14) {
+						t.Errorf("side = %c, pivot = %c, direct = %c, m = %v, n = %v: answer mismatch", side, pivot, direct, m, n)
+					}
+				}
+			}
+		}
+	}
+
+	// A single rotation by 90 degrees swaps two rows, negating one.
+	a := []float64{1, 2, 3, 4}
+	Blasser.Dlasr('L', 'V', 'F', 2, 2, []float64{0}, []float64{1}, a, 2)
+	if a[0] != 3 || a[1] != 4 || a[2] != -1 || a[3] != -2 {
+		t.Errorf("unexpected result for a 90 degree rotation: %v", a)
+	}
+
+	for _, f := range []func(){
+		func() { Blasser.Dlasr('X', 'V', 'F', 2, 2, []float64{1}, []float64{0}, make([]float64, 4), 2) },
+		func() { Blasser.Dlasr('L', 'X', 'F', 2, 2, []float64{1}, []float64{0}, make([]float64, 4), 2) },
+		func() { Blasser.Dlasr('L', 'V', 'X', 2, 2, []float64{1}, []float64{0}, make([]float64, 4), 2) },
+		func() { Blasser.Dlasr('L', 'V', 'F', -1, 2, nil, nil, nil, 2) },
+		func() { Blasser.Dlasr('L', 'V', 'F', 2, -1, nil, nil, nil, 1) },
+		func() { Blasser.Dlasr('L', 'V', 'F', 2, 2, []float64{1}, []float64{0}, make([]float64, 4), 1) },
+		func() { Blasser.Dlasr('L', 'V', 'F', 3, 2, []float64{1}, []float64{0, 0}, make([]float64, 6), 2) },
+	} {
+		if !panics(f) {
+			t.Errorf("Expected panic")
+		}
+	}
+}
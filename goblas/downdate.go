@@ -0,0 +1,36 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import "fmt"
+
+// A symmetric update with a negative alpha, such as Dsyrk or DgerSym with
+// alpha < 0, subtracts a positive semi-definite matrix and may leave a
+// positive definite matrix indefinite. In debug mode these routines record
+// which diagonal elements are positive before the update and panic if one
+// of them is no longer positive after it, so that the loss of definiteness
+// is reported by the downdate that caused it rather than by a later
+// Cholesky factorization.
+
+// positiveDiag returns whether each of the n diagonal elements of a, scaled
+// by scale, is positive.
+func positiveDiag(n int, scale float64, a []float64, lda int) []bool {
+	pos := make([]bool, n)
+	for i := range pos {
+		pos[i] = scale*a[i*lda+i] > 0
+	}
+	return pos
+}
+
+// checkDowndate panics if a diagonal element of a that was positive before
+// the downdate by routine, as recorded in before, is no longer positive or
+// is NaN.
+func checkDowndate(routine string, before []bool, a []float64, lda int) {
+	for i, pos := range before {
+		if v := a[i*lda+i]; pos && !(v > 0) {
+			panic(fmt.Sprintf("goblas: %s: downdate made diagonal element %d non-positive: %v", routine, i, v))
+		}
+	}
+}
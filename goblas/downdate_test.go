@@ -0,0 +1,66 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestDowndateGuard(t *testing.T) {
+	// A = [4 1; 1 3] is positive definite.
+	spd := func() []float64 { return []float64{4, 1, 1, 3} }
+	for _, test := range []struct {
+		name  string
+		x     []float64 // x is 2×1, so x*x^T is added to A with weight alpha.
+		alpha float64
+		beta  float64
+		fails bool
+	}{
+		{"benign", []float64{1, 1}, -1, 1, false},
+		{"update", []float64{3, 3}, 1, 1, false},
+		{"breaks A[1][1]", []float64{1, 2}, -1, 1, true},
+		{"zeroes A[0][0]", []float64{2, 0}, -1, 1, true},
+		// With beta = 0, C is overwritten and no diagonal is positive beforehand.
+		{"overwrite", []float64{1, 2}, -1, 0, false},
+	} {
+		calls := map[string]func(bl Blas){
+			"Dsyrk": func(bl Blas) {
+				for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+					bl.Dsyrk(ul, blas.NoTrans, 2, 1, test.alpha, test.x, 1, test.beta, spd(), 2)
+				}
+			},
+		}
+		if test.beta == 1 {
+			calls["DgerSym"] = func(bl Blas) {
+				bl.DgerSym(2, test.alpha, test.x, 1, spd(), 2)
+			}
+		}
+		for name, f := range calls {
+			msg := panicMessage(func() { f(New(WithDebug(true))) })
+			if test.fails {
+				if !strings.HasPrefix(msg, "goblas: "+name+": downdate made diagonal element") {
+					t.Errorf("%s %s in debug mode: got panic %q, want downdate panic", name, test.name, msg)
+				}
+			} else if msg != "" {
+				t.Errorf("%s %s in debug mode: unexpected panic %q", name, test.name, msg)
+			}
+			if panics(func() { f(New()) }) {
+				t.Errorf("%s %s panicked without debug mode", name, test.name)
+			}
+		}
+	}
+
+	// A panic from the update itself, here from a short x after A[0][0] has
+	// been zeroed, is not replaced by a downdate panic.
+	msg := panicMessage(func() {
+		New(WithDebug(true)).DgerSym(2, -1, []float64{2}, 1, spd(), 2)
+	})
+	if !strings.Contains(msg, "index out of range") {
+		t.Errorf("DgerSym with short x: got panic %q, want index out of range", msg)
+	}
+}
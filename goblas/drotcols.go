@@ -0,0 +1,39 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+// DrotCols applies a plane rotation to columns j1 and j2 of the matrix A with
+// m rows and stride lda, replacing them in every row by
+//
+//	c*A[i][j1] + s*A[i][j2] and c*A[i][j2] - s*A[i][j1],
+//
+// exactly as Drot(m, a[j1:], lda, a[j2:], lda, c, s) would. The rows of A are
+// contiguous in memory, so the two columns are rotated one row at a time
+// rather than as two strided vectors. To rotate two rows, call Drot on the
+// rows directly.
+func (Blas) DrotCols(m int, a []float64, lda, j1, j2 int, c, s float64) {
+	if m < 0 {
+		panic(mLT0)
+	}
+	if lda < 1 {
+		panic(badLda)
+	}
+	if j1 < 0 || j1 >= lda || j2 < 0 || j2 >= lda {
+		panic(badColumn)
+	}
+	if j1 == j2 {
+		panic("goblas: j1 == j2")
+	}
+	if m == 0 {
+		return
+	}
+	if (m-1)*lda+max(j1, j2) >= len(a) {
+		panic("goblas: insufficient length of a")
+	}
+	for i := 0; i < m; i++ {
+		v1, v2 := a[i*lda+j1], a[i*lda+j2]
+		a[i*lda+j1], a[i*lda+j2] = c*v1+s*v2, c*v2-s*v1
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDrotCols(t *testing.T) {
+	for _, test := range []struct {
+		m, n, lda, j1, j2 int
+	}{
+		{0, 3, 3, 0, 1},
+		{1, 2, 2, 0, 1},
+		{4, 3, 3, 1, 2},
+		{5, 6, 8, 3, 4},
+		{5, 6, 8, 5, 0},
+		{2, 2, 3, 0, 1},
+	} {
+		c, s := math.Cos(0.7), math.Sin(0.7)
+		a := randmat(test.m, test.n, test.lda)
+		// The last row need not be padded to the full stride. The data is
+		// copied so that its capacity ends with the last element as well.
+		if test.m > 0 {
+			a.data = append(make([]float64, 0, (test.m-1)*test.lda+test.n), a.data[:(test.m-1)*test.lda+test.n]...)
+		}
+		want := a.clone()
+
+		// Extract the columns, rotate them with Drot and write them back.
+		x := make([]float64, test.m)
+		y := make([]float64, test.m)
+		for i := range x {
+			x[i], y[i] = want.at(i, test.j1), want.at(i, test.j2)
+		}
+		Blasser.Drot(test.m, x, 1, y, 1, c, s)
+		for i := range x {
+			want.data[i*want.stride+test.j1] = x[i]
+			want.data[i*want.stride+test.j2] = y[i]
+		}
+
+		Blasser.DrotCols(test.m, a.data, a.stride, test.j1, test.j2, c, s)
+		for i, v := range a.data {
+			if math.Abs(v-want.data[i]) > 1e-14 {
+				t.Errorf("%+v: a[%v] = %v, want %v", test, i, v, want.data[i])
+			}
+		}
+	}
+
+	for _, test := range []struct {
+		name string
+		f    func()
+	}{
+		{"m < 0", func() { Blasser.DrotCols(-1, nil, 1, 0, 0, 1, 0) }},
+		{"j2 >= lda", func() { Blasser.DrotCols(2, make([]float64, 6), 3, 0, 3, 1, 0) }},
+		{"j1 == j2", func() { Blasser.DrotCols(2, make([]float64, 6), 3, 1, 1, 1, 0) }},
+		{"short a", func() { Blasser.DrotCols(2, make([]float64, 4), 3, 0, 2, 1, 0) }},
+	} {
+		if !panics(test.f) {
+			t.Errorf("%s: no panic", test.name)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import "github.com/gonum/blas"
+
+const badColumn = "goblas: column index out of range"
+
+// DspScalCol scales the stored elements of column j of the n×n symmetric
+// matrix A held in packed form in ap by alpha. The packing is row-major, as
+// for Dtpsv. If ul == blas.Upper, the stored elements of column j are
+// A[i][j] for i <= j, at ap[i*n - i*(i-1)/2 + j-i]. If ul == blas.Lower, they
+// are A[i][j] for i >= j, at ap[i*(i+1)/2 + j]. The diagonal element is
+// scaled in both cases.
+//
+// In a column-oriented Cholesky factorization of a lower packed matrix,
+// DspScalCol(blas.Lower, n, j, 1/math.Sqrt(pivot), ap) scales column j by the
+// inverse square root of its pivot.
+func (Blas) DspScalCol(ul blas.Uplo, n, j int, alpha float64, ap []float64) {
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if j < 0 || j >= n {
+		panic(badColumn)
+	}
+	checkPacked(n, ap)
+	if ul == blas.Upper {
+		// Row i starts at column i, so the distance between A[i][j] and
+		// A[i+1][j] is the length n-i-1 of row i less one.
+		k := j
+		for i := 0; i <= j; i++ {
+			ap[k] *= alpha
+			k += n - i - 1
+		}
+		return
+	}
+	// Row i holds i+1 elements, so the distance between A[i][j] and
+	// A[i+1][j] is i+1.
+	k := j*(j+1)/2 + j
+	for i := j; i < n; i++ {
+		ap[k] *= alpha
+		k += i + 1
+	}
+}
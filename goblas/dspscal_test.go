@@ -0,0 +1,90 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestDspScalCol(t *testing.T) {
+	for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for n := 1; n <= 6; n++ {
+			for j := 0; j < n; j++ {
+				// Store the packed index of A[r][c] in the matrix itself,
+				// so that the expected result can be computed from the
+				// dense form.
+				ap := make([]float64, n*(n+1)/2)
+				idx := make([][]int, n)
+				var k int
+				for r := 0; r < n; r++ {
+					idx[r] = make([]int, n)
+					lo, hi := 0, r+1
+					if ul == blas.Upper {
+						lo, hi = r, n
+					}
+					for c := lo; c < hi; c++ {
+						idx[r][c] = k
+						ap[k] = float64(k + 1)
+						k++
+					}
+				}
+				want := make([]float64, len(ap))
+				copy(want, ap)
+				for r := 0; r < n; r++ {
+					if (ul == blas.Upper && r <= j) || (ul == blas.Lower && r >= j) {
+						want[idx[r][j]] *= -2
+					}
+				}
+				Blasser.DspScalCol(ul, n, j, -2, ap)
+				for i := range ap {
+					if ap[i] != want[i] {
+						t.Errorf("ul = %c, n = %d, j = %d: got %v, want %v", ul, n, j, ap, want)
+						break
+					}
+				}
+			}
+		}
+	}
+
+	for _, f := range []func(){
+		func() { Blasser.DspScalCol('x', 2, 0, 1, make([]float64, 3)) },
+		func() { Blasser.DspScalCol(blas.Upper, -1, 0, 1, nil) },
+		func() { Blasser.DspScalCol(blas.Upper, 2, 2, 1, make([]float64, 3)) },
+		func() { Blasser.DspScalCol(blas.Lower, 2, -1, 1, make([]float64, 3)) },
+		func() { Blasser.DspScalCol(blas.Lower, 3, 0, 1, make([]float64, 5)) },
+	} {
+		if !panics(f) {
+			t.Errorf("Expected panic")
+		}
+	}
+}
+
+func TestPackedOverflow(t *testing.T) {
+	// n*(n+1)/2 overflows int and must not wrap around to a length that ap
+	// appears to satisfy.
+	const n = maxInt / 2
+	ap := make([]float64, 3)
+	x := make([]float64, 2)
+	for _, test := range []struct {
+		name string
+		f    func()
+	}{
+		{"DspScalCol", func() { Blasser.DspScalCol(blas.Upper, n, 0, 1, ap) }},
+		{"Dtpmv", func() { Blasser.Dtpmv(blas.Upper, blas.NoTrans, blas.NonUnit, n, ap, x, 1) }},
+		{"Dtpsv", func() { Blasser.Dtpsv(blas.Lower, blas.Trans, blas.Unit, n, ap, x, 1) }},
+		{"Dspmv", func() { Blasser.Dspmv(blas.Upper, n, 1, ap, x, 1, 0, x, 1) }},
+	} {
+		var r interface{}
+		func() {
+			defer func() { r = recover() }()
+			test.f()
+		}()
+		if r != "goblas: n*(n+1)/2 overflows int" {
+			t.Errorf("%v: unexpected panic: %v", test.name, r)
+		}
+	}
+}
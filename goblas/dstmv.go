@@ -0,0 +1,59 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+// Dstmv computes y := T*x where T is the n×n symmetric tridiagonal matrix with
+// diagonal d and off-diagonal e, that is T[i][i] = d[i] and
+// T[i][i+1] = T[i+1][i] = e[i]. d must have at least n and e at least n-1
+// elements. x and y are vectors with increments incX and incY and must not
+// overlap. Large problems are computed concurrently by row ranges.
+//
+// Dstmv is not part of the BLAS standard. It stores T in O(n) memory where a
+// Symmetric or band matrix would need O(n^2) or 2n.
+func (bl Blas) Dstmv(n int, d []float64, e []float64, x []float64, incX int, y []float64, incY int) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if incX == 0 {
+		panic(zeroInc)
+	}
+	if incY == 0 {
+		panic(zeroInc)
+	}
+	if len(d) < n {
+		panic("goblas: insufficient length of d")
+	}
+	if n > 0 && len(e) < n-1 {
+		panic("goblas: insufficient length of e")
+	}
+	if n == 0 {
+		return
+	}
+
+	var kx, ky int
+	if incX < 0 {
+		kx = -(n - 1) * incX
+	}
+	if incY < 0 {
+		ky = -(n - 1) * incY
+	}
+
+	bl.parallelRows(n, 3, func(i, r int) {
+		ix := kx + i*incX
+		iy := ky + i*incY
+		for l := i; l < i+r; l++ {
+			v := d[l] * x[ix]
+			if l > 0 {
+				v += e[l-1] * x[ix-incX]
+			}
+			if l < n-1 {
+				v += e[l] * x[ix+incX]
+			}
+			y[iy] = v
+			ix += incX
+			iy += incY
+		}
+	})
+}
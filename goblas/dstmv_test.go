@@ -0,0 +1,89 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestDstmv(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 5, minParScale/3 + 7} {
+		d := randSlice(n)
+		e := randSlice(max(0, n-1))
+		for _, inc := range []struct{ x, y int }{{1, 1}, {2, 3}, {-1, 1}, {1, -2}, {-3, -1}} {
+			x := randSlice(max(0, (n-1)*abs(inc.x)+1))
+			y := make([]float64, max(0, (n-1)*abs(inc.y)+1))
+			for i := range y {
+				y[i] = math.NaN()
+			}
+			want := dstmvDense(n, d, e, x, inc.x)
+
+			Blasser.Dstmv(n, d, e, x, inc.x, y, inc.y)
+
+			iy := 0
+			if inc.y < 0 {
+				iy = -(n - 1) * inc.y
+			}
+			for i := 0; i < n; i++ {
+				if math.Abs(y[iy]-want[i]) > 1e-14 {
+					t.Errorf("n = %v, incX = %v, incY = %v: mismatch at %v. Want %v, got %v", n, inc.x, inc.y, i, want[i], y[iy])
+					break
+				}
+				iy += inc.y
+			}
+		}
+	}
+	for _, f := range []func(){
+		func() { Blasser.Dstmv(-1, nil, nil, nil, 1, nil, 1) },
+		func() {
+			Blasser.Dstmv(2, make([]float64, 2), make([]float64, 1), make([]float64, 2), 0, make([]float64, 2), 1)
+		},
+		func() {
+			Blasser.Dstmv(2, make([]float64, 2), make([]float64, 1), make([]float64, 2), 1, make([]float64, 2), 0)
+		},
+		func() {
+			Blasser.Dstmv(3, make([]float64, 2), make([]float64, 2), make([]float64, 3), 1, make([]float64, 3), 1)
+		},
+		func() {
+			Blasser.Dstmv(3, make([]float64, 3), make([]float64, 1), make([]float64, 3), 1, make([]float64, 3), 1)
+		},
+	} {
+		if !panics(f) {
+			t.Errorf("Expected panic")
+		}
+	}
+}
+
+// dstmvDense computes T*x using Dgemv on the dense form of T for small n. For
+// large n the dense form is too big, and the result of a serial Dstmv is used.
+func dstmvDense(n int, d, e, x []float64, incX int) []float64 {
+	y := make([]float64, n)
+	if n > 100 {
+		New(WithMaxWorkers(1)).Dstmv(n, d, e, x, incX, y, 1)
+		return y
+	}
+	a := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		a[i*n+i] = d[i]
+		if i < n-1 {
+			a[i*n+i+1] = e[i]
+			a[(i+1)*n+i] = e[i]
+		}
+	}
+	if n > 0 {
+		Blasser.Dgemv(blas.NoTrans, n, n, 1, a, max(1, n), x, incX, 0, y, 1)
+	}
+	return y
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
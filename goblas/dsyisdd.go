@@ -0,0 +1,59 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+)
+
+// DsyIsDiagonallyDominant reports whether the n×n symmetric matrix A, of
+// which only the triangle given by ul is referenced, is strictly diagonally
+// dominant with a positive diagonal, that is whether
+//
+//	A[i][i] > \sum_{j != i} |A[i][j]|
+//
+// for every row i. Such a matrix is positive definite, so this is a cheap
+// sufficient check before a Cholesky factorization; a matrix for which it
+// is false may still be positive definite. The sum for row i includes the
+// elements of column i in the referenced triangle, so the triangle is
+// traversed once with n elements of workspace. If any element is NaN, the
+// result is false. An empty matrix is reported as dominant.
+func (Blas) DsyIsDiagonallyDominant(ul blas.Uplo, n int, a []float64, lda int) bool {
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if lda < max(1, n) {
+		panic(badLda)
+	}
+	if n == 0 {
+		return true
+	}
+
+	// off[i] accumulates the absolute values of the off-diagonal elements
+	// of row i.
+	off := make([]float64, n)
+	for i := 0; i < n; i++ {
+		jl, ju := i+1, n
+		if ul == blas.Lower {
+			jl, ju = 0, i
+		}
+		for j, v := range a[i*lda+jl : i*lda+ju] {
+			v = math.Abs(v)
+			off[i] += v
+			off[jl+j] += v
+		}
+	}
+	for i, s := range off {
+		if !(a[i*lda+i] > s) {
+			return false
+		}
+	}
+	return true
+}
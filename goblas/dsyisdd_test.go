@@ -0,0 +1,73 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestDsyIsDiagonallyDominant(t *testing.T) {
+	nan := math.NaN()
+	for _, test := range []struct {
+		name string
+		n    int
+		a    []float64 // full symmetric matrix
+		want bool
+	}{
+		{"empty", 0, nil, true},
+		{"positive scalar", 1, []float64{2}, true},
+		{"zero scalar", 1, []float64{0}, false},
+		{"dominant", 3, []float64{
+			4, -1, 2,
+			-1, 3, 1,
+			2, 1, 5,
+		}, true},
+		// Row 1 is dominant over its stored part in either triangle alone,
+		// but not over the whole row.
+		{"column counts", 3, []float64{
+			4, -2, 1,
+			-2, 2.2, 0.5,
+			1, 0.5, 5,
+		}, false},
+		{"equal is not strict", 2, []float64{
+			1, 1,
+			1, 2,
+		}, false},
+		{"negative diagonal", 2, []float64{
+			-3, 1,
+			1, 3,
+		}, false},
+		{"NaN", 2, []float64{
+			3, nan,
+			nan, 3,
+		}, false},
+	} {
+		for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+			// Store the matrix with padding and poison the unreferenced
+			// triangle and the padding.
+			lda := test.n + 1
+			a := make([]float64, test.n*lda)
+			for i := 0; i < test.n; i++ {
+				for j := 0; j < lda; j++ {
+					switch {
+					case j >= test.n, ul == blas.Upper && j < i, ul == blas.Lower && j > i:
+						a[i*lda+j] = 1e10
+					default:
+						a[i*lda+j] = test.a[i*test.n+j]
+					}
+				}
+			}
+			if got := Blasser.DsyIsDiagonallyDominant(ul, test.n, a, max(1, lda)); got != test.want {
+				t.Errorf("%s, ul = %c: got %v, want %v", test.name, ul, got, test.want)
+			}
+		}
+	}
+	if !panics(func() { Blasser.DsyIsDiagonallyDominant(blas.Upper, 3, make([]float64, 9), 2) }) {
+		t.Errorf("no panic for lda < n")
+	}
+}
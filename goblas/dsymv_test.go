@@ -0,0 +1,96 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+// TestDsymv checks Dsymv against Dgemv on the full matrix for each stored
+// triangle, both for a matrix stored row-major and for one stored by a
+// column-major library, which is passed with ul swapped.
+func TestDsymv(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 5, 9} {
+		for _, lda := range []int{max(1, n), n + 3} {
+			for _, inc := range []struct{ x, y int }{{1, 1}, {2, -1}, {-3, 2}} {
+				for _, ab := range []struct{ alpha, beta float64 }{{1, 0}, {0.5, 2}, {0, 3}} {
+					// full is the symmetric matrix in dense form.
+					full := randmat(n, n, max(1, n))
+					for i := 0; i < n; i++ {
+						for j := 0; j < i; j++ {
+							full.data[i*full.stride+j] = full.at(j, i)
+						}
+					}
+					x := randSlice(max(0, (n-1)*abs(inc.x)+1))
+					y := randSlice(max(0, (n-1)*abs(inc.y)+1))
+					want := append([]float64(nil), y...)
+					Blasser.Dgemv(blas.NoTrans, n, n, ab.alpha, full.data, full.stride, x, inc.x, ab.beta, want, inc.y)
+
+					for _, storage := range []struct {
+						name     string
+						colMajor bool
+					}{{"row-major", false}, {"col-major", true}} {
+						for _, stored := range []blas.Uplo{blas.Upper, blas.Lower} {
+							// a holds only the stored triangle, in the storage
+							// order under test; the rest is NaN so that reading
+							// it is detected.
+							a := make([]float64, max(0, (n-1)*lda+n))
+							for i := range a {
+								a[i] = math.NaN()
+							}
+							for i := 0; i < n; i++ {
+								for j := 0; j < n; j++ {
+									if (stored == blas.Upper && j < i) || (stored == blas.Lower && j > i) {
+										continue
+									}
+									if storage.colMajor {
+										a[j*lda+i] = full.at(i, j)
+									} else {
+										a[i*lda+j] = full.at(i, j)
+									}
+								}
+							}
+							ul := stored
+							if storage.colMajor {
+								ul = blas.Upper
+								if stored == blas.Upper {
+									ul = blas.Lower
+								}
+							}
+
+							got := append([]float64(nil), y...)
+							Blasser.Dsymv(ul, n, ab.alpha, a, lda, x, inc.x, ab.beta, got, inc.y)
+							name := fmt.Sprintf("n = %v, lda = %v, inc = %v, %v %v", n, lda, inc, storage.name, stored)
+							for i := range got {
+								if math.Abs(got[i]-want[i]) > 1e-14 {
+									t.Errorf("%v: y[%v] = %v, want %v", name, i, got[i], want[i])
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for _, f := range []func(){
+		func() { Blasser.Dsymv(blas.All, 1, 1, []float64{1}, 1, []float64{1}, 1, 0, []float64{1}, 1) },
+		func() { Blasser.Dsymv(blas.Upper, -1, 1, nil, 1, nil, 1, 0, nil, 1) },
+		func() {
+			Blasser.Dsymv(blas.Upper, 2, 1, make([]float64, 4), 1, make([]float64, 2), 1, 0, make([]float64, 2), 1)
+		},
+		func() {
+			Blasser.Dsymv(blas.Upper, 2, 1, make([]float64, 4), 2, make([]float64, 2), 0, 0, make([]float64, 2), 1)
+		},
+	} {
+		if !panics(f) {
+			t.Errorf("expected panic")
+		}
+	}
+}
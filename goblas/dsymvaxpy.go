@@ -0,0 +1,119 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import "github.com/gonum/blas"
+
+// DsymvAxpy performs the fused operations
+//
+//	y := alpha*A*x + beta*y,
+//	r := gamma*y + r,
+//
+// where A is an n×n symmetric matrix of which only the triangle ul is
+// referenced, and x, y and r are vectors with increments incX, incY and incR.
+// The result is the same as Dsymv followed by Daxpy(n, gamma, y, incY, r,
+// incR), but y is traversed once: each element of r is updated as soon as the
+// matching element of y is final. In the conjugate gradient method, with
+// y = q, x = p and r the residual, this computes q := A*p and r := r - a_k*q
+// for gamma = -a_k. If beta is zero, y need not be set on input. The vectors
+// must not overlap.
+func (Blas) DsymvAxpy(ul blas.Uplo, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int, gamma float64, r []float64, incR int) {
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if lda < max(1, n) {
+		panic(badLda)
+	}
+	if incX == 0 || incY == 0 || incR == 0 {
+		panic(zeroInc)
+	}
+	if n == 0 {
+		return
+	}
+
+	var kx, ky, kr int
+	if incX < 0 {
+		kx = -(n - 1) * incX
+	}
+	if incY < 0 {
+		ky = -(n - 1) * incY
+	}
+	if incR < 0 {
+		kr = -(n - 1) * incR
+	}
+
+	// scaled returns beta*v, or zero if beta is zero so that a NaN in an
+	// unset y is not propagated. It is applied when the first row touches
+	// each element of y.
+	scaled := func(v float64) float64 {
+		if beta == 0 {
+			return 0
+		}
+		return beta * v
+	}
+
+	if ul == blas.Upper {
+		// Row i holds A[i][i:]. It completes y_i, which has already
+		// received the contributions of the rows above, and adds A[i][j]*x_i
+		// to y_j for j > i.
+		ix, iy, ir := kx, ky, kr
+		for i := 0; i < n; i++ {
+			row := a[i*lda+i : i*lda+n]
+			xi := alpha * x[ix]
+			if i == 0 {
+				y[iy] = scaled(y[iy])
+			}
+			sum := row[0] * x[ix]
+			jx, jy := ix, iy
+			for _, v := range row[1:] {
+				jx += incX
+				jy += incY
+				sum += v * x[jx]
+				if i == 0 {
+					y[jy] = scaled(y[jy])
+				}
+				y[jy] += xi * v
+			}
+			y[iy] += alpha * sum
+			r[ir] += gamma * y[iy]
+			ix += incX
+			iy += incY
+			ir += incR
+		}
+		return
+	}
+
+	// Row i holds A[i][:i+1]. Processing the rows from the bottom, y_i has
+	// received the contributions of the rows below when row i completes it.
+	ix := kx + (n-1)*incX
+	iy := ky + (n-1)*incY
+	ir := kr + (n-1)*incR
+	for i := n - 1; i >= 0; i-- {
+		row := a[i*lda : i*lda+i+1]
+		xi := alpha * x[ix]
+		if i == n-1 {
+			y[iy] = scaled(y[iy])
+		}
+		sum := row[i] * x[ix]
+		jx, jy := kx, ky
+		for _, v := range row[:i] {
+			sum += v * x[jx]
+			if i == n-1 {
+				y[jy] = scaled(y[jy])
+			}
+			y[jy] += xi * v
+			jx += incX
+			jy += incY
+		}
+		y[iy] += alpha * sum
+		r[ir] += gamma * y[iy]
+		ix -= incX
+		iy -= incY
+		ir -= incR
+	}
+}
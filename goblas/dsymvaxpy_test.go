@@ -0,0 +1,103 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestDsymvAxpy(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 5, 17} {
+		for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, inc := range [][3]int{{1, 1, 1}, {2, -1, 3}, {-2, 3, -1}} {
+				for _, beta := range []float64{0, 1, -0.5} {
+					incX, incY, incR := inc[0], inc[1], inc[2]
+					const alpha, gamma = 1.5, -0.75
+					lda := n + 2
+					a := randSlice(n * lda)
+					// Fill the unreferenced triangle with NaN.
+					for i := 0; i < n; i++ {
+						for j := 0; j < n; j++ {
+							if (ul == blas.Upper && j < i) || (ul == blas.Lower && j > i) {
+								a[i*lda+j] = math.NaN()
+							}
+						}
+					}
+					x := randSlice(max(0, (n-1)*abs(incX)+1))
+					y := randSlice(max(0, (n-1)*abs(incY)+1))
+					r := randSlice(max(0, (n-1)*abs(incR)+1))
+					if beta == 0 {
+						for i := range y {
+							y[i] = math.NaN()
+						}
+					}
+
+					// Reference: dense symmetric product, then axpy.
+					elem := func(v []float64, inc, i int) *float64 {
+						if inc < 0 {
+							return &v[(n-1-i)*-inc]
+						}
+						return &v[i*inc]
+					}
+					wantY := make([]float64, n)
+					wantR := make([]float64, n)
+					for i := 0; i < n; i++ {
+						var sum float64
+						for j := 0; j < n; j++ {
+							aij := a[i*lda+j]
+							if (ul == blas.Upper && j < i) || (ul == blas.Lower && j > i) {
+								aij = a[j*lda+i]
+							}
+							sum += aij * *elem(x, incX, j)
+						}
+						wantY[i] = alpha * sum
+						if beta != 0 {
+							wantY[i] += beta * *elem(y, incY, i)
+						}
+						wantR[i] = *elem(r, incR, i) + gamma*wantY[i]
+					}
+
+					Blasser.DsymvAxpy(ul, n, alpha, a, lda, x, incX, beta, y, incY, gamma, r, incR)
+					for i := 0; i < n; i++ {
+						if math.Abs(*elem(y, incY, i)-wantY[i]) > 1e-12 {
+							t.Errorf("n = %v, ul = %v, inc = %v, beta = %v: y mismatch at %v", n, ul, inc, beta, i)
+							break
+						}
+						if math.Abs(*elem(r, incR, i)-wantR[i]) > 1e-12 {
+							t.Errorf("n = %v, ul = %v, inc = %v, beta = %v: r mismatch at %v", n, ul, inc, beta, i)
+							break
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for _, f := range []func(){
+		func() {
+			Blasser.DsymvAxpy(blas.All, 2, 1, make([]float64, 4), 2, make([]float64, 2), 1, 0, make([]float64, 2), 1, 1, make([]float64, 2), 1)
+		},
+		func() { Blasser.DsymvAxpy(blas.Upper, -1, 1, nil, 1, nil, 1, 0, nil, 1, 1, nil, 1) },
+		func() {
+			Blasser.DsymvAxpy(blas.Upper, 2, 1, make([]float64, 4), 1, make([]float64, 2), 1, 0, make([]float64, 2), 1, 1, make([]float64, 2), 1)
+		},
+		func() {
+			Blasser.DsymvAxpy(blas.Upper, 2, 1, make([]float64, 4), 2, make([]float64, 2), 0, 0, make([]float64, 2), 1, 1, make([]float64, 2), 1)
+		},
+		func() {
+			Blasser.DsymvAxpy(blas.Upper, 2, 1, make([]float64, 4), 2, make([]float64, 2), 1, 0, make([]float64, 2), 0, 1, make([]float64, 2), 1)
+		},
+		func() {
+			Blasser.DsymvAxpy(blas.Upper, 2, 1, make([]float64, 4), 2, make([]float64, 2), 1, 0, make([]float64, 2), 1, 1, make([]float64, 2), 0)
+		},
+	} {
+		if !panics(f) {
+			t.Errorf("Expected panic")
+		}
+	}
+}
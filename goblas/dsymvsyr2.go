@@ -0,0 +1,95 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import "github.com/gonum/blas"
+
+// DsymvSyr2Step performs the fused operations
+//
+//	A := alpha*x*y^T + alpha*y*x^T + A,
+//	w := tau*A*v + beta*w,
+//
+// where A is an n×n symmetric matrix of which only the triangle ul is
+// referenced, and x, y, v and w are n element vectors. The product uses the
+// updated A. Each element of the triangle is updated and then immediately
+// used in the product, so the triangle is traversed once instead of once by
+// Dsyr2 and once by Dsymv.
+//
+// In the reduction of a symmetric matrix to tridiagonal form, as in LAPACK's
+// DSYTRD, step k forms w_k from A*v_k and then applies the rank-2 update
+// A := A - v_k*w_k^T - w_k*v_k^T, which needs all of w_k first. The two
+// cannot be fused within a step, but the update of step k can be fused with
+// the product of step k+1: calling DsymvSyr2Step with alpha = -1, x = v_k,
+// y = w_k, v = v_{k+1} and beta = 0 applies the pending update and forms
+// tau*A*v_{k+1} in one pass.
+//
+// If beta is zero, w need not be set on input. w must not overlap x, y or v.
+func (Blas) DsymvSyr2Step(ul blas.Uplo, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64, lda int, tau float64, v []float64, incV int, beta float64, w []float64, incW int) {
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if lda < max(1, n) {
+		panic(badLda)
+	}
+	if incX == 0 || incY == 0 || incV == 0 || incW == 0 {
+		panic(zeroInc)
+	}
+	if n == 0 {
+		return
+	}
+
+	// start returns the index of element 0 of a vector with increment inc.
+	start := func(inc int) int {
+		if inc < 0 {
+			return -(n - 1) * inc
+		}
+		return 0
+	}
+	kx, ky, kv, kw := start(incX), start(incY), start(incV), start(incW)
+
+	iw := kw
+	for i := 0; i < n; i++ {
+		if beta == 0 {
+			w[iw] = 0
+		} else {
+			w[iw] *= beta
+		}
+		iw += incW
+	}
+
+	ix, iy, iv, iw := kx, ky, kv, kw
+	for i := 0; i < n; i++ {
+		xi := alpha * x[ix]
+		yi := alpha * y[iy]
+		vi := tau * v[iv]
+		jl, ju := i, n
+		if ul == blas.Lower {
+			jl, ju = 0, i+1
+		}
+		row := a[i*lda+jl : i*lda+ju]
+		jx, jy, jv, jw := kx+jl*incX, ky+jl*incY, kv+jl*incV, kw+jl*incW
+		var sum float64
+		for j := range row {
+			aij := row[j] + xi*y[jy] + yi*x[jx]
+			row[j] = aij
+			sum += aij * v[jv]
+			if jl+j != i {
+				w[jw] += aij * vi
+			}
+			jx += incX
+			jy += incY
+			jv += incV
+			jw += incW
+		}
+		w[iw] += tau * sum
+		ix += incX
+		iy += incY
+		iv += incV
+		iw += incW
+	}
+}
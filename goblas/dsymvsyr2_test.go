@@ -0,0 +1,106 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestDsymvSyr2Step(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 7} {
+		for _, lda := range []int{max(1, n), n + 2} {
+			for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+				for _, inc := range [][4]int{{1, 1, 1, 1}, {2, -1, 3, -2}, {-1, 2, -3, 1}} {
+					for _, s := range []struct{ alpha, tau, beta float64 }{{-1, 0.5, 0}, {2, 1, 1}, {0, 1.5, -0.5}} {
+						name := fmt.Sprintf("n = %v, lda = %v, ul = %v, inc = %v, scalars = %v", n, lda, ul, inc, s)
+						vec := func(inc int) []float64 {
+							return randSlice(max(0, (n-1)*abs(inc)+1))
+						}
+						// elem returns the index of element i of a vector.
+						elem := func(i, inc int) int {
+							if inc < 0 {
+								return (n - 1 - i) * -inc
+							}
+							return i * inc
+						}
+						x, y, v, w := vec(inc[0]), vec(inc[1]), vec(inc[2]), vec(inc[3])
+						if s.beta == 0 {
+							for i := range w {
+								w[i] = math.NaN()
+							}
+						}
+						a := randSlice(max(0, (n-1)*lda+n))
+
+						// Compute the expected result on the full matrix.
+						full := make([]float64, n*n)
+						for i := 0; i < n; i++ {
+							for j := 0; j < n; j++ {
+								r, c := i, j
+								if (ul == blas.Upper) != (r <= c) {
+									r, c = c, r
+								}
+								full[i*n+j] = a[r*lda+c] + s.alpha*(x[elem(i, inc[0])]*y[elem(j, inc[1])]+y[elem(i, inc[1])]*x[elem(j, inc[0])])
+							}
+						}
+						wantW := append([]float64(nil), w...)
+						for i := 0; i < n; i++ {
+							var sum float64
+							for j := 0; j < n; j++ {
+								sum += full[i*n+j] * v[elem(j, inc[2])]
+							}
+							iw := elem(i, inc[3])
+							if s.beta == 0 {
+								wantW[iw] = s.tau * sum
+							} else {
+								wantW[iw] = s.tau*sum + s.beta*w[iw]
+							}
+						}
+						wantA := append([]float64(nil), a...)
+						for i := 0; i < n; i++ {
+							for j := 0; j < n; j++ {
+								if (ul == blas.Upper) == (j >= i) || i == j {
+									wantA[i*lda+j] = full[i*n+j]
+								}
+							}
+						}
+
+						Blasser.DsymvSyr2Step(ul, n, s.alpha, x, inc[0], y, inc[1], a, lda, s.tau, v, inc[2], s.beta, w, inc[3])
+						for i := range a {
+							if math.Abs(a[i]-wantA[i]) > 1e-14 {
+								t.Errorf("%v: a[%v] = %v, want %v", name, i, a[i], wantA[i])
+							}
+						}
+						for i := range w {
+							if math.Abs(w[i]-wantW[i]) > 1e-13 || math.IsNaN(w[i]) != math.IsNaN(wantW[i]) {
+								t.Errorf("%v: w[%v] = %v, want %v", name, i, w[i], wantW[i])
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for _, f := range []func(){
+		func() {
+			Blasser.DsymvSyr2Step(blas.All, 1, 1, []float64{1}, 1, []float64{1}, 1, []float64{1}, 1, 1, []float64{1}, 1, 0, []float64{1}, 1)
+		},
+		func() { Blasser.DsymvSyr2Step(blas.Upper, -1, 1, nil, 1, nil, 1, nil, 1, 1, nil, 1, 0, nil, 1) },
+		func() {
+			Blasser.DsymvSyr2Step(blas.Upper, 2, 1, make([]float64, 2), 1, make([]float64, 2), 1, make([]float64, 4), 1, 1, make([]float64, 2), 1, 0, make([]float64, 2), 1)
+		},
+		func() {
+			Blasser.DsymvSyr2Step(blas.Lower, 2, 1, make([]float64, 2), 1, make([]float64, 2), 1, make([]float64, 4), 2, 1, make([]float64, 2), 0, 0, make([]float64, 2), 1)
+		},
+	} {
+		if !panics(f) {
+			t.Errorf("expected panic")
+		}
+	}
+}
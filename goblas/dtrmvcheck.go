@@ -0,0 +1,92 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+)
+
+// DtrmvCheck computes x := A*x or x := A^T*x as Dtrmv does and returns the
+// largest absolute value of the elements of the result, as a quick gauge of
+// overflow. If an element of the result is NaN, xmax is NaN. If n is zero,
+// xmax is zero.
+//
+// With A not transposed, each element of x is final as soon as its row of A
+// has been traversed, so the maximum is found in the same pass. With A
+// transposed, the elements are only final at the end and the maximum takes
+// one more pass over x, which is cheap compared to the pass over A.
+func (bl Blas) DtrmvCheck(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float64, lda int, x []float64, incX int) (xmax float64) {
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if d != blas.NonUnit && d != blas.Unit {
+		panic(badDiag)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if lda < max(1, n) {
+		panic(badLda)
+	}
+	if incX == 0 {
+		panic(zeroInc)
+	}
+	if n == 0 {
+		return 0
+	}
+	var kx int
+	if incX < 0 {
+		kx = -(n - 1) * incX
+	}
+	// gauge records the absolute value of a final element of x.
+	gauge := func(v float64) {
+		v = math.Abs(v)
+		if v > xmax || math.IsNaN(v) {
+			xmax = v
+		}
+	}
+
+	if tA != blas.NoTrans {
+		bl.Dtrmv(ul, tA, d, n, a, lda, x, incX)
+		ix := kx
+		for i := 0; i < n; i++ {
+			gauge(x[ix])
+			ix += incX
+		}
+		return xmax
+	}
+
+	// x_i depends on x_j for j >= i if A is upper triangular and j <= i if
+	// it is lower triangular, so the rows are processed in the order that
+	// reads each x_j before it is overwritten.
+	i, step := 0, 1
+	if ul == blas.Lower {
+		i, step = n-1, -1
+	}
+	for ; 0 <= i && i < n; i += step {
+		jl, ju := i+1, n
+		if ul == blas.Lower {
+			jl, ju = 0, i
+		}
+		ix := kx + i*incX
+		tmp := x[ix]
+		if d == blas.NonUnit {
+			tmp *= a[i*lda+i]
+		}
+		jx := kx + jl*incX
+		for _, v := range a[i*lda+jl : i*lda+ju] {
+			tmp += v * x[jx]
+			jx += incX
+		}
+		x[ix] = tmp
+		gauge(tmp)
+	}
+	return xmax
+}
@@ -0,0 +1,90 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestDtrmvCheck(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 5, 9} {
+		for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				for _, d := range []blas.Diag{blas.NonUnit, blas.Unit} {
+					for _, inc := range []int{1, 2, -3} {
+						// lda > n checks that the stride is honoured.
+						a := randmat(n, n, n+2)
+						x := randSlice(max(0, (n-1)*abs(inc)+1))
+
+						// Compute the reference from the dense triangle.
+						want := append([]float64(nil), x...)
+						var wantMax float64
+						for i := 0; i < n; i++ {
+							var v float64
+							for j := 0; j < n; j++ {
+								r, c := i, j
+								if tA != blas.NoTrans {
+									r, c = j, i
+								}
+								if (ul == blas.Upper && c < r) || (ul == blas.Lower && c > r) {
+									continue
+								}
+								aij := a.at(r, c)
+								if r == c && d == blas.Unit {
+									aij = 1
+								}
+								v += aij * xAt(x, n, inc, j)
+							}
+							want[xIdx(n, inc, i)] = v
+							wantMax = math.Max(wantMax, math.Abs(v))
+						}
+
+						got := Blasser.DtrmvCheck(ul, tA, d, n, a.data, a.stride, x, inc)
+						for i := range x {
+							if math.Abs(x[i]-want[i]) > 1e-14 {
+								t.Errorf("n = %v, ul = %c, tA = %c, d = %c, inc = %v: x[%v] = %v, want %v", n, ul, tA, d, inc, i, x[i], want[i])
+							}
+						}
+						if math.Abs(got-wantMax) > 1e-14 {
+							t.Errorf("n = %v, ul = %c, tA = %c, d = %c, inc = %v: xmax = %v, want %v", n, ul, tA, d, inc, got, wantMax)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// A NaN in the result is reported even if it is not the last element.
+	a := []float64{
+		1, 2,
+		0, 3,
+	}
+	x := []float64{math.NaN(), 1}
+	if got := Blasser.DtrmvCheck(blas.Upper, blas.NoTrans, blas.NonUnit, 2, a, 2, x, 1); !math.IsNaN(got) {
+		t.Errorf("xmax = %v, want NaN", got)
+	}
+
+	if !panics(func() {
+		Blasser.DtrmvCheck(blas.Upper, blas.NoTrans, blas.NonUnit, 3, make([]float64, 9), 2, make([]float64, 3), 1)
+	}) {
+		t.Errorf("no panic for lda < n")
+	}
+}
+
+// xIdx returns the position of the ith element of a vector of length n with
+// increment inc.
+func xIdx(n, inc, i int) int {
+	if inc < 0 {
+		return (n - 1 - i) * -inc
+	}
+	return i * inc
+}
+
+func xAt(x []float64, n, inc, i int) float64 {
+	return x[xIdx(n, inc, i)]
+}
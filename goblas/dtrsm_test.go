@@ -0,0 +1,93 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestDtrsmSolve(t *testing.T) {
+	for _, dims := range []struct{ m, n int }{
+		{1, 1}, {3, 1}, {1, 4}, {5, 7}, {20, 3}, {64, 300}, {300, 64},
+	} {
+		m, n := dims.m, dims.n
+		for _, s := range []blas.Side{blas.Left, blas.Right} {
+			for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+				for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+					for _, d := range []blas.Diag{blas.NonUnit, blas.Unit} {
+						for _, alpha := range []float64{1, -0.5} {
+							na := m
+							if s == blas.Right {
+								na = n
+							}
+							lda := na + 3
+							ldb := n + 2
+							// Keep the off-diagonal elements small so that
+							// unit triangular matrices are well conditioned.
+							a := randSlice(na * lda)
+							for i := range a {
+								a[i] /= float64(na)
+							}
+							for i := 0; i < na; i++ {
+								a[i*lda+i] += 1
+							}
+							b := randSlice(m * ldb)
+							x := make([]float64, len(b))
+							copy(x, b)
+							Blasser.Dtrsm(s, ul, tA, d, m, n, alpha, a, lda, x, ldb)
+
+							// Form op(A) densely and check that op(A)*X or
+							// X*op(A) reproduces alpha*B.
+							opA := make([]float64, na*na)
+							for i := 0; i < na; i++ {
+								for j := 0; j < na; j++ {
+									var v float64
+									switch {
+									case i == j && d == blas.Unit:
+										v = 1
+									case ul == blas.Upper && j >= i, ul == blas.Lower && j <= i:
+										v = a[i*lda+j]
+									}
+									if tA == blas.NoTrans {
+										opA[i*na+j] = v
+									} else {
+										opA[j*na+i] = v
+									}
+								}
+							}
+							var maxErr float64
+							for i := 0; i < m; i++ {
+								for j := 0; j < n; j++ {
+									var v float64
+									if s == blas.Left {
+										for k := 0; k < m; k++ {
+											v += opA[i*na+k] * x[k*ldb+j]
+										}
+									} else {
+										for k := 0; k < n; k++ {
+											v += x[i*ldb+k] * opA[k*na+j]
+										}
+									}
+									maxErr = math.Max(maxErr, math.Abs(v-alpha*b[i*ldb+j]))
+								}
+								for j := n; j < ldb; j++ {
+									if x[i*ldb+j] != b[i*ldb+j] {
+										t.Fatalf("m = %v, n = %v, s = %v, ul = %v, tA = %v, d = %v: padding modified", m, n, s, ul, tA, d)
+									}
+								}
+							}
+							if maxErr > 1e-10 {
+								t.Errorf("m = %v, n = %v, s = %v, ul = %v, tA = %v, d = %v, alpha = %v: residual too large: %v", m, n, s, ul, tA, d, alpha, maxErr)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
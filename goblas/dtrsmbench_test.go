@@ -0,0 +1,38 @@
+package goblas
+
+import (
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+// The following benchmarks solve a 2048×2048 lower triangular system with
+// 512 right-hand sides, with the columns of B partitioned among the default
+// number of workers and with a single worker.
+
+func BenchmarkDtrsmLeft2048x512(b *testing.B) {
+	benchmarkDtrsm(b, Blas{}, 2048, 512)
+}
+
+func BenchmarkDtrsmLeft2048x512Serial(b *testing.B) {
+	benchmarkDtrsm(b, New(WithMaxWorkers(1)), 2048, 512)
+}
+
+func benchmarkDtrsm(b *testing.B, impl Blas, m, n int) {
+	a := randSlice(m * m)
+	for i := range a {
+		a[i] /= float64(m)
+	}
+	for i := 0; i < m; i++ {
+		a[i*m+i] += 1
+	}
+	bm := randSlice(m * n)
+	x := make([]float64, len(bm))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		copy(x, bm)
+		b.StartTimer()
+		impl.Dtrsm(blas.Left, blas.Lower, blas.NoTrans, blas.NonUnit, m, n, 1, a, m, x, n)
+	}
+}
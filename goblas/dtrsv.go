@@ -0,0 +1,75 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+)
+
+// DtrsvResidual solves the same system as Dtrsv, overwriting x with the
+// solution, and returns the Euclidean norm of the residual A*x - b, or
+// A^T*x - b, where b is x on entry.
+//
+// Each x_i is computed from b_i and the dot product s_i of row i of op(A) with
+// the elements of x that are already solved. The residual of row i is then
+// s_i + A_ii*x_i - b_i, so the norm costs O(n) extra work instead of a second
+// matrix-vector multiplication.
+func (Blas) DtrsvResidual(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float64, lda int, x []float64, incX int) float64 {
+	dtrsvCheck(ul, tA, d, n, lda, incX)
+	if n == 0 {
+		return 0
+	}
+
+	var kx int
+	if incX < 0 {
+		kx = -(n - 1) * incX
+	}
+	// Element (i, j) of op(A) is a[i*rs+j*cs].
+	rs, cs := lda, 1
+	if tA != blas.NoTrans {
+		rs, cs = 1, lda
+	}
+	// A lower triangular op(A) is solved forwards, an upper one backwards.
+	lower := (ul == blas.Lower) == (tA == blas.NoTrans)
+
+	scale := 0.0
+	sumSquares := 1.0
+	for step := 0; step < n; step++ {
+		i := step
+		j0, j1 := 0, i
+		if !lower {
+			i = n - 1 - step
+			j0, j1 = i+1, n
+		}
+		var s float64
+		for j := j0; j < j1; j++ {
+			s += a[i*rs+j*cs] * x[kx+j*incX]
+		}
+		ix := kx + i*incX
+		b := x[ix]
+		xi := b - s
+		diag := 1.0
+		if d == blas.NonUnit {
+			diag = a[i*rs+i*cs]
+			xi /= diag
+		}
+		x[ix] = xi
+
+		r := s + diag*xi - b
+		if r == 0 {
+			continue
+		}
+		absr := math.Abs(r)
+		if scale < absr {
+			sumSquares = 1 + sumSquares*(scale/absr)*(scale/absr)
+			scale = absr
+		} else {
+			sumSquares = sumSquares + (absr/scale)*(absr/scale)
+		}
+	}
+	return scale * math.Sqrt(sumSquares)
+}
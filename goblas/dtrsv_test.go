@@ -0,0 +1,56 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestDtrsvResidual(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 5, 20} {
+		for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				for _, d := range []blas.Diag{blas.NonUnit, blas.Unit} {
+					for _, incX := range []int{1, 2, -3} {
+						lda := max(1, n)
+						a := randSlice(n * lda)
+						for i := 0; i < n; i++ {
+							a[i*lda+i] += float64(n)
+						}
+						b := randSlice(max(0, (n-1)*abs(incX)+1))
+						x := make([]float64, len(b))
+						copy(x, b)
+						want := make([]float64, len(b))
+						copy(want, b)
+
+						res := Blasser.DtrsvResidual(ul, tA, d, n, a, lda, x, incX)
+						Blasser.Dtrsv(ul, tA, d, n, a, lda, want, incX)
+
+						for i := range x {
+							if math.Abs(x[i]-want[i]) > 1e-12 {
+								t.Errorf("n = %v, ul = %v, tA = %v, d = %v, incX = %v: solution differs from Dtrsv", n, ul, tA, d, incX)
+								break
+							}
+						}
+						// Compute the residual independently with Dtrmv.
+						r := make([]float64, len(x))
+						copy(r, x)
+						if n > 0 {
+							Blasser.Dtrmv(ul, tA, d, n, a, lda, r, incX)
+							Blasser.Daxpy(n, -1, b, incX, r, incX)
+						}
+						wantRes := Blasser.Dnrm2(n, r, abs(incX))
+						if math.Abs(res-wantRes) > 1e-13 {
+							t.Errorf("n = %v, ul = %v, tA = %v, d = %v, incX = %v: residual mismatch. Want %v, got %v", n, ul, tA, d, incX, wantRes, res)
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import "github.com/gonum/blas"
+
+// Dtrtri computes the inverse of the n×n triangular matrix A in place. Only
+// the ul triangle of A is referenced and overwritten with the corresponding
+// triangle of the inverse. If d is blas.Unit, the diagonal of A is assumed to
+// be one and is not referenced; the inverse is then also unit triangular.
+//
+// The inverse is built a row at a time. For an upper triangular A, row i of
+// X = inv(A) is
+//
+//	X[i][i+1:] = -X[i][i] * A[i][i+1:] * X[i+1:][i+1:],
+//
+// so the rows are computed from the last to the first, each as a sum of
+// multiples of the rows below it; a lower triangular A is handled in the
+// opposite order. Each update is to a contiguous row, as in Dtrsm.
+//
+// No test for singularity or near-singularity is included in this routine.
+// Such tests must be performed before calling this routine.
+func (bl Blas) Dtrtri(ul blas.Uplo, d blas.Diag, n int, a []float64, lda int) {
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if d != blas.NonUnit && d != blas.Unit {
+		panic(badDiag)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if lda < max(1, n) {
+		panic(badLda)
+	}
+	if n == 0 {
+		return
+	}
+	if len(a) < (n-1)*lda+n {
+		panic(shortA)
+	}
+
+	nonUnit := d == blas.NonUnit
+	if ul == blas.Upper {
+		for i := n - 1; i >= 0; i-- {
+			xii := 1.0
+			if nonUnit {
+				xii = 1 / a[i*lda+i]
+				a[i*lda+i] = xii
+			}
+			row := a[i*lda : i*lda+n]
+			// Replace A[i][p] by the sum over q <= p of A[i][q]*X[q][p].
+			// Going down from the last column, A[i][p] is read by the
+			// step for p before it is first written.
+			for p := n - 1; p > i; p-- {
+				c := row[p]
+				xp := a[p*lda : p*lda+n]
+				if nonUnit {
+					row[p] = c * xp[p]
+				}
+				if c != 0 || bl.strict {
+					for l := p + 1; l < n; l++ {
+						row[l] += c * xp[l]
+					}
+				}
+			}
+			for l := i + 1; l < n; l++ {
+				row[l] *= -xii
+			}
+		}
+		return
+	}
+	for i := 0; i < n; i++ {
+		xii := 1.0
+		if nonUnit {
+			xii = 1 / a[i*lda+i]
+			a[i*lda+i] = xii
+		}
+		row := a[i*lda : i*lda+i]
+		for p := 0; p < i; p++ {
+			c := row[p]
+			xp := a[p*lda : p*lda+p+1]
+			if nonUnit {
+				row[p] = c * xp[p]
+			}
+			if c != 0 || bl.strict {
+				for l := 0; l < p; l++ {
+					row[l] += c * xp[l]
+				}
+			}
+		}
+		for l := range row {
+			row[l] *= -xii
+		}
+	}
+}
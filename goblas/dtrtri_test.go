@@ -0,0 +1,90 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestDtrtri(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 7, 40} {
+		for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, d := range []blas.Diag{blas.NonUnit, blas.Unit} {
+				lda := n + 2
+				// Keep the off-diagonal elements small so that unit
+				// triangular matrices are well conditioned.
+				a := randSlice(max(0, (n-1)*lda+n))
+				for i := range a {
+					a[i] /= float64(n)
+				}
+				for i := 0; i < n; i++ {
+					a[i*lda+i] += 1
+				}
+				x := make([]float64, len(a))
+				copy(x, a)
+				Blasser.Dtrtri(ul, d, n, x, lda)
+
+				inTri := func(i, j int) bool {
+					return (ul == blas.Upper && j >= i) || (ul == blas.Lower && j <= i)
+				}
+				// dense returns the triangular matrix stored in s.
+				dense := func(s []float64) []float64 {
+					m := make([]float64, n*n)
+					for i := 0; i < n; i++ {
+						for j := 0; j < n; j++ {
+							switch {
+							case i == j && d == blas.Unit:
+								m[i*n+j] = 1
+							case inTri(i, j):
+								m[i*n+j] = s[i*lda+j]
+							}
+						}
+					}
+					return m
+				}
+				ad, xd := dense(a), dense(x)
+				for i := 0; i < n; i++ {
+					for j := 0; j < n; j++ {
+						var v float64
+						for l := 0; l < n; l++ {
+							v += ad[i*n+l] * xd[l*n+j]
+						}
+						want := 0.0
+						if i == j {
+							want = 1
+						}
+						if math.Abs(v-want) > 1e-12 {
+							t.Errorf("n = %d, ul = %c, d = %c: A*inv(A) is %v at (%d, %d)", n, ul, d, v, i, j)
+						}
+					}
+				}
+				// Elements outside the triangle, the padding and a unit
+				// diagonal are not modified.
+				for i := 0; i < n; i++ {
+					for j := 0; j < lda && i*lda+j < len(a); j++ {
+						if (j >= n || !inTri(i, j) || (i == j && d == blas.Unit)) && x[i*lda+j] != a[i*lda+j] {
+							t.Errorf("n = %d, ul = %c, d = %c: element (%d, %d) outside the triangle modified", n, ul, d, i, j)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for _, f := range []func(){
+		func() { Blasser.Dtrtri('x', blas.NonUnit, 2, make([]float64, 4), 2) },
+		func() { Blasser.Dtrtri(blas.Upper, 'x', 2, make([]float64, 4), 2) },
+		func() { Blasser.Dtrtri(blas.Upper, blas.NonUnit, -1, nil, 1) },
+		func() { Blasser.Dtrtri(blas.Upper, blas.NonUnit, 3, make([]float64, 9), 2) },
+		func() { Blasser.Dtrtri(blas.Lower, blas.NonUnit, 3, make([]float64, 8), 3) },
+	} {
+		if !panics(f) {
+			t.Errorf("Expected panic")
+		}
+	}
+}
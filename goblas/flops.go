@@ -0,0 +1,109 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import "fmt"
+
+// flopCount is the number of dimensions taken by an operation and the
+// function counting its flops.
+type flopCount struct {
+	nDims int
+	names string // names of the dimensions, for error messages
+	count func(d []int64) int64
+}
+
+// flopCounts holds the flop count of each operation known to Flops.
+var flopCounts = map[string]flopCount{
+	// Level 1
+	"Dasum": {1, "n", func(d []int64) int64 { return d[0] }},
+	"Daxpy": {1, "n", func(d []int64) int64 { return 2 * d[0] }},
+	"Ddot":  {1, "n", func(d []int64) int64 { return 2 * d[0] }},
+	"Dnrm2": {1, "n", func(d []int64) int64 { return 2 * d[0] }},
+	"Drot":  {1, "n", func(d []int64) int64 { return 6 * d[0] }},
+	"Drotm": {1, "n", func(d []int64) int64 { return 6 * d[0] }},
+	"Dscal": {1, "n", func(d []int64) int64 { return d[0] }},
+
+	// Level 2
+	"Dgemv": {2, "m, n", func(d []int64) int64 { return 2 * d[0] * d[1] }},
+	"Dger":  {2, "m, n", func(d []int64) int64 { return 2 * d[0] * d[1] }},
+	"Dgbmv": {4, "m, n, kl, ku", func(d []int64) int64 { return 2 * bandElems(d[0], d[1], d[2], d[3]) }},
+	"Dsymv": {1, "n", func(d []int64) int64 { return 2 * d[0] * d[0] }},
+	"Dspmv": {1, "n", func(d []int64) int64 { return 2 * d[0] * d[0] }},
+	"Dsbmv": {2, "n, k", func(d []int64) int64 { return 2 * bandElems(d[0], d[0], d[1], d[1]) }},
+	"Dsyr":  {1, "n", func(d []int64) int64 { return d[0] * (d[0] + 1) }},
+	"Dspr":  {1, "n", func(d []int64) int64 { return d[0] * (d[0] + 1) }},
+	"Dsyr2": {1, "n", func(d []int64) int64 { return 2 * d[0] * (d[0] + 1) }},
+	"Dspr2": {1, "n", func(d []int64) int64 { return 2 * d[0] * (d[0] + 1) }},
+	"Dtrmv": {1, "n", func(d []int64) int64 { return d[0] * d[0] }},
+	"Dtrsv": {1, "n", func(d []int64) int64 { return d[0] * d[0] }},
+	"Dtpmv": {1, "n", func(d []int64) int64 { return d[0] * d[0] }},
+	"Dtpsv": {1, "n", func(d []int64) int64 { return d[0] * d[0] }},
+	"Dtbmv": {2, "n, k", func(d []int64) int64 { return 2*bandElems(d[0], d[0], 0, d[1]) - d[0] }},
+	"Dtbsv": {2, "n, k", func(d []int64) int64 { return 2*bandElems(d[0], d[0], 0, d[1]) - d[0] }},
+
+	// Level 3
+	"Dgemm":  {3, "m, n, k", func(d []int64) int64 { return 2 * d[0] * d[1] * d[2] }},
+	"Dsymm":  {3, "m, n, k", func(d []int64) int64 { return 2 * d[0] * d[1] * d[2] }},
+	"Dtrmm":  {3, "m, n, k", func(d []int64) int64 { return d[0] * d[1] * d[2] }},
+	"Dtrsm":  {3, "m, n, k", func(d []int64) int64 { return d[0] * d[1] * d[2] }},
+	"Dsyrk":  {2, "n, k", func(d []int64) int64 { return d[0] * (d[0] + 1) * d[1] }},
+	"Dsyr2k": {2, "n, k", func(d []int64) int64 { return 2 * d[0] * (d[0] + 1) * d[1] }},
+}
+
+// Flops returns the number of floating point operations performed by the
+// BLAS routine op, such as "Dgemm", for the dimensions dims. A multiplication
+// and an addition count as two flops, and the scaling by alpha and beta is
+// not counted, so that Flops("Dgemm", m, n, k) is 2*m*n*k as reported by
+// DgemmWork. The dimensions, in order, are
+//
+//	Dasum, Daxpy, Ddot, Dnrm2, Drot, Drotm, Dscal: n
+//	Dgemv, Dger:                                    m, n
+//	Dgbmv:                                          m, n, kl, ku
+//	Dsymv, Dspmv, Dsyr, Dspr, Dsyr2, Dspr2:         n
+//	Dtrmv, Dtrsv, Dtpmv, Dtpsv:                     n
+//	Dsbmv, Dtbmv, Dtbsv:                            n, k
+//	Dgemm:                                          m, n, k
+//	Dsymm, Dtrmm, Dtrsm:                            m, n, k
+//	Dsyrk, Dsyr2k:                                  n, k
+//
+// where for Dsymm, Dtrmm and Dtrsm k is the order of A, m if A is on the left
+// and n if it is on the right. The band routines count only the elements in
+// the band. Flops panics if op is not one of these routines, if the number of
+// dimensions does not match, or if a dimension is negative. The count
+// describes the operation, not whether Blas implements it; see Implemented.
+func Flops(op string, dims ...int) int64 {
+	f, ok := flopCounts[op]
+	if !ok {
+		panic(fmt.Sprintf("goblas: Flops: unknown operation %q", op))
+	}
+	d := make([]int64, len(dims))
+	for i, v := range dims {
+		if v < 0 {
+			panic(fmt.Sprintf("goblas: Flops: negative dimension %d for %s", v, op))
+		}
+		d[i] = int64(v)
+	}
+	if len(d) != f.nDims {
+		panic(fmt.Sprintf("goblas: Flops: %s takes %d dimensions (%s), got %d", op, f.nDims, f.names, len(d)))
+	}
+	return f.count(d)
+}
+
+// bandElems returns the number of elements in the band of an m×n matrix with
+// kl sub-diagonals and ku super-diagonals.
+func bandElems(m, n, kl, ku int64) int64 {
+	var elems int64
+	for i := int64(0); i < m && i < n+kl; i++ {
+		jl, ju := i-kl, i+ku+1
+		if jl < 0 {
+			jl = 0
+		}
+		if ju > n {
+			ju = n
+		}
+		elems += ju - jl
+	}
+	return elems
+}
@@ -0,0 +1,67 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestFlops(t *testing.T) {
+	for _, test := range []struct {
+		op   string
+		dims []int
+		want int64
+	}{
+		{"Ddot", []int{10}, 20},
+		{"Dscal", []int{10}, 10},
+		{"Dgemv", []int{3, 4}, 24},
+		{"Dsymv", []int{5}, 50},
+		{"Dsyr2", []int{4}, 40},
+		{"Dtrsv", []int{6}, 36},
+		// A 4×4 tridiagonal matrix has 10 elements in its band.
+		{"Dgbmv", []int{4, 4, 1, 1}, 20},
+		// Rows 3 to 5 of a 6×2 matrix with one sub-diagonal are empty.
+		{"Dgbmv", []int{6, 2, 1, 0}, 8},
+		{"Dsbmv", []int{4, 1}, 20},
+		// An upper bidiagonal 4×4 matrix has 7 elements.
+		{"Dtbmv", []int{4, 1}, 10},
+		{"Dtbsv", []int{4, 3}, 16},
+		{"Dgemm", []int{2, 3, 4}, 48},
+		{"Dgemm", []int{0, 3, 4}, 0},
+		{"Dtrsm", []int{2, 3, 2}, 12},
+		{"Dsyrk", []int{3, 2}, 24},
+		{"Dsyr2k", []int{3, 2}, 48},
+	} {
+		if got := Flops(test.op, test.dims...); got != test.want {
+			t.Errorf("Flops(%q, %v) = %v, want %v", test.op, test.dims, got, test.want)
+		}
+	}
+
+	// Flops agrees with DgemmWork.
+	flops, _ := Blasser.DgemmWork(blas.Trans, blas.NoTrans, 70, 90, 11)
+	if got := Flops("Dgemm", 70, 90, 11); got != flops {
+		t.Errorf("Flops and DgemmWork differ: %v != %v", got, flops)
+	}
+
+	// Every operation is a routine of blas.Float64.
+	for op := range flopCounts {
+		if _, ok := float64Type.MethodByName(op); !ok {
+			t.Errorf("%v is not a blas.Float64 routine", op)
+		}
+	}
+
+	for _, f := range []func(){
+		func() { Flops("Dfoo", 1) },
+		func() { Flops("Dgemm", 1, 2) },
+		func() { Flops("Ddot") },
+		func() { Flops("Dgemv", 3, -1) },
+	} {
+		if !panics(f) {
+			t.Errorf("expected panic")
+		}
+	}
+}
@@ -6,11 +6,16 @@ package goblas
 
 import (
 	"errors"
+	"fmt"
 	"math"
 )
 
 const (
 	debug = false
+
+	nonFiniteScalar = "blas: non-finite scalar"
+
+	maxInt = int(^uint(0) >> 1)
 )
 
 func newGeneral(r, c int) general {
@@ -73,12 +78,53 @@ func (g general) check() error {
 	if g.stride < g.cols {
 		return errors.New("general: illegal stride")
 	}
+	if g.rows == 0 || g.cols == 0 {
+		// An empty matrix does not reference any data.
+		return nil
+	}
+	// The offset of the last element, (rows-1)*stride+cols-1, must be
+	// representable so that no index into the matrix wraps around.
+	if g.rows-1 > (maxInt-g.cols)/g.stride {
+		return errors.New("general: rows*stride overflows int")
+	}
 	if (g.rows-1)*g.stride+g.cols > len(g.data) {
 		return errors.New("general: insufficient length")
 	}
 	return nil
 }
 
+// mustCheck panics if g is not a valid matrix. The error of check is
+// prefixed with "goblas: ", as are the other argument errors of the package.
+func (g general) mustCheck() {
+	if err := g.check(); err != nil {
+		panic(errors.New("goblas: " + err.Error()))
+	}
+}
+
+// CheckGeneral reports whether data, rows, cols and stride describe a valid
+// row-major matrix, applying the same checks that Dgemm and the other level 3
+// routines apply to their matrix arguments before they panic. The error
+// states which check failed along with the dimensions, so a matrix can be
+// validated where it enters a program instead of inside a BLAS call.
+func CheckGeneral(rows, cols, stride int, data []float64) error {
+	g := general{data: data, rows: rows, cols: cols, stride: stride}
+	err := g.check()
+	if err == nil {
+		return nil
+	}
+	dims := fmt.Sprintf("rows = %d, cols = %d, stride = %d, len(data) = %d", rows, cols, stride, len(data))
+	if stride >= max(1, cols) && rows > 0 && cols > 0 && rows-1 <= (maxInt-cols)/stride {
+		dims += fmt.Sprintf("; need len(data) >= %d", (rows-1)*stride+cols)
+	}
+	return fmt.Errorf("goblas: %v (%s)", err, dims)
+}
+
+// contiguous reports whether the rows of g follow each other in data
+// without a gap, so that g is stored in data[:g.rows*g.cols].
+func (g general) contiguous() bool {
+	return g.stride == g.cols || g.rows <= 1
+}
+
 func (g general) clone() general {
 	data := make([]float64, len(g.data))
 	copy(data, g.data)
@@ -128,13 +174,13 @@ func (g general) print() {
 }
 */
 
+// view returns the r×c sub-matrix of g starting at row i and column j. The
+// sub-matrix must lie within g; since g passed check, none of the offsets
+// computed here can overflow.
 func (g general) view(i, j, r, c int) general {
 	if debug {
-		if i < 0 || i+r > g.rows {
-			panic("row out of bounds")
-		}
-		if j < 0 || j+c > g.cols {
-			panic("col out of bounds")
+		if err := g.checkView(i, j, r, c); err != nil {
+			panic(err)
 		}
 	}
 	return general{
@@ -145,6 +191,34 @@ func (g general) view(i, j, r, c int) general {
 	}
 }
 
+// checkView returns an error if the r×c sub-matrix of g at row i and column
+// j is empty or does not lie within g.
+func (g general) checkView(i, j, r, c int) error {
+	if r < 1 || c < 1 {
+		return fmt.Errorf("general: empty %d×%d view", r, c)
+	}
+	if i < 0 || i+r > g.rows {
+		return fmt.Errorf("general: view rows [%d, %d) out of bounds of %d×%d matrix", i, i+r, g.rows, g.cols)
+	}
+	if j < 0 || j+c > g.cols {
+		return fmt.Errorf("general: view cols [%d, %d) out of bounds of %d×%d matrix", j, j+c, g.rows, g.cols)
+	}
+	return nil
+}
+
+// view returns g.view(i, j, r, c). If bl was configured with WithDebug, it
+// first checks that the sub-matrix lies within g, so that an error in the
+// handling of the edge blocks panics with the offending bounds instead of
+// reading outside the sub-matrix.
+func (bl Blas) view(g general, i, j, r, c int) general {
+	if bl.debug {
+		if err := g.checkView(i, j, r, c); err != nil {
+			panic(err)
+		}
+	}
+	return g.view(i, j, r, c)
+}
+
 func (g general) equalWithinAbs(a general, tol float64) bool {
 	if g.rows != a.rows || g.cols != a.cols || g.stride != a.stride {
 		return false
@@ -156,3 +230,8 @@ func (g general) equalWithinAbs(a general, tol float64) bool {
 	}
 	return true
 }
+
+// finite reports whether alpha and beta are both finite.
+func finite(alpha, beta float64) bool {
+	return !math.IsNaN(alpha) && !math.IsInf(alpha, 0) && !math.IsNaN(beta) && !math.IsInf(beta, 0)
+}
@@ -0,0 +1,116 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestGeneralCheckOverflow(t *testing.T) {
+	for i, g := range []general{
+		{rows: maxInt/4 + 2, cols: 2, stride: 4},
+		{rows: 3, cols: 2, stride: maxInt/2 + 1},
+		{rows: 2, cols: maxInt, stride: maxInt},
+	} {
+		if g.check() == nil {
+			t.Errorf("Case %v: expected overflow error for rows = %v, stride = %v", i, g.rows, g.stride)
+		}
+	}
+	// The largest representable matrix is not an overflow, only too short.
+	g := general{rows: 2, cols: 1, stride: maxInt - 1}
+	if err := g.check(); err == nil || err.Error() != "general: insufficient length" {
+		t.Errorf("unexpected error for largest offset: %v", err)
+	}
+	if !panics(func() {
+		Blasser.Dgemm(blas.NoTrans, blas.NoTrans, maxInt/4+2, 1, 2, 1, nil, 4, make([]float64, 2), 1, 0, nil, 1)
+	}) {
+		t.Errorf("expected Dgemm to panic on overflowing dimensions")
+	}
+}
+
+func TestGeneralCheckStride(t *testing.T) {
+	for _, g := range []general{
+		{data: make([]float64, 16), rows: 4, cols: 4, stride: 0},
+		{data: make([]float64, 16), rows: 4, cols: 4, stride: -4},
+		{rows: 0, cols: 0, stride: 0},
+		{rows: 0, cols: 0, stride: -1},
+	} {
+		if err := g.check(); err == nil || err.Error() != "general: stride < 1" {
+			t.Errorf("unexpected error for %d×%d matrix with stride %d: %v", g.rows, g.cols, g.stride, err)
+		}
+	}
+	if !panics(func() {
+		Blasser.Dgemm(blas.NoTrans, blas.NoTrans, 2, 2, 2, 1, make([]float64, 4), 2, make([]float64, 4), -2, 0, make([]float64, 4), 2)
+	}) {
+		t.Errorf("expected Dgemm to panic on negative ldb")
+	}
+}
+
+func TestBlasViewDebug(t *testing.T) {
+	g := newGeneral(4, 5)
+	for i := range g.data {
+		g.data[i] = float64(i)
+	}
+	bl := New(WithDebug(true))
+	v := bl.view(g, 1, 2, 3, 3)
+	if v.rows != 3 || v.cols != 3 || v.stride != 5 || v.at(0, 0) != 7 || v.at(2, 2) != 19 {
+		t.Errorf("unexpected view: %+v", v)
+	}
+	for _, test := range []struct {
+		i, j, r, c int
+		want       string
+	}{
+		{2, 0, 3, 5, "general: view rows [2, 5) out of bounds of 4×5 matrix"},
+		{0, -1, 4, 2, "general: view cols [-1, 1) out of bounds of 4×5 matrix"},
+		{0, 3, 1, 3, "general: view cols [3, 6) out of bounds of 4×5 matrix"},
+		{0, 0, 0, 5, "general: empty 0×5 view"},
+	} {
+		msg := panicMessage(func() { bl.view(g, test.i, test.j, test.r, test.c) })
+		if msg != test.want {
+			t.Errorf("view(%d, %d, %d, %d): got panic %q, want %q", test.i, test.j, test.r, test.c, msg, test.want)
+		}
+	}
+}
+
+func TestCheckGeneral(t *testing.T) {
+	for _, test := range []struct {
+		rows, cols, stride, len int
+		want                    string
+	}{
+		{3, 4, 5, 14, ""},
+		{3, 4, 4, 12, ""},
+		{0, 4, 4, 0, ""},
+		{4, 0, 1, 0, ""},
+		{-1, 4, 4, 0, "goblas: general: rows < 0 (rows = -1, cols = 4, stride = 4, len(data) = 0)"},
+		{3, -1, 4, 0, "goblas: general: cols < 0 (rows = 3, cols = -1, stride = 4, len(data) = 0)"},
+		{3, 4, 0, 12, "goblas: general: stride < 1 (rows = 3, cols = 4, stride = 0, len(data) = 12)"},
+		{3, 4, 3, 12, "goblas: general: illegal stride (rows = 3, cols = 4, stride = 3, len(data) = 12)"},
+		{3, 4, 5, 13, "goblas: general: insufficient length (rows = 3, cols = 4, stride = 5, len(data) = 13; need len(data) >= 14)"},
+		{maxInt/4 + 2, 2, 4, 0, fmt.Sprintf("goblas: general: rows*stride overflows int (rows = %d, cols = 2, stride = 4, len(data) = 0)", maxInt/4+2)},
+	} {
+		err := CheckGeneral(test.rows, test.cols, test.stride, make([]float64, test.len))
+		var got string
+		if err != nil {
+			got = err.Error()
+		}
+		if got != test.want {
+			t.Errorf("rows = %d, cols = %d, stride = %d, len = %d: got error %q, want %q", test.rows, test.cols, test.stride, test.len, got, test.want)
+		}
+		// CheckGeneral must agree with Dgemm on which matrices are valid.
+		if test.rows >= 0 && test.cols >= 0 && test.rows < 100 {
+			p := panics(func() {
+				Blasser.Dgemm(blas.NoTrans, blas.NoTrans, test.rows, test.cols, test.cols, 1,
+					make([]float64, test.rows*test.cols), max(1, test.cols), make([]float64, test.cols*test.cols), max(1, test.cols),
+					0, make([]float64, test.len), test.stride)
+			})
+			if p != (err != nil) {
+				t.Errorf("rows = %d, cols = %d, stride = %d, len = %d: Dgemm panics = %v, CheckGeneral error = %v", test.rows, test.cols, test.stride, test.len, p, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"reflect"
+
+	"github.com/gonum/blas"
+)
+
+// notImplemented holds the names of the routines for which Blas only has a
+// stub that panics. It must be updated when a stub is implemented;
+// TestImplemented checks that it matches the stubs.
+var notImplemented = map[string]bool{
+	"Dsbmv":  true,
+	"Dspr":   true,
+	"Dspr2":  true,
+	"Dsyr":   true,
+	"Dsyr2":  true,
+	"Dsymm":  true,
+	"Dsyr2k": true,
+	"Dtrmm":  true,
+}
+
+var (
+	float64Type    = reflect.TypeOf((*blas.Float64)(nil)).Elem()
+	complex128Type = reflect.TypeOf((*blas.Complex128)(nil)).Elem()
+	blasType       = reflect.TypeOf(Blas{})
+)
+
+// Implemented reports whether name, such as "Dgemm", is a routine of the
+// blas.Float64 or blas.Complex128 interface that Blas implements. Blas
+// satisfies blas.Float64, but some of its routines panic when called; a
+// program that selects between implementations can use Implemented to fall
+// back to another implementation, such as cblas, for those. Of the
+// blas.Complex128 routines, Blas only provides a few as methods.
+func (Blas) Implemented(name string) bool {
+	if notImplemented[name] {
+		return false
+	}
+	if _, ok := blasType.MethodByName(name); !ok {
+		return false
+	}
+	_, f := float64Type.MethodByName(name)
+	_, c := complex128Type.MethodByName(name)
+	return f || c
+}
@@ -0,0 +1,52 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestImplemented(t *testing.T) {
+	// Call every routine with zero arguments. The stubs panic at once with
+	// a "not implemented" message, and no implemented routine may do so.
+	v := reflect.ValueOf(Blasser)
+	for _, typ := range []reflect.Type{float64Type, complex128Type} {
+		for i := 0; i < typ.NumMethod(); i++ {
+			name := typ.Method(i).Name
+			m := v.MethodByName(name)
+			if !m.IsValid() {
+				if Blasser.Implemented(name) {
+					t.Errorf("%v: Implemented is true for a missing method", name)
+				}
+				continue
+			}
+			args := make([]reflect.Value, m.Type().NumIn())
+			for j := range args {
+				args[j] = reflect.Zero(m.Type().In(j))
+			}
+			msg := func() (msg string) {
+				defer func() {
+					if r := recover(); r != nil {
+						msg = fmt.Sprint(r)
+					}
+				}()
+				m.Call(args)
+				return ""
+			}()
+			stub := strings.Contains(msg, "not implemented")
+			if Blasser.Implemented(name) == stub {
+				t.Errorf("%v: Implemented is %v, but the call panicked with %q", name, !stub, msg)
+			}
+		}
+	}
+	for _, name := range []string{"", "Sgemm", "DgemmTo", "Implemented", "dgemm"} {
+		if Blasser.Implemented(name) {
+			t.Errorf("Implemented(%q) is true", name)
+		}
+	}
+}
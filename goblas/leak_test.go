@@ -0,0 +1,148 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gonum/blas"
+)
+
+// checkNoLeak calls f, recovering any panic, and reports an error if the
+// number of goroutines has not returned to its previous value shortly after
+// f returns. It returns the recovered panic value.
+func checkNoLeak(t *testing.T, name string, f func()) (v interface{}) {
+	before := runtime.NumGoroutine()
+	func() {
+		defer func() { v = recover() }()
+		f()
+	}()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		after := runtime.NumGoroutine()
+		if after <= before {
+			return v
+		}
+		if time.Now().After(deadline) {
+			t.Errorf("%v: goroutine leak: %v before, %v after", name, before, after)
+			return v
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestNoGoroutineLeak(t *testing.T) {
+	const n = 200
+	a := randSlice(n * n)
+	b := randSlice(n * n)
+	c := randSlice(n * n)
+	d := randSlice(n * n)
+	bl := New(WithMaxWorkers(4), WithBlockSize(16))
+	for _, test := range []struct {
+		name string
+		f    func()
+	}{
+		{"Dgemm alpha = 0", func() {
+			bl.Dgemm(blas.NoTrans, blas.NoTrans, n, n, n, 0, a, n, b, n, 2, c, n)
+		}},
+		{"Dgemm serial", func() {
+			bl.Dgemm(blas.NoTrans, blas.NoTrans, 10, 10, 10, 1, a, n, b, n, 1, c, n)
+		}},
+		{"Dgemm parallel", func() {
+			bl.Dgemm(blas.Trans, blas.NoTrans, n, n, n, 1, a, n, b, n, 1, c, n)
+		}},
+		{"DgemmTo parallel", func() {
+			bl.DgemmTo(d, n, blas.NoTrans, blas.Trans, n, n, n, 1, a, n, b, n, 1, c, n)
+		}},
+		{"Dgemm recursive", func() {
+			New(WithMaxWorkers(4), WithDgemmStrategy(RecursiveDgemm), WithBlockSize(16)).
+				Dgemm(blas.NoTrans, blas.NoTrans, n, n, n, 1, a, n, b, n, 1, c, n)
+		}},
+		{"Dgemm stats", func() {
+			New(WithMaxWorkers(4), WithStats(true)).
+				Dgemm(blas.NoTrans, blas.NoTrans, n, n, n, 1, a, n, b, n, 1, c, n)
+		}},
+		{"Dgemm bad argument", func() {
+			bl.Dgemm(blas.NoTrans, blas.NoTrans, n, n, n, 1, a, n-1, b, n, 1, c, n)
+		}},
+		{"DgerBatch", func() {
+			bl.DgerBatch(n, n, 1, [][]float64{a[:n], b[:n]}, [][]float64{b[:n], a[:n]}, c, n)
+		}},
+		{"Dhad", func() {
+			bl.Dhad(n, n, a, n, b, n, d, n)
+		}},
+		{"Dtrsm", func() {
+			bl.Dtrsm(blas.Left, blas.Upper, blas.NoTrans, blas.Unit, n, n, 1, a, n, d, n)
+		}},
+	} {
+		checkNoLeak(t, test.name, test.f)
+	}
+}
+
+func TestWorkerPanic(t *testing.T) {
+	bl := New(WithMaxWorkers(4))
+	const nBlocks = 100
+	gen := func(send func(subMul)) {
+		for i := 0; i < nBlocks; i++ {
+			send(subMul{i: i})
+		}
+	}
+
+	// A panic in one block is raised again by runBlocks, after all of the
+	// workers have exited.
+	v := checkNoLeak(t, "runBlocks work panic", func() {
+		bl.runBlocks(nBlocks, gen, func(sub subMul) {
+			if sub.i == 10 {
+				panic("work")
+			}
+		})
+	})
+	if v != "work" {
+		t.Errorf("runBlocks work panic: got panic %v", v)
+	}
+
+	// A panic while dispatching the blocks must not leave the workers
+	// blocked on the channel, and reaches the caller only once the blocks
+	// that were sent have been computed.
+	// The workers are pinned so that the blocks are computed concurrently
+	// whatever GOMAXPROCS is.
+	pinned := bl
+	pinned.nWorkers = 4
+	var done, atPanic int32
+	v = checkNoLeak(t, "runBlocks gen panic", func() {
+		defer func() { atPanic = atomic.LoadInt32(&done) }()
+		pinned.runBlocks(nBlocks, func(send func(subMul)) {
+			for i := 0; i < nBlocks; i++ {
+				if i == 10 {
+					panic("gen")
+				}
+				send(subMul{i: i})
+			}
+		}, func(subMul) {
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&done, 1)
+		})
+	})
+	if v != "gen" {
+		t.Errorf("runBlocks gen panic: got panic %v", v)
+	}
+	if atPanic != 10 {
+		t.Errorf("runBlocks gen panic: %v of 10 blocks computed when the panic was raised", atPanic)
+	}
+
+	v = checkNoLeak(t, "parallelRows panic", func() {
+		bl.parallelRows(minParScale, 4, func(i, r int) {
+			if i == 0 {
+				panic("rows")
+			}
+		})
+	})
+	if v != "rows" {
+		t.Errorf("parallelRows panic: got panic %v", v)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import "math/cmplx"
+
+// Zdotu computes the unconjugated dot product of the two complex vectors
+// \sum_i x[i]*y[i].
+func (Blas) Zdotu(n int, x []complex128, incX int, y []complex128, incY int) complex128 {
+	return zdot(n, x, incX, y, incY, false)
+}
+
+// Zdotc computes the dot product of the two complex vectors with x
+// conjugated, \sum_i conj(x[i])*y[i]. Zdotc(n, x, incX, x, incX) is the
+// squared Euclidean norm of x, which is real and non-negative.
+func (Blas) Zdotc(n int, x []complex128, incX int, y []complex128, incY int) complex128 {
+	return zdot(n, x, incX, y, incY, true)
+}
+
+// zdot computes \sum_i x[i]*y[i], conjugating x if conj is true.
+func zdot(n int, x []complex128, incX int, y []complex128, incY int, conj bool) complex128 {
+	if n < 0 {
+		panic(negativeN)
+	}
+	if incX == 0 || incY == 0 {
+		panic(zeroInc)
+	}
+	var ix, iy int
+	if incX < 0 {
+		ix = (-n + 1) * incX
+	}
+	if incY < 0 {
+		iy = (-n + 1) * incY
+	}
+	var sum complex128
+	for i := 0; i < n; i++ {
+		v := x[ix]
+		if conj {
+			v = cmplx.Conj(v)
+		}
+		sum += v * y[iy]
+		ix += incX
+		iy += incY
+	}
+	return sum
+}
+
+// Zaxpy computes y <- α x + y for complex vectors x and y.
+func (Blas) Zaxpy(n int, alpha complex128, x []complex128, incX int, y []complex128, incY int) {
+	if n < 1 {
+		if n == 0 {
+			return
+		}
+		panic(negativeN)
+	}
+	if incX == 0 || incY == 0 {
+		panic(zeroInc)
+	}
+	if alpha == 0 {
+		return
+	}
+	if incX == 1 && incY == 1 {
+		for i, v := range x[:n] {
+			y[i] += alpha * v
+		}
+		return
+	}
+
+	var ix, iy int
+	if incX < 0 {
+		ix = (-n + 1) * incX
+	}
+	if incY < 0 {
+		iy = (-n + 1) * incY
+	}
+	for i := 0; i < n; i++ {
+		y[iy] += alpha * x[ix]
+		ix += incX
+		iy += incY
+	}
+}
+
+// Zscal computes x <- α x for a complex scalar α. As for Dscal, x is not
+// changed if incX is negative.
+func (Blas) Zscal(n int, alpha complex128, x []complex128, incX int) {
+	if incX < 1 {
+		if incX == 0 {
+			panic(zeroInc)
+		}
+		return
+	}
+	if n < 1 {
+		if n == 0 {
+			return
+		}
+		panic(negativeN)
+	}
+	for ix := 0; ix < n*incX; ix += incX {
+		x[ix] *= alpha
+	}
+}
+
+// Zdscal computes x <- α x for a real scalar α, scaling the real and
+// imaginary parts of each element separately. As for Dscal, x is not changed
+// if incX is negative.
+func (Blas) Zdscal(n int, alpha float64, x []complex128, incX int) {
+	if incX < 1 {
+		if incX == 0 {
+			panic(zeroInc)
+		}
+		return
+	}
+	if n < 1 {
+		if n == 0 {
+			return
+		}
+		panic(negativeN)
+	}
+	for ix := 0; ix < n*incX; ix += incX {
+		v := x[ix]
+		x[ix] = complex(alpha*real(v), alpha*imag(v))
+	}
+}
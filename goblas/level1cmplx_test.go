@@ -0,0 +1,130 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestZdot(t *testing.T) {
+	x := []complex128{1 + 2i, 3 - 1i, -2 + 0.5i}
+	y := []complex128{2 - 1i, 1i, 4}
+	for _, test := range []struct {
+		n, incX, incY int
+		dotu, dotc    complex128
+	}{
+		{0, 1, 1, 0, 0},
+		{3, 1, 1, (1+2i)*(2-1i) + (3-1i)*1i + (-2+0.5i)*4, (1-2i)*(2-1i) + (3+1i)*1i + (-2-0.5i)*4},
+		{2, 2, 1, (1+2i)*(2-1i) + (-2+0.5i)*1i, (1-2i)*(2-1i) + (-2-0.5i)*1i},
+		// With a negative increment, element 0 of x is the last one stored.
+		{2, -2, 1, (-2+0.5i)*(2-1i) + (1+2i)*1i, (-2-0.5i)*(2-1i) + (1-2i)*1i},
+		{3, 1, -1, (1+2i)*4 + (3-1i)*1i + (-2+0.5i)*(2-1i), (1-2i)*4 + (3+1i)*1i + (-2-0.5i)*(2-1i)},
+	} {
+		if got := Blasser.Zdotu(test.n, x, test.incX, y, test.incY); cmplx.Abs(got-test.dotu) > 1e-14 {
+			t.Errorf("Zdotu(%d, incX = %d, incY = %d): got %v, want %v", test.n, test.incX, test.incY, got, test.dotu)
+		}
+		if got := Blasser.Zdotc(test.n, x, test.incX, y, test.incY); cmplx.Abs(got-test.dotc) > 1e-14 {
+			t.Errorf("Zdotc(%d, incX = %d, incY = %d): got %v, want %v", test.n, test.incX, test.incY, got, test.dotc)
+		}
+	}
+
+	// Zdotc(x, x) is the squared norm of x.
+	norm2 := Blasser.Zdotc(len(x), x, 1, x, 1)
+	var want float64
+	for _, v := range x {
+		want += real(v)*real(v) + imag(v)*imag(v)
+	}
+	if imag(norm2) != 0 || real(norm2) < 0 || math.Abs(real(norm2)-want) > 1e-14 {
+		t.Errorf("Zdotc(x, x) = %v, want %v", norm2, want)
+	}
+
+	for _, f := range []func(){
+		func() { Blasser.Zdotu(-1, x, 1, y, 1) },
+		func() { Blasser.Zdotc(1, x, 0, y, 1) },
+		func() { Blasser.Zdotc(4, x, 1, y, 1) },
+	} {
+		if !panics(f) {
+			t.Errorf("Expected panic")
+		}
+	}
+}
+
+func TestZaxpy(t *testing.T) {
+	for _, test := range []struct {
+		n, incX, incY int
+		alpha         complex128
+		x, y, want    []complex128
+	}{
+		{0, 1, 1, 2, []complex128{1}, []complex128{1}, []complex128{1}},
+		{2, 1, 1, 0, []complex128{1, 2}, []complex128{3, 4}, []complex128{3, 4}},
+		{2, 1, 1, 1i, []complex128{1, 2i}, []complex128{3, 4}, []complex128{3 + 1i, 2}},
+		{2, 2, 1, 2, []complex128{1, 9, 1i}, []complex128{3, 4, 5}, []complex128{5, 4 + 2i, 5}},
+		{2, -1, 2, 1 + 1i, []complex128{1, 1i}, []complex128{0, 7, 0}, []complex128{-1 + 1i, 7, 1 + 1i}},
+	} {
+		y := append([]complex128(nil), test.y...)
+		Blasser.Zaxpy(test.n, test.alpha, test.x, test.incX, y, test.incY)
+		for i := range y {
+			if y[i] != test.want[i] {
+				t.Errorf("Zaxpy(%d, %v, incX = %d, incY = %d): got %v, want %v", test.n, test.alpha, test.incX, test.incY, y, test.want)
+				break
+			}
+		}
+	}
+	if !panics(func() { Blasser.Zaxpy(-1, 1, nil, 1, nil, 1) }) {
+		t.Errorf("Expected panic for negative n")
+	}
+	if !panics(func() { Blasser.Zaxpy(1, 1, []complex128{1}, 1, []complex128{1}, 0) }) {
+		t.Errorf("Expected panic for zero increment")
+	}
+}
+
+func TestZscal(t *testing.T) {
+	x := []complex128{1 + 1i, 2, 3i, 4 - 1i}
+	Blasser.Zscal(2, 1i, x, 2)
+	if want := []complex128{-1 + 1i, 2, -3, 4 - 1i}; !zEqual(x, want) {
+		t.Errorf("Zscal: got %v, want %v", x, want)
+	}
+	Blasser.Zdscal(3, 2, x, 1)
+	if want := []complex128{-2 + 2i, 4, -6, 4 - 1i}; !zEqual(x, want) {
+		t.Errorf("Zdscal: got %v, want %v", x, want)
+	}
+	// Zdscal scales the parts separately, so an infinite imaginary part does
+	// not produce a NaN real part.
+	y := []complex128{complex(1, math.Inf(1))}
+	Blasser.Zdscal(1, 2, y, 1)
+	if real(y[0]) != 2 || !math.IsInf(imag(y[0]), 1) {
+		t.Errorf("Zdscal: got %v, want (2+Infi)", y[0])
+	}
+	// A negative increment leaves x unchanged.
+	Blasser.Zscal(2, 0, x, -1)
+	Blasser.Zdscal(2, 0, x, -1)
+	if want := []complex128{-2 + 2i, 4, -6, 4 - 1i}; !zEqual(x, want) {
+		t.Errorf("negative increment: got %v, want %v", x, want)
+	}
+	for _, f := range []func(){
+		func() { Blasser.Zscal(1, 1, x, 0) },
+		func() { Blasser.Zscal(-1, 1, x, 1) },
+		func() { Blasser.Zdscal(1, 1, x, 0) },
+		func() { Blasser.Zdscal(-1, 1, x, 1) },
+	} {
+		if !panics(f) {
+			t.Errorf("Expected panic")
+		}
+	}
+}
+
+func zEqual(a, b []complex128) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, v := range a {
+		if v != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -1,7 +1,37 @@
+// Package goblas is a pure Go implementation of the BLAS API.
+//
 // Uses the netlib standard. Other implementations may differ. Difference
 // is that the code panics for n < 0 and incx == 0 rather than returning zero.
 // (Documentation says incx must not be zero)
 //
+// As in package blas, all matrices are stored in row-major order and there is
+// no order parameter. A column-major matrix is the row-major storage of its
+// transpose, so column-major data must be passed with the transpose flags and
+// dimensions adjusted accordingly; it is not detected or converted.
+// DgemmColMajor does this adjustment for Dgemm.
+//
+// The methods take raw slices, dimensions and strides. Package dbw provides
+// typed wrappers taking matrix and vector structs instead; after
+// dbw.Register(goblas.Blas{}), dbw.Gemm(tA, tB, alpha, A, B, beta, C) calls
+// Dgemm with the dimensions and strides taken from the dbw.General values.
+//
+// Setting the environment variable GOBLAS_SERIAL to a true value, such as
+// GOBLAS_SERIAL=1, forces every routine to compute serially in the calling
+// goroutine, regardless of the configuration of the Blas value. This gives a
+// deterministic reference for comparison with the concurrent code paths. The
+// variable is read once when the package is initialized, so it must be set
+// before the program starts.
+//
+// The Level 3 routines start from a default block size of 64. Setting
+// GOBLAS_AUTOTUNE to a true value, such as GOBLAS_AUTOTUNE=1, makes the first
+// Level 3 call in a program time a small blocked product at a few block
+// sizes instead, which takes some tens of milliseconds, and use the fastest
+// as the starting point for the block size of every later call that does not
+// set one with WithBlockSize. The block size decides how the inner products
+// of Dgemm are split, so with autotuning the results may differ in the last
+// bits from run to run, as well as the timings. GOBLAS_AUTOTUNE is also read
+// once when the package is initialized.
+//
 // TODO: Improve documentation
 package goblas
 
@@ -11,7 +41,23 @@ import (
 	"github.com/gonum/blas"
 )
 
-type Blas struct{}
+// Blas implements the blas.Float64 interface in pure Go. The zero value is
+// ready to use with the default configuration. Use New to construct a Blas
+// with different tuning parameters.
+type Blas struct {
+	bs         int            // block size used by the blocked Level 3 routines; 0 means chosen adaptively
+	maxWorkers int            // maximum number of concurrent workers; 0 means runtime.GOMAXPROCS(0)
+	nWorkers   int            // number of workers regardless of GOMAXPROCS, set by DgemmN; 0 if unset
+	order      BlockOrder     // order in which blocks are dispatched to the workers
+	dispatch   int            // blocks buffered per worker when dispatching; 0 means buffMul
+	strategy   DgemmStrategy  // algorithm used to partition Dgemm
+	reduce     ReduceStrategy // order in which partial sums of the workers are added
+	strict     bool           // whether zero elements are multiplied rather than skipped
+	debug      bool           // whether additional internal consistency checks are performed
+
+	stats    *statsRecorder    // recorder of the last Dgemm call; nil if stats are disabled
+	progress *progressReporter // reporter of completed Dgemm blocks; nil outside DgemmProgress
+}
 
 var Blasser Blas
 
@@ -60,6 +106,41 @@ func (Blas) Ddot(n int, x []float64, incX int, y []float64, incY int) float64 {
 	return sum
 }
 
+// pairwiseBlock is the length below which DdotPairwise sums sequentially.
+const pairwiseBlock = 32
+
+// DdotPairwise computes the dot product of the two vectors \sum_i x[i]*y[i]
+// like Ddot, but sums the products pairwise: the vectors are split in half
+// recursively and the partial sums of the halves added, with blocks of at
+// most 32 elements summed sequentially. The rounding error grows as
+// O(log n) instead of O(n), and since the split points depend only on n
+// the result does not depend on how the summation is implemented.
+func (Blas) DdotPairwise(n int, x []float64, incX int, y []float64, incY int) float64 {
+	if n < 0 {
+		panic(negativeN)
+	}
+	if incX == 0 || incY == 0 {
+		panic(zeroInc)
+	}
+	var ix, iy int
+	if incX < 0 {
+		ix = (-n + 1) * incX
+	}
+	if incY < 0 {
+		iy = (-n + 1) * incY
+	}
+	return pairwiseSum(0, n, pairwiseBlock, func(lo, hi int) float64 {
+		var sum float64
+		jx, jy := ix+lo*incX, iy+lo*incY
+		for i := lo; i < hi; i++ {
+			sum += y[jy] * x[jx]
+			jx += incX
+			jy += incY
+		}
+		return sum
+	})
+}
+
 // Dnrm2 computes the euclidean norm of a vector via the function
 // name so that
 //       dnrm2 = sqrt(x'x)
@@ -291,6 +372,62 @@ func (Blas) Daxpy(n int, alpha float64, x []float64, incX int, y []float64, incY
 	}
 }
 
+// Daxpby computes y <- α x + β y in a single pass over y. It is not part of
+// the reference BLAS. If β is zero, y is not read, so it need not be set on
+// input. If α is zero, x is not referenced and y is only scaled by β, and if
+// in addition β is one, Daxpby does nothing.
+func (Blas) Daxpby(n int, alpha float64, x []float64, incX int, beta float64, y []float64, incY int) {
+	if n < 1 {
+		if n == 0 {
+			return
+		}
+		panic(negativeN)
+	}
+	if incX == 0 || incY == 0 {
+		panic(zeroInc)
+	}
+	if alpha == 0 && beta == 1 {
+		return
+	}
+
+	var ix, iy int
+	if incX < 0 {
+		ix = (-n + 1) * incX
+	}
+	if incY < 0 {
+		iy = (-n + 1) * incY
+	}
+	switch {
+	case alpha == 0:
+		for i := 0; i < n; i++ {
+			if beta == 0 {
+				y[iy] = 0
+			} else {
+				y[iy] *= beta
+			}
+			iy += incY
+		}
+	case beta == 0:
+		for i := 0; i < n; i++ {
+			y[iy] = alpha * x[ix]
+			ix += incX
+			iy += incY
+		}
+	case beta == 1:
+		for i := 0; i < n; i++ {
+			y[iy] += alpha * x[ix]
+			ix += incX
+			iy += incY
+		}
+	default:
+		for i := 0; i < n; i++ {
+			y[iy] = alpha*x[ix] + beta*y[iy]
+			ix += incX
+			iy += incY
+		}
+	}
+}
+
 // DrotG gives plane rotation
 //
 // _      _    _   _     _   _
@@ -0,0 +1,176 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math/cmplx"
+
+	"github.com/gonum/blas"
+)
+
+// Zhemv performs the matrix-vector operation
+//
+//	y := alpha*A*x + beta*y,
+//
+// where alpha and beta are scalars, x and y are n element vectors and A is an
+// n by n Hermitian matrix. Only the ul triangle of A is referenced; the other
+// triangle is taken to be its conjugate transpose. The imaginary parts of the
+// diagonal elements are assumed to be zero and are not referenced.
+// If beta is zero, y need not be set on input.
+func (Blas) Zhemv(ul blas.Uplo, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	// Check inputs
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if lda < max(1, n) {
+		panic(badLda)
+	}
+	if incX == 0 {
+		panic(zeroInc)
+	}
+	if incY == 0 {
+		panic(zeroInc)
+	}
+	// Quick return if possible
+	if n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+
+	// Set up start points
+	var kx, ky int
+	if incX < 0 {
+		kx = -(n - 1) * incX
+	}
+	if incY < 0 {
+		ky = -(n - 1) * incY
+	}
+
+	// Form y = beta * y
+	if beta != 1 {
+		iy := ky
+		for i := 0; i < n; i++ {
+			if beta == 0 {
+				y[iy] = 0
+			} else {
+				y[iy] *= beta
+			}
+			iy += incY
+		}
+	}
+
+	if alpha == 0 {
+		return
+	}
+
+	// Form y = alpha * A * x + y. An off-diagonal element a_ij of the stored
+	// triangle contributes a_ij * x_j to y_i and conj(a_ij) * x_i to y_j.
+	ix := kx
+	iy := ky
+	for i := 0; i < n; i++ {
+		tmp1 := alpha * x[ix]
+		tmp2 := complex(real(a[i*lda+i]), 0) * x[ix]
+		if ul == blas.Upper {
+			jx := ix
+			jy := iy
+			for j := i + 1; j < n; j++ {
+				jx += incX
+				jy += incY
+				v := a[i*lda+j]
+				tmp2 += v * x[jx]
+				y[jy] += tmp1 * cmplx.Conj(v)
+			}
+		} else {
+			jx := kx
+			jy := ky
+			for j := 0; j < i; j++ {
+				v := a[i*lda+j]
+				tmp2 += v * x[jx]
+				y[jy] += tmp1 * cmplx.Conj(v)
+				jx += incX
+				jy += incY
+			}
+		}
+		y[iy] += alpha * tmp2
+		ix += incX
+		iy += incY
+	}
+}
+
+// Zgeru performs the rank one operation
+//
+//	A := alpha*x*y^T + A,
+//
+// where alpha is a scalar, x is an m element vector, y is an n element vector
+// and A is an m by n matrix.
+func (bl Blas) Zgeru(m, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) {
+	zger(m, n, alpha, x, incX, y, incY, a, lda, false, bl.strict)
+}
+
+// Zgerc performs the rank one operation
+//
+//	A := alpha*x*y^H + A,
+//
+// where alpha is a scalar, x is an m element vector, y is an n element vector
+// and A is an m by n matrix.
+func (bl Blas) Zgerc(m, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) {
+	zger(m, n, alpha, x, incX, y, incY, a, lda, true, bl.strict)
+}
+
+// zger computes A += alpha * x * y^T, conjugating y if conj is true. Rows for
+// which x_i is zero are skipped unless strict is true.
+func zger(m, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int, conj, strict bool) {
+	// Check inputs
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if incX == 0 {
+		panic(zeroInc)
+	}
+	if incY == 0 {
+		panic(zeroInc)
+	}
+	if lda < max(1, n) {
+		panic(badLdaRow)
+	}
+
+	// Quick return if possible
+	if m == 0 || n == 0 || alpha == 0 {
+		return
+	}
+
+	var kx, ky int
+	if incX < 0 {
+		kx = -(m - 1) * incX
+	}
+	if incY < 0 {
+		ky = -(n - 1) * incY
+	}
+
+	ix := kx
+	for i := 0; i < m; i++ {
+		if x[ix] == 0 && !strict {
+			ix += incX
+			continue
+		}
+		tmp := alpha * x[ix]
+		atmp := a[i*lda : i*lda+n]
+		jy := ky
+		for j := range atmp {
+			v := y[jy]
+			if conj {
+				v = cmplx.Conj(v)
+			}
+			atmp[j] += tmp * v
+			jy += incY
+		}
+		ix += incX
+	}
+}
@@ -0,0 +1,15 @@
+package goblas
+
+import (
+	"testing"
+
+	"github.com/gonum/blas/testblas"
+)
+
+func TestZhemv(t *testing.T) {
+	testblas.ZhemvTest(t, blasser)
+}
+
+func TestZger(t *testing.T) {
+	testblas.ZgerTest(t, blasser)
+}
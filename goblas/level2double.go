@@ -17,9 +17,9 @@ const (
 	badTranspose string = "referenceblas: illegal transpose"
 	badDiag      string = "referenceblas: illegal diag"
 	badSide      string = "referenceblas: illegal side"
-	badLdaRow    string = "lda must be greater than max(1,n) for row major"
-	badLdaCol    string = "lda must be greater than max(1,m) for col major"
-	badLda       string = "lda must be greater than max(1,n)"
+	badLdaRow    string = "goblas: lda must be greater than max(1,n) for row major"
+	badLdaCol    string = "goblas: lda must be greater than max(1,m) for col major"
+	badLda       string = "goblas: lda must be greater than max(1,n)"
 )
 
 func max(a, b int) int {
@@ -36,28 +36,54 @@ func min(a, b int) int {
 	return a
 }
 
-// Dgemv computes y = alpha*a*x + beta*y if tA = blas.NoTrans
-// or alpha*A^T*x + beta*y if tA = blas.Trans or blas.ConjTrans
-func (b Blas) Dgemv(tA blas.Transpose, m, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
-	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic(badTranspose)
-	}
-	if m < 0 {
-		panic(mLT0)
+// checkPacked panics if ap is too short to hold an n×n triangle in packed
+// storage, or if the packed length n*(n+1)/2 overflows int.
+func checkPacked(n int, ap []float64) {
+	if n > 0 && n > maxInt/(n+1) {
+		panic("goblas: n*(n+1)/2 overflows int")
 	}
-	if n < 0 {
-		panic(nLT0)
-	}
-	if lda < max(1, n) {
-		panic(badLdaRow)
+	if len(ap) < n*(n+1)/2 {
+		panic("blas: not enough data in ap")
 	}
+}
 
-	if incX == 0 {
-		panic(zeroInc)
+// axpyInc computes y += alpha*x for the first n elements of x and the n
+// elements of y with increment incY, as Daxpy does with incX == 1, but
+// without the quick return on alpha == 0, so that the strict IEEE mode of
+// the triangular routines can rely on 0*Inf and 0*NaN propagating.
+func axpyInc(n int, alpha float64, x, y []float64, incY int) {
+	var iy int
+	if incY < 0 {
+		iy = (1 - n) * incY
+	}
+	for _, v := range x[:n] {
+		y[iy] += alpha * v
+		iy += incY
 	}
-	if incY == 0 {
-		panic(zeroInc)
+}
+
+// scaleVec computes y := beta * y for the n elements of y with increment
+// incY > 0. If beta is zero, y is set to zero without being read, so NaN or
+// Inf values in y do not propagate.
+func (b Blas) scaleVec(n int, beta float64, y []float64, incY int) {
+	switch beta {
+	case 1:
+	case 0:
+		for iy := 0; iy < n*incY; iy += incY {
+			y[iy] = 0
+		}
+	default:
+		b.Dscal(n, beta, y, incY)
 	}
+}
+
+// Dgemv computes y = alpha*a*x + beta*y if tA = blas.NoTrans
+// or alpha*A^T*x + beta*y if tA = blas.Trans or blas.ConjTrans
+// If beta is zero, y need not be set on input. x and y must not share memory;
+// in debug mode this is checked and Dgemv panics if they do. In debug mode
+// Dgemv also panics if alpha or beta is NaN or infinite.
+func (b Blas) Dgemv(tA blas.Transpose, m, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	b.checkDgemv(tA, m, n, alpha, lda, incX, beta, incY)
 
 	// Quick return if possible
 	if m == 0 || n == 0 || (alpha == 0 && beta == 1) {
@@ -71,6 +97,9 @@ func (b Blas) Dgemv(tA blas.Transpose, m, n int, alpha float64, a []float64, lda
 		lenX = n
 		lenY = m
 	}
+	if b.debug && vecOverlap(lenX, x, incX, lenY, y, incY) {
+		panic(badOverlap)
+	}
 	var kx, ky int
 	if incX > 0 {
 		kx = 0
@@ -85,9 +114,9 @@ func (b Blas) Dgemv(tA blas.Transpose, m, n int, alpha float64, a []float64, lda
 
 	// First form y := beta * y
 	if incY > 0 {
-		b.Dscal(lenY, beta, y, incY)
+		b.scaleVec(lenY, beta, y, incY)
 	} else {
-		b.Dscal(lenY, beta, y, -incY)
+		b.scaleVec(lenY, beta, y, -incY)
 	}
 
 	if alpha == 0 {
@@ -126,14 +155,42 @@ func (b Blas) Dgemv(tA blas.Transpose, m, n int, alpha float64, a []float64, lda
 	}
 }
 
+// checkDgemv panics if the parameters of a call to Dgemv are invalid.
+func (b Blas) checkDgemv(tA blas.Transpose, m, n int, alpha float64, lda, incX int, beta float64, incY int) {
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if lda < max(1, n) {
+		panic(badLdaRow)
+	}
+
+	if incX == 0 {
+		panic(zeroInc)
+	}
+	if incY == 0 {
+		panic(zeroInc)
+	}
+	if b.debug && !finite(alpha, beta) {
+		panic(nonFiniteScalar)
+	}
+}
+
 // Dger   performs the rank 1 operation
 //    A := alpha*x*y**T + A,
 // where alpha is a scalar, x is an m element vector, y is an n element
 // vector and A is an m by n matrix.
-func (Blas) Dger(m, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64, lda int) {
+// If A is large enough, its rows are partitioned among the workers and
+// updated concurrently; no two goroutines update the same element.
+func (bl Blas) Dger(m, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64, lda int) {
 	// Check inputs
 	if m < 0 {
-		panic("m < 0")
+		panic(mLT0)
 	}
 	if n < 0 {
 		panic(negativeN)
@@ -160,39 +217,45 @@ func (Blas) Dger(m, n int, alpha float64, x []float64, incX int, y []float64, in
 		ky = -(n - 1) * incY
 	}
 
-	if incY > 0 {
+	if incX > 0 {
 		kx = 0
 	} else {
 		kx = -(m - 1) * incX
 	}
 
-	ix := kx
-	for i := 0; i < m; i++ {
-		if x[ix] == 0 {
+	bl.parallelRows(m, n, func(i, r int) {
+		ix := kx + i*incX
+		for ; r > 0; i, r = i+1, r-1 {
+			if x[ix] == 0 && !bl.strict {
+				ix += incX
+				continue
+			}
+			tmp := alpha * x[ix]
+			atmp := a[i*lda : i*lda+n]
+			if incY == 1 {
+				for j, v := range y[:n] {
+					atmp[j] += v * tmp
+				}
+			} else {
+				jy := ky
+				for j := range atmp {
+					atmp[j] += y[jy] * tmp
+					jy += incY
+				}
+			}
 			ix += incX
-			continue
-		}
-		tmp := alpha * x[ix]
-		jy := ky
-		for j := 0; j < n; j++ {
-			a[i*lda+j] += y[jy] * tmp
-			jy += incY
 		}
-		ix += incX
-	}
-
+	})
 }
 
-func (b Blas) Dgbmv(tA blas.Transpose, m, n, kL, kU int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
-	// Transform for row major
-	m, n = n, m
-	kU, kL = kL, kU
-	if tA == blas.NoTrans {
-		tA = blas.Trans
-	} else {
-		tA = blas.NoTrans
-	}
-
+// Dgbmv computes y := alpha*A*x + beta*y if tA is blas.NoTrans, or
+// y := alpha*A^T*x + beta*y otherwise, where A is an m×n band matrix with kL
+// sub-diagonals and kU super-diagonals. Row i of the band is stored in
+// a[i*lda : i*lda+kL+kU+1], with element (i, j) at a[i*lda+kL+j-i] for
+// max(0, i-kL) <= j < min(n, i+kU+1); only the elements in that range are
+// read, so the work is proportional to the number of elements in the band
+// rather than to m*n. If beta is zero, y need not be set on input.
+func (bl Blas) Dgbmv(tA blas.Transpose, m, n, kL, kU int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
 		panic(badTranspose)
 	}
@@ -202,10 +265,15 @@ func (b Blas) Dgbmv(tA blas.Transpose, m, n, kL, kU int, alpha float64, a []floa
 	if n < 0 {
 		panic(nLT0)
 	}
-	if lda < max(1, n) {
-		panic(badLdaRow)
+	if kL < 0 {
+		panic("goblas: kl < 0")
+	}
+	if kU < 0 {
+		panic("goblas: ku < 0")
+	}
+	if lda < kL+kU+1 {
+		panic("goblas: lda < kl+ku+1")
 	}
-
 	if incX == 0 {
 		panic(zeroInc)
 	}
@@ -218,94 +286,75 @@ func (b Blas) Dgbmv(tA blas.Transpose, m, n, kL, kU int, alpha float64, a []floa
 		return
 	}
 
-	// Set up indexes
-	lenX := m
-	lenY := n
-	if tA == blas.NoTrans {
-		lenX = n
-		lenY = m
+	lenX, lenY := n, m
+	if tA != blas.NoTrans {
+		lenX, lenY = m, n
 	}
 	var kx, ky int
-	if incX > 0 {
-		kx = 0
-	} else {
+	if incX < 0 {
 		kx = -(lenX - 1) * incX
 	}
-	if incY > 0 {
-		ky = 0
-	} else {
+	if incY < 0 {
 		ky = -(lenY - 1) * incY
 	}
 
 	// First form y := beta * y
 	if incY > 0 {
-		b.Dscal(lenY, beta, y, incY)
+		bl.scaleVec(lenY, beta, y, incY)
 	} else {
-		b.Dscal(lenY, beta, y, -incY)
+		bl.scaleVec(lenY, beta, y, -incY)
 	}
 
 	if alpha == 0 {
 		return
 	}
 
+	// Rows of A that lie below the band, i >= n+kL, are empty.
+	rows := min(m, n+kL)
 	if tA == blas.NoTrans {
-		jx := kx
-		if incY == 1 {
-			for j := 0; j < n; j++ {
-				if x[jx] != 0 {
-					temp := alpha * x[jx]
-					k := kU - j
-					for i := max(0, j-kU); i < min(m, j+kL+1); i++ {
-						y[i] += temp * a[k+i+j*lda]
-					}
-				}
-				jx += incX
-			}
-		} else {
-			for j := 0; j < n; j++ {
-				if x[jx] != 0 {
-					temp := alpha * x[jx]
-					iy := ky
-					k := kU - j
-					for i := max(0, j-kU); i < min(m, j+kL+1); i++ {
-						y[iy] += temp * a[k+i+j*lda]
-						iy += incY
-					}
+		// y_i += alpha * (band of row i) . x[jl:ju]
+		iy := ky
+		for i := 0; i < rows; i++ {
+			jl, ju := max(0, i-kL), min(n, i+kU+1)
+			atmp := a[i*lda+kL+jl-i : i*lda+kL+ju-i]
+			var tmp float64
+			if incX == 1 {
+				for j, v := range atmp {
+					tmp += v * x[jl+j]
 				}
-				jx += incX
-				if j >= kU {
-					ky += incY
+			} else {
+				jx := kx + jl*incX
+				for _, v := range atmp {
+					tmp += v * x[jx]
+					jx += incX
 				}
 			}
+			y[iy] += alpha * tmp
+			iy += incY
 		}
-	} else {
-		jy := ky
-		if incX == 1 {
-			for j := 0; j < n; j++ {
-				temp := 0.0
-				k := kU - j
-				for i := max(0, j-kU); i < min(m, j+kL+1); i++ {
-					temp += a[k+i+j*lda] * x[i]
-				}
-				y[jy] += alpha * temp
-				jy += incY
-			}
-		} else {
-			for j := 0; j < n; j++ {
-				temp := 0.0
-				ix := kx
-				k := kU - j
-				for i := max(0, j-kU); i < min(m, j+kL+1); i++ {
-					temp += a[k+i+j*lda] * x[ix]
-					ix += incX
+		return
+	}
+
+	// y[jl:ju] += alpha * x_i * (band of row i)
+	ix := kx
+	for i := 0; i < rows; i++ {
+		if x[ix] != 0 || bl.strict {
+			tmp := alpha * x[ix]
+			jl, ju := max(0, i-kL), min(n, i+kU+1)
+			atmp := a[i*lda+kL+jl-i : i*lda+kL+ju-i]
+			if incY == 1 {
+				for j, v := range atmp {
+					y[jl+j] += tmp * v
 				}
-				y[jy] += alpha * temp
-				jy += incY
-				if j > kU {
-					kx += incX
+			} else {
+				jy := ky + jl*incY
+				for _, v := range atmp {
+					y[jy] += tmp * v
+					jy += incY
 				}
 			}
 		}
+		ix += incX
 	}
 }
 
@@ -313,7 +362,7 @@ func (b Blas) Dgbmv(tA blas.Transpose, m, n, kL, kU int, alpha float64, a []floa
 // 		x := A*x,   or   x := A**T*x,
 // where x is an n element vector and  A is an n by n unit, or non-unit,
 // upper or lower triangular matrix.
-func (Blas) Dtrmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float64, lda int, x []float64, incX int) {
+func (bl Blas) Dtrmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float64, lda int, x []float64, incX int) {
 	// Verify inputs
 	if tA == blas.NoTrans {
 		tA = blas.Trans
@@ -338,7 +387,7 @@ func (Blas) Dtrmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float
 	if n < 0 {
 		panic(nLT0)
 	}
-	if lda > n && lda > 1 {
+	if lda < max(1, n) {
 		panic(badLda)
 	}
 	if incX == 0 {
@@ -358,7 +407,7 @@ func (Blas) Dtrmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float
 		jx := kx
 		for j := 0; j < n; j++ {
 			ja := j * lda
-			if x[jx] != 0 {
+			if x[jx] != 0 || bl.strict {
 				temp := x[jx]
 				ix := kx
 				for i := 0; i < j; i++ {
@@ -376,7 +425,7 @@ func (Blas) Dtrmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float
 		jx := kx
 		for j := n - 1; j >= 0; j-- {
 			ja := j * lda
-			if x[jx] != 0 {
+			if x[jx] != 0 || bl.strict {
 				tmp := x[jx]
 				ix := kx
 				for i := n - 1; i > j; i-- {
@@ -431,27 +480,8 @@ func (Blas) Dtrmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float
 //
 // No test for singularity or near-singularity is included in this
 // routine. Such tests must be performed before calling this routine.
-func (Blas) Dtrsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float64, lda int, x []float64, incX int) {
-	// Test the input parameters
-	// Verify inputs
-	if ul != blas.Lower && ul != blas.Upper {
-		panic(badUplo)
-	}
-	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic(badTranspose)
-	}
-	if d != blas.NonUnit && d != blas.Unit {
-		panic(badDiag)
-	}
-	if n < 0 {
-		panic(nLT0)
-	}
-	if lda > n && lda > 1 {
-		panic("blas: lda must be less than max(1,n)")
-	}
-	if incX == 0 {
-		panic(zeroInc)
-	}
+func (bl Blas) Dtrsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float64, lda int, x []float64, incX int) {
+	dtrsvCheck(ul, tA, d, n, lda, incX)
 	// Quick return if possible
 	if n == 0 {
 		return
@@ -462,72 +492,99 @@ func (Blas) Dtrsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float
 		kx = -(n - 1) * incX
 	}
 
-	switch {
-	default:
-		panic("goblas: unreachable")
-	case tA == blas.NoTrans && ul == blas.Upper:
-		jx := kx + (n-1)*incX
-		for j := n; j >= 0; j-- {
-			if x[jx] != 0 {
-				if d == blas.NonUnit {
-					x[jx] /= a[lda*j+j]
-				}
-				tmp := x[jx]
-				ix := jx
-				for i := j - 2; i >= 0; i-- {
-					ix -= incX
-					x[ix] -= tmp * a[lda*i+j]
+	if tA == blas.NoTrans {
+		// Each x_i is b_i minus the dot product of row i of A with the
+		// elements of x that are already solved.
+		if ul == blas.Upper {
+			ix := kx + (n-1)*incX
+			for i := n - 1; i >= 0; i-- {
+				tmp := x[ix]
+				jx := ix
+				for _, v := range a[i*lda+i+1 : i*lda+n] {
+					jx += incX
+					tmp -= v * x[jx]
 				}
-			}
-			jx -= incX
-		}
-	case tA == blas.NoTrans && ul == blas.Lower:
-		jx := kx
-		for j := 0; j < n; j++ {
-			if x[jx] != 0 {
 				if d == blas.NonUnit {
-					x[jx] /= a[lda*j+j]
-				}
-				tmp := x[jx]
-				ix := jx
-				for i := j; i < n; j++ {
-					ix += incX
-					x[ix] -= tmp * a[lda*i+j]
+					tmp /= a[i*lda+i]
 				}
+				x[ix] = tmp
+				ix -= incX
 			}
-			jx += incX
+			return
 		}
-	case (tA == blas.Trans || tA == blas.ConjTrans) && ul == blas.Upper:
-		jx := kx
-		for j := 0; j < n; j++ {
-			tmp := x[jx]
-			ix := kx
-			for i := 0; i < j-1; i++ {
-				tmp -= a[lda*i+j] * x[ix]
-				ix += incX
+		ix := kx
+		for i := 0; i < n; i++ {
+			tmp := x[ix]
+			jx := kx
+			for _, v := range a[i*lda : i*lda+i] {
+				tmp -= v * x[jx]
+				jx += incX
 			}
 			if d == blas.NonUnit {
-				tmp /= a[lda*j+j]
+				tmp /= a[i*lda+i]
 			}
-			x[jx] = tmp
-			jx += incX
+			x[ix] = tmp
+			ix += incX
 		}
-	case (tA == blas.Trans || tA == blas.ConjTrans) && ul == blas.Lower:
-		kx += (n - 1) * incX
-		jx := kx
-		for j := n - 1; j >= 0; j-- {
-			tmp := x[jx]
-			ix := kx
-			for i := n - 1; i >= j; i-- {
-				tmp -= a[lda*i+j] * x[ix]
-				ix -= incX
-			}
+		return
+	}
+
+	// Form x := inv(A^T) * x. Once x_i is solved, row i of A is used to
+	// eliminate it from the remaining equations.
+	if ul == blas.Upper {
+		ix := kx
+		for i := 0; i < n; i++ {
 			if d == blas.NonUnit {
-				tmp /= a[lda*j+j]
-				x[jx] = tmp
-				jx -= incX
+				x[ix] /= a[i*lda+i]
+			}
+			tmp := x[ix]
+			if tmp != 0 || bl.strict {
+				jx := ix
+				for _, v := range a[i*lda+i+1 : i*lda+n] {
+					jx += incX
+					x[jx] -= tmp * v
+				}
+			}
+			ix += incX
+		}
+		return
+	}
+	ix := kx + (n-1)*incX
+	for i := n - 1; i >= 0; i-- {
+		if d == blas.NonUnit {
+			x[ix] /= a[i*lda+i]
+		}
+		tmp := x[ix]
+		if tmp != 0 || bl.strict {
+			jx := kx
+			for _, v := range a[i*lda : i*lda+i] {
+				x[jx] -= tmp * v
+				jx += incX
 			}
 		}
+		ix -= incX
+	}
+}
+
+// dtrsvCheck panics if the parameters of a call to Dtrsv are invalid.
+func dtrsvCheck(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, lda, incX int) {
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if d != blas.NonUnit && d != blas.Unit {
+		panic(badDiag)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if lda < max(1, n) {
+		panic(badLda)
+	}
+	if incX == 0 {
+		panic(zeroInc)
 	}
 }
 
@@ -535,6 +592,12 @@ func (Blas) Dtrsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float
 //    y := alpha*A*x + beta*y,
 // where alpha and beta are scalars, x and y are n element vectors and
 // A is an n by n symmetric matrix.
+// As everywhere in this package A is stored row-major, and only the triangle
+// given by ul is referenced. The upper triangle of a column-major matrix with
+// leading dimension lda occupies the same elements as the lower triangle of
+// a row-major one with stride lda, and vice versa, so a symmetric matrix
+// stored by a column-major library is passed with ul swapped. As A is
+// symmetric, the result is the same.
 func (b Blas) Dsymv(ul blas.Uplo, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
 	// Check inputs
 	if ul != blas.Lower && ul != blas.Upper {
@@ -543,7 +606,7 @@ func (b Blas) Dsymv(ul blas.Uplo, n int, alpha float64, a []float64, lda int, x
 	if n < 0 {
 		panic(negativeN)
 	}
-	if lda > 1 && lda > n {
+	if lda < max(1, n) {
 		panic(badLda)
 	}
 	if incX == 0 {
@@ -560,68 +623,67 @@ func (b Blas) Dsymv(ul blas.Uplo, n int, alpha float64, a []float64, lda int, x
 	// Set up start points
 	var kx, ky int
 	if incX > 0 {
-		kx = 1
+		kx = 0
 	} else {
 		kx = -(n - 1) * incX
 	}
 	if incY > 0 {
-		ky = 1
+		ky = 0
 	} else {
 		ky = -(n - 1) * incY
 	}
 
 	// Form y = beta * y
-	if beta != 1 {
-		b.Dscal(n, beta, y, incY)
+	if incY > 0 {
+		b.scaleVec(n, beta, y, incY)
+	} else {
+		b.scaleVec(n, beta, y, -incY)
 	}
 
 	if alpha == 0 {
 		return
 	}
 
-	// TODO: Need to think about changing the major and minor
-	// looping when row major (help with cache misses)
-
-	// Form y = Ax + y
+	// Form y = Ax + y. Row i of the stored triangle contributes A[i][j]*x_j
+	// to y_i and, by symmetry, A[i][j]*x_i to y_j for each off-diagonal j.
 	switch {
 	default:
 		panic("goblas: unreachable")
 	case ul == blas.Upper:
-		jx := kx
-		jy := ky
-		for j := 0; j < n; j++ {
-			tmp1 := alpha * x[jx]
+		ix := kx
+		iy := ky
+		for i := 0; i < n; i++ {
+			tmp1 := alpha * x[ix]
 			var tmp2 float64
-			ix := kx
-			iy := ky
-			for i := 0; i < j-2; i++ {
-				y[iy] += tmp1 * a[i*lda+j]
-				tmp2 += a[i*lda+j] * x[ix]
-				ix += incX
-				iy += incY
+			jx := ix
+			jy := iy
+			for j := i + 1; j < n; j++ {
+				jx += incX
+				jy += incY
+				y[jy] += tmp1 * a[i*lda+j]
+				tmp2 += a[i*lda+j] * x[jx]
 			}
-			y[jy] += tmp1*a[j*lda+j] + alpha*tmp2
-			jx += incX
-			jy += incY
+			y[iy] += tmp1*a[i*lda+i] + alpha*tmp2
+			ix += incX
+			iy += incY
 		}
 	case ul == blas.Lower:
-		jx := kx
-		jy := ky
-		for j := 0; j < n; j++ {
-			tmp1 := alpha * x[jx]
+		ix := kx
+		iy := ky
+		for i := 0; i < n; i++ {
+			tmp1 := alpha * x[ix]
 			var tmp2 float64
-			y[jy] += tmp1 * a[j*lda+j]
-			ix := jx
-			iy := jy
-			for i := j; i < n; i++ {
-				ix += incX
-				iy += incY
-				y[iy] += tmp1 * a[i*lda+j]
-				tmp2 += a[i*lda+j] * x[ix]
+			jx := kx
+			jy := ky
+			for j := 0; j < i; j++ {
+				y[jy] += tmp1 * a[i*lda+j]
+				tmp2 += a[i*lda+j] * x[jx]
+				jx += incX
+				jy += incY
 			}
-			y[jy] += alpha * tmp2
-			jx += incX
-			jy += incY
+			y[iy] += tmp1*a[i*lda+i] + alpha*tmp2
+			ix += incX
+			iy += incY
 		}
 	}
 }
@@ -630,7 +692,7 @@ func (b Blas) Dsymv(ul blas.Uplo, n int, alpha float64, a []float64, lda int, x
 // 		x := A*x,   or   x := A**T*x,
 // where x is an n element vector and  A is an n by n unit, or non-unit,
 // upper or lower triangular band matrix.
-func (Blas) Dtbmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k int, a []float64, lda int, x []float64, incX int) {
+func (bl Blas) Dtbmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k int, a []float64, lda int, x []float64, incX int) {
 	// Verify inputs
 	// Transform for row major
 	if tA == blas.NoTrans {
@@ -679,7 +741,7 @@ func (Blas) Dtbmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k int, a []fl
 		if ul == blas.Upper {
 			if incX == 1 {
 				for j := 0; j < n; j++ {
-					if x[j] != 0 {
+					if x[j] != 0 || bl.strict {
 						temp := x[j]
 						l := k - j
 						for i := max(0, j-k); i < j; i++ {
@@ -693,7 +755,7 @@ func (Blas) Dtbmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k int, a []fl
 			} else {
 				jx := kx
 				for j := 0; j < n; j++ {
-					if x[jx] != 0 {
+					if x[jx] != 0 || bl.strict {
 						temp := x[jx]
 						ix := kx
 						l := k - j
@@ -715,7 +777,7 @@ func (Blas) Dtbmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k int, a []fl
 
 			if incX == 1 {
 				for j := n - 1; j >= 0; j-- {
-					if x[j] != 0 {
+					if x[j] != 0 || bl.strict {
 						temp := x[j]
 						l := -j
 						for i := min(n-1, j+k); i >= j+1; i-- {
@@ -730,7 +792,7 @@ func (Blas) Dtbmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k int, a []fl
 				kx += (n - 1) * incX
 				jx := kx
 				for j := n - 1; j >= 0; j-- {
-					if x[jx] != 0 {
+					if x[jx] != 0 || bl.strict {
 						temp := x[jx]
 						ix := kx
 						l := -j
@@ -845,9 +907,7 @@ func (bl Blas) Dtpmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []f
 	if n < 0 {
 		panic(nLT0)
 	}
-	if len(ap) < (n*(n+1))/2 {
-		panic("blas: not enough data in ap")
-	}
+	checkPacked(n, ap)
 	if incX == 0 {
 		panic(zeroInc)
 	}
@@ -868,10 +928,10 @@ func (bl Blas) Dtpmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []f
 			kk := 0
 			jx := kx
 			for j := 0; j < n; j++ {
-				if x[jx] != 0 {
+				if x[jx] != 0 || bl.strict {
 					if j > 0 {
 						offset := max(0, -(n-j)*incX)
-						bl.Daxpy(j, x[jx], ap[kk:], 1, x[offset:], incX)
+						axpyInc(j, x[jx], ap[kk:], x[offset:], incX)
 					}
 					if d == blas.NonUnit {
 						x[jx] *= ap[kk+j]
@@ -884,10 +944,10 @@ func (bl Blas) Dtpmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []f
 			kk := (n*(n+1))/2 - 1
 			jx := kx + (n-1)*incX
 			for j := n - 1; j >= 0; j-- {
-				if x[jx] != 0 {
+				if x[jx] != 0 || bl.strict {
 					if j+1 < n {
 						offset := max((j+1)*incX, 0)
-						bl.Daxpy(n-j-1, x[jx], ap[kk-n+j+2:], 1, x[offset:], incX)
+						axpyInc(n-j-1, x[jx], ap[kk-n+j+2:], x[offset:], incX)
 					}
 					if d == blas.NonUnit {
 						x[jx] *= ap[kk-n+j+1]
@@ -937,19 +997,253 @@ func (bl Blas) Dtpmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []f
 	}
 }
 
-//TODO: Not yet implemented Level 2 routines.
-func (Blas) Dtbsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k int, a []float64, lda int, x []float64, incX int) {
-	panic("referenceblas: function not implemented")
+// Dtbsv  solves one of the systems of equations
+//
+//	A*x = b,   or   A**T*x = b,
+//
+// where b and x are n element vectors and A is an n by n unit, or
+// non-unit, upper or lower triangular band matrix, with ( k + 1 )
+// diagonals.
+//
+// The band storage is row-major. If ul == blas.Upper, element (i, j) with
+// i <= j <= i+k is stored at a[i*lda+j-i], so the diagonal is the first
+// element of each row. If ul == blas.Lower, element (i, j) with
+// i-k <= j <= i is stored at a[i*lda+k+j-i], so the diagonal is the last.
+//
+// No test for singularity or near-singularity is included in this
+// routine. Such tests must be performed before calling this routine.
+func (bl Blas) Dtbsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k int, a []float64, lda int, x []float64, incX int) {
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if d != blas.NonUnit && d != blas.Unit {
+		panic(badDiag)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if k < 0 {
+		panic(kLT0)
+	}
+	if lda < k+1 {
+		panic(badLda)
+	}
+	if incX == 0 {
+		panic(zeroInc)
+	}
+	if n == 0 {
+		return
+	}
+
+	var kx int
+	if incX < 0 {
+		kx = -(n - 1) * incX
+	}
+
+	if tA == blas.NoTrans {
+		if ul == blas.Upper {
+			ix := kx + (n-1)*incX
+			for i := n - 1; i >= 0; i-- {
+				tmp := x[ix]
+				jx := ix
+				for _, v := range a[i*lda+1 : i*lda+min(k, n-1-i)+1] {
+					jx += incX
+					tmp -= v * x[jx]
+				}
+				if d == blas.NonUnit {
+					tmp /= a[i*lda]
+				}
+				x[ix] = tmp
+				ix -= incX
+			}
+			return
+		}
+		ix := kx
+		for i := 0; i < n; i++ {
+			tmp := x[ix]
+			jl := max(0, i-k)
+			jx := kx + jl*incX
+			for _, v := range a[i*lda+k+jl-i : i*lda+k] {
+				tmp -= v * x[jx]
+				jx += incX
+			}
+			if d == blas.NonUnit {
+				tmp /= a[i*lda+k]
+			}
+			x[ix] = tmp
+			ix += incX
+		}
+		return
+	}
+
+	// Form x := inv(A^T) * x. Once x_i is solved, row i of A is used to
+	// eliminate it from the remaining equations.
+	if ul == blas.Upper {
+		ix := kx
+		for i := 0; i < n; i++ {
+			if d == blas.NonUnit {
+				x[ix] /= a[i*lda]
+			}
+			tmp := x[ix]
+			if tmp != 0 || bl.strict {
+				jx := ix
+				for _, v := range a[i*lda+1 : i*lda+min(k, n-1-i)+1] {
+					jx += incX
+					x[jx] -= tmp * v
+				}
+			}
+			ix += incX
+		}
+		return
+	}
+	ix := kx + (n-1)*incX
+	for i := n - 1; i >= 0; i-- {
+		if d == blas.NonUnit {
+			x[ix] /= a[i*lda+k]
+		}
+		tmp := x[ix]
+		if tmp != 0 || bl.strict {
+			jl := max(0, i-k)
+			jx := kx + jl*incX
+			for _, v := range a[i*lda+k+jl-i : i*lda+k] {
+				x[jx] -= tmp * v
+				jx += incX
+			}
+		}
+		ix -= incX
+	}
 }
-func (Blas) Dtpsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []float64, x []float64, incX int) {
-	panic("referenceblas: function not implemented")
+
+// Dtpsv  solves one of the systems of equations
+//
+//	A*x = b,   or   A**T*x = b,
+//
+// where b and x are n element vectors and A is an n by n unit, or
+// non-unit, upper or lower triangular matrix, supplied in packed form.
+//
+// The packed storage is row-major, as for Dtpmv. If ul == blas.Upper, ap
+// holds the upper triangle row by row, so row i starts at
+// ap[i*n - i*(i-1)/2] with the diagonal element. If ul == blas.Lower, ap
+// holds the lower triangle row by row, so row i starts at ap[i*(i+1)/2] and
+// ends with the diagonal element.
+//
+// No test for singularity or near-singularity is included in this
+// routine. Such tests must be performed before calling this routine.
+func (bl Blas) Dtpsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []float64, x []float64, incX int) {
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if d != blas.NonUnit && d != blas.Unit {
+		panic(badDiag)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	checkPacked(n, ap)
+	if incX == 0 {
+		panic(zeroInc)
+	}
+	if n == 0 {
+		return
+	}
+
+	var kx int
+	if incX < 0 {
+		kx = -(n - 1) * incX
+	}
+
+	if tA == blas.NoTrans {
+		if ul == blas.Upper {
+			// kk is the start of row i, which holds columns i through n-1.
+			kk := (n*(n+1))/2 - 1
+			ix := kx + (n-1)*incX
+			for i := n - 1; i >= 0; i-- {
+				tmp := x[ix]
+				jx := ix
+				for _, v := range ap[kk+1 : kk+n-i] {
+					jx += incX
+					tmp -= v * x[jx]
+				}
+				if d == blas.NonUnit {
+					tmp /= ap[kk]
+				}
+				x[ix] = tmp
+				ix -= incX
+				kk -= n - i + 1
+			}
+			return
+		}
+		// kk is the start of row i, which holds columns 0 through i.
+		var kk int
+		ix := kx
+		for i := 0; i < n; i++ {
+			tmp := x[ix]
+			jx := kx
+			for _, v := range ap[kk : kk+i] {
+				tmp -= v * x[jx]
+				jx += incX
+			}
+			if d == blas.NonUnit {
+				tmp /= ap[kk+i]
+			}
+			x[ix] = tmp
+			ix += incX
+			kk += i + 1
+		}
+		return
+	}
+
+	// Form x := inv(A^T) * x. Once x_i is solved, row i of A is used to
+	// eliminate it from the remaining equations.
+	if ul == blas.Upper {
+		var kk int
+		ix := kx
+		for i := 0; i < n; i++ {
+			if d == blas.NonUnit {
+				x[ix] /= ap[kk]
+			}
+			tmp := x[ix]
+			if tmp != 0 || bl.strict {
+				jx := ix
+				for _, v := range ap[kk+1 : kk+n-i] {
+					jx += incX
+					x[jx] -= tmp * v
+				}
+			}
+			ix += incX
+			kk += n - i
+		}
+		return
+	}
+	kk := (n*(n+1))/2 - n
+	ix := kx + (n-1)*incX
+	for i := n - 1; i >= 0; i-- {
+		if d == blas.NonUnit {
+			x[ix] /= ap[kk+i]
+		}
+		tmp := x[ix]
+		if tmp != 0 || bl.strict {
+			jx := kx
+			for _, v := range ap[kk : kk+i] {
+				x[jx] -= tmp * v
+				jx += incX
+			}
+		}
+		ix -= incX
+		kk -= i
+	}
 }
+
+//TODO: Not yet implemented Level 2 routines.
 func (Blas) Dsbmv(ul blas.Uplo, n, k int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
 	panic("referenceblas: function not implemented")
 }
-func (Blas) Dspmv(ul blas.Uplo, n int, alpha float64, ap []float64, x []float64, incX int, beta float64, y []float64, incY int) {
-	panic("referenceblas: function not implemented")
-}
 func (Blas) Dspr(ul blas.Uplo, n int, alpha float64, x []float64, incX int, ap []float64) {
 	panic("referenceblas: function not implemented")
 }
@@ -962,3 +1256,98 @@ func (Blas) Dsyr(ul blas.Uplo, n int, alpha float64, x []float64, incX int, a []
 func (Blas) Dsyr2(ul blas.Uplo, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64, lda int) {
 	panic("referenceblas: function not implemented")
 }
+
+// Dspmv performs the matrix-vector operation
+//    y := alpha*A*x + beta*y,
+// where alpha and beta are scalars, x and y are n element vectors and
+// A is an n by n symmetric matrix, supplied in packed form.
+//
+// The packed storage is row-major. If ul == blas.Upper, ap holds the upper
+// triangle row by row, so element (i, j) with j >= i is stored at
+// ap[i*n - i*(i-1)/2 + j - i]. If ul == blas.Lower, ap holds the lower
+// triangle row by row, so element (i, j) with j <= i is stored at
+// ap[i*(i+1)/2 + j]. Column-major packed storage of one triangle is the same
+// as row-major packed storage of the other triangle.
+func (b Blas) Dspmv(ul blas.Uplo, n int, alpha float64, ap []float64, x []float64, incX int, beta float64, y []float64, incY int) {
+	// Check inputs
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	checkPacked(n, ap)
+	if incX == 0 {
+		panic(zeroInc)
+	}
+	if incY == 0 {
+		panic(zeroInc)
+	}
+	// Quick return if possible
+	if n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+
+	// Set up start points
+	var kx, ky int
+	if incX < 0 {
+		kx = -(n - 1) * incX
+	}
+	if incY < 0 {
+		ky = -(n - 1) * incY
+	}
+
+	// Form y = beta * y
+	if incY > 0 {
+		b.scaleVec(n, beta, y, incY)
+	} else {
+		b.scaleVec(n, beta, y, -incY)
+	}
+
+	if alpha == 0 {
+		return
+	}
+
+	// Form y = alpha * A * x + y. Each stored element a_ij with i != j
+	// contributes to both y_i and y_j.
+	kk := 0
+	ix := kx
+	iy := ky
+	if ul == blas.Upper {
+		for i := 0; i < n; i++ {
+			tmp1 := alpha * x[ix]
+			tmp2 := ap[kk] * x[ix]
+			jx := ix
+			jy := iy
+			for j := i + 1; j < n; j++ {
+				jx += incX
+				jy += incY
+				v := ap[kk+j-i]
+				tmp2 += v * x[jx]
+				y[jy] += tmp1 * v
+			}
+			y[iy] += alpha * tmp2
+			ix += incX
+			iy += incY
+			kk += n - i
+		}
+		return
+	}
+	for i := 0; i < n; i++ {
+		tmp1 := alpha * x[ix]
+		var tmp2 float64
+		jx := kx
+		jy := ky
+		for j := 0; j < i; j++ {
+			v := ap[kk+j]
+			tmp2 += v * x[jx]
+			y[jy] += tmp1 * v
+			jx += incX
+			jy += incY
+		}
+		y[iy] += tmp1*ap[kk+i] + alpha*tmp2
+		ix += incX
+		iy += incY
+		kk += i + 1
+	}
+}
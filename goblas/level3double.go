@@ -4,27 +4,19 @@ import "github.com/gonum/blas"
 
 var _ blas.Float64Level3 = Blasser
 
+// Dtrsm solves one of the matrix equations
+//
+//	op(A)*X = alpha*B,   or   X*op(A) = alpha*B,
+//
+// where alpha is a scalar, X and B are m by n matrices, A is a unit, or
+// non-unit, upper or lower triangular matrix and op(A) is A or A**T. The
+// matrix X is overwritten on B.
+//
+// The right-hand sides are independent, so for large problems they are
+// partitioned among the workers: the columns of B for a Left solve and the
+// rows of B for a Right solve. The substitution along the triangular
+// dimension is serial within each worker.
 func (bl Blas) Dtrsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha float64, a []float64, lda int, b []float64, ldb int) {
-	// Transform to row major
-	if ul == blas.Upper {
-		ul = blas.Lower
-	} else {
-		ul = blas.Upper
-	}
-	if s == blas.Left {
-		s = blas.Right
-	} else {
-		s = blas.Left
-	}
-	m, n = n, m
-
-	var nrowa int
-	if s == blas.Left {
-		nrowa = m
-	} else {
-		nrowa = n
-	}
-
 	if s != blas.Left && s != blas.Right {
 		panic(badSide)
 	}
@@ -43,10 +35,14 @@ func (bl Blas) Dtrsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag,
 	if n < 0 {
 		panic(nLT0)
 	}
-	if lda < nrowa {
+	k := n
+	if s == blas.Left {
+		k = m
+	}
+	if lda < max(1, k) {
 		panic(badLda)
 	}
-	if ldb < m {
+	if ldb < max(1, n) {
 		panic(badLda)
 	}
 
@@ -55,106 +51,232 @@ func (bl Blas) Dtrsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag,
 	}
 
 	if alpha == 0 {
-		for j := 0; j < n; j++ {
-			jb := ldb * j
-			for i := 0; i < m; i++ {
-				b[i+jb] = 0
+		for i := 0; i < m; i++ {
+			row := b[i*ldb : i*ldb+n]
+			for j := range row {
+				row[j] = 0
 			}
 		}
 		return
 	}
 
-	if s == blas.Left {
-		for j := 0; j < n; j++ {
-			jb := j * ldb
-			if alpha != 1 {
-				bl.Dscal(m, alpha, b[jb:], 1)
-			}
-			bl.Dtrsv(ul, tA, d, m, a, lda, b[jb:], 1)
+	if s == blas.Right {
+		// Each row x of X solves x*op(A) = alpha*b, that is
+		// op(A)**T * x**T = alpha*b**T.
+		tx := blas.Trans
+		if tA != blas.NoTrans {
+			tx = blas.NoTrans
 		}
-	} else {
-		if tA == blas.NoTrans {
+		bl.parallelRows(m, n*n, func(i0, r int) {
+			for i := i0; i < i0+r; i++ {
+				row := b[i*ldb : i*ldb+n]
+				if alpha != 1 {
+					bl.Dscal(n, alpha, row, 1)
+				}
+				bl.Dtrsv(ul, tx, d, n, a, lda, row, 1)
+			}
+		})
+		return
+	}
 
-			//B := alpha*B*inv( A )
-			if ul == blas.Upper {
-				for j := 0; j < n; j++ {
-					jb := j * ldb
-					ja := j * lda
-					if alpha != 1 {
-						bl.Dscal(m, alpha, b[jb:], 1)
-					}
-					for k := 0; k < j; k++ {
-						if a[k+ja] != 0 {
-							bl.Daxpy(m, -a[k+ja], b[k*ldb:], 1, b[jb:], 1)
-						}
-					}
-					if d == blas.NonUnit {
-						bl.Dscal(m, 1/a[j+ja], b[jb:], 1)
+	bl.parallelRows(n, m*m, func(j0, r int) {
+		bl.dtrsmLeft(ul, tA, d, m, r, alpha, a, lda, b[j0:], ldb)
+	})
+}
+
+// dtrsmLeft computes B := alpha * inv(op(A)) * B for the m×n matrix B
+// serially, operating on whole rows of B so that the inner loops are
+// contiguous.
+func (bl Blas) dtrsmLeft(ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha float64, a []float64, lda int, b []float64, ldb int) {
+	if alpha != 1 {
+		for i := 0; i < m; i++ {
+			bl.Dscal(n, alpha, b[i*ldb:], 1)
+		}
+	}
+	strict := bl.strict
+	if tA == blas.NoTrans {
+		if ul == blas.Upper {
+			for i := m - 1; i >= 0; i-- {
+				bi := b[i*ldb : i*ldb+n]
+				for k := i + 1; k < m; k++ {
+					if tmp := a[i*lda+k]; tmp != 0 || strict {
+						bl.Daxpy(n, -tmp, b[k*ldb:], 1, bi, 1)
 					}
 				}
-			} else {
-				for j := n - 1; j >= 0; j-- {
-					jb := j * ldb
-					ja := j * lda
-					if alpha != 1 {
-						bl.Dscal(m, alpha, b[jb:], 1)
-					}
-					for k := j + 1; k < n; k++ {
-						if a[k+ja] != 0 {
-							bl.Daxpy(m, -a[k+ja], b[k*ldb:], 1, b[jb:], 1)
-						}
-					}
-					if d == blas.NonUnit {
-						bl.Dscal(m, 1/a[j+ja], b[jb:], 1)
-					}
+				if d == blas.NonUnit {
+					bl.Dscal(n, 1/a[i*lda+i], bi, 1)
 				}
 			}
-		} else {
-
-			//B := alpha*B*inv( A**T )
-			if ul == blas.Upper {
-				for k := n - 1; k >= 0; k-- {
-					ka := k * lda
-					kb := k * ldb
-					if d == blas.NonUnit {
-						bl.Dscal(m, 1/a[k+ka], b[kb:], 1)
-					}
-					for j := 0; j < k; j++ {
-						if a[j+ka] != 0 {
-							bl.Daxpy(m, a[j+ka], b[kb:], 1, b[j*ldb:], 1)
-						}
-					}
-					if alpha != 1 {
-						bl.Dscal(m, alpha, b[kb:], 1)
-					}
+			return
+		}
+		for i := 0; i < m; i++ {
+			bi := b[i*ldb : i*ldb+n]
+			for k := 0; k < i; k++ {
+				if tmp := a[i*lda+k]; tmp != 0 || strict {
+					bl.Daxpy(n, -tmp, b[k*ldb:], 1, bi, 1)
 				}
-			} else {
-				for k := 0; k < n; k++ {
-					ka := k * lda
-					kb := k * ldb
-					if d == blas.NonUnit {
-						bl.Dscal(m, 1/a[k+ka], b[kb:], 1)
-					}
-					for j := k + 1; j < n; j++ {
-						if a[j+ka] != 0 {
-							bl.Daxpy(m, a[j+ka], b[kb:], 1, b[j*ldb:], 1)
-						}
-					}
-					if alpha != 1 {
-						bl.Dscal(m, alpha, b[kb:], 1)
-					}
+			}
+			if d == blas.NonUnit {
+				bl.Dscal(n, 1/a[i*lda+i], bi, 1)
+			}
+		}
+		return
+	}
+	// Form inv(A**T)*B. Row i of X is final once the rows it depends on have
+	// been eliminated, and is then eliminated from the remaining rows.
+	if ul == blas.Upper {
+		for i := 0; i < m; i++ {
+			bi := b[i*ldb : i*ldb+n]
+			if d == blas.NonUnit {
+				bl.Dscal(n, 1/a[i*lda+i], bi, 1)
+			}
+			for k := i + 1; k < m; k++ {
+				if tmp := a[i*lda+k]; tmp != 0 || strict {
+					bl.Daxpy(n, -tmp, bi, 1, b[k*ldb:], 1)
 				}
 			}
 		}
+		return
+	}
+	for i := m - 1; i >= 0; i-- {
+		bi := b[i*ldb : i*ldb+n]
+		if d == blas.NonUnit {
+			bl.Dscal(n, 1/a[i*lda+i], bi, 1)
+		}
+		for k := 0; k < i; k++ {
+			if tmp := a[i*lda+k]; tmp != 0 || strict {
+				bl.Daxpy(n, -tmp, bi, 1, b[k*ldb:], 1)
+			}
+		}
 	}
 }
 
 func (Blas) Dsymm(s blas.Side, ul blas.Uplo, m, n int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
 	panic("blas: function not implemented")
 }
-func (Blas) Dsyrk(ul blas.Uplo, t blas.Transpose, n, k int, alpha float64, a []float64, lda int, beta float64, c []float64, ldc int) {
-	panic("blas: function not implemented")
+
+// Dsyrk performs one of the symmetric rank k operations
+//
+//	C := alpha*A*A**T + beta*C,   or   C := alpha*A**T*A + beta*C,
+//
+// where alpha and beta are scalars, C is an n by n symmetric matrix and A is
+// an n by k matrix in the first case and a k by n matrix in the second case.
+// Only the triangle of C given by ul is referenced and updated. If beta is
+// zero, C need not be set on input.
+//
+// For large problems the blocks of the triangle of C are computed
+// concurrently. In debug mode, a downdate with alpha < 0 panics if it leaves a diagonal
+// element of C that was positive, after scaling by beta, non-positive.
+func (bl Blas) Dsyrk(ul blas.Uplo, t blas.Transpose, n, k int, alpha float64, a []float64, lda int, beta float64, c []float64, ldc int) {
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if t != blas.NoTrans && t != blas.Trans && t != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if k < 0 {
+		panic(kLT0)
+	}
+	rowA, colA := n, k
+	if t != blas.NoTrans {
+		rowA, colA = k, n
+	}
+	if lda < max(1, colA) {
+		panic(badLda)
+	}
+	if ldc < max(1, n) {
+		panic(badLda)
+	}
+	for _, g := range []general{
+		{data: a, rows: rowA, cols: colA, stride: lda},
+		{data: c, rows: n, cols: n, stride: ldc},
+	} {
+		g.mustCheck()
+	}
+	if n == 0 || ((alpha == 0 || k == 0) && beta == 1) {
+		return
+	}
+	var before []bool
+	if bl.debug && alpha < 0 && k > 0 {
+		before = positiveDiag(n, beta, c, ldc)
+	}
+
+	// syrk updates the elements of rows [i0, i1) and columns [j0, j1) of C
+	// that lie in its triangle.
+	syrk := func(i0, i1, j0, j1 int) {
+		for i := i0; i < i1; i++ {
+			jl, ju := max(i, j0), j1
+			if ul == blas.Lower {
+				jl, ju = j0, min(i+1, j1)
+			}
+			if jl >= ju {
+				continue
+			}
+			ctmp := c[i*ldc+jl : i*ldc+ju]
+			if beta == 0 {
+				for j := range ctmp {
+					ctmp[j] = 0
+				}
+			} else if beta != 1 {
+				for j := range ctmp {
+					ctmp[j] *= beta
+				}
+			}
+			if alpha == 0 || k == 0 {
+				continue
+			}
+			if t == blas.NoTrans {
+				// C[i][j] += alpha * (row i of A) . (row j of A)
+				atmp := a[i*lda : i*lda+k]
+				for j := range ctmp {
+					var sum float64
+					for l, v := range a[(jl+j)*lda : (jl+j)*lda+k] {
+						sum += atmp[l] * v
+					}
+					ctmp[j] += alpha * sum
+				}
+				continue
+			}
+			// Row i of C gains alpha*A[l][i] times row l of A for each l.
+			for l := 0; l < k; l++ {
+				tmp := alpha * a[l*lda+i]
+				if tmp == 0 && !bl.strict {
+					continue
+				}
+				for j, v := range a[l*lda+jl : l*lda+ju] {
+					ctmp[j] += tmp * v
+				}
+			}
+		}
+	}
+
+	if n*n*(k+1) < minParScale || bl.workers() < 2 {
+		syrk(0, n, 0, n)
+	} else {
+		// The rows of the triangle shrink from n elements to one, so equal
+		// ranges of rows would not be equal work. Only the blocks of the
+		// triangle are sent to the workers, which balance them as they
+		// take them from runBlocks.
+		bs := bl.dgemmBlockSize(n, n)
+		nb := numBlocks(n, bs)
+		bl.runBlocks(nb*(nb+1)/2, func(send func(subMul)) {
+			bl.order.blocks(n, n, bs, func(i, j int) {
+				if (ul == blas.Upper && j >= i) || (ul == blas.Lower && j <= i) {
+					send(subMul{i: i, j: j})
+				}
+			})
+		}, func(sub subMul) {
+			syrk(sub.i, min(sub.i+bs, n), sub.j, min(sub.j+bs, n))
+		})
+	}
+	if before != nil {
+		checkDowndate("Dsyrk", before, c, ldc)
+	}
 }
+
 func (Blas) Dsyr2k(ul blas.Uplo, t blas.Transpose, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
 	panic("blas: function not implemented")
 }
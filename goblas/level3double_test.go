@@ -1,11 +1,184 @@
 package goblas
 
 import (
+	"math"
 	"testing"
 
+	"github.com/gonum/blas"
 	"github.com/gonum/blas/testblas"
 )
 
 func TestDgemm(t *testing.T) {
 	testblas.TestDgemm(t, blasser)
 }
+
+func TestDsyrk(t *testing.T) {
+	testblas.DsyrkTest(t, blasser)
+}
+
+// sentinel fills the parts of a matrix that a routine must not modify.
+const sentinel = -123.25
+
+// poisonTriangle sets the strict triangle of the n×n matrix g opposite to ul,
+// and the padding of each row, to v.
+func poisonTriangle(g general, n int, ul blas.Uplo, v float64) {
+	for i := 0; i < n; i++ {
+		for j := 0; j < g.stride; j++ {
+			if j >= n || (ul == blas.Upper && j < i) || (ul == blas.Lower && j > i) {
+				g.data[i*g.stride+j] = v
+			}
+		}
+	}
+}
+
+// checkTriangle reports the first element of the n×n matrix g outside the
+// triangle ul, or in the row padding, that is not the sentinel.
+func checkTriangle(g general, n int, ul blas.Uplo) (i, j int, ok bool) {
+	for i := 0; i < n; i++ {
+		for j := 0; j < g.stride; j++ {
+			if j >= n || (ul == blas.Upper && j < i) || (ul == blas.Lower && j > i) {
+				if g.data[i*g.stride+j] != sentinel {
+					return i, j, false
+				}
+			}
+		}
+	}
+	return 0, 0, true
+}
+
+// TestDsyrkTriangle checks that Dsyrk scales and writes only the triangle of
+// C given by ul, so that the other triangle may hold unrelated data.
+func TestDsyrkTriangle(t *testing.T) {
+	for _, test := range []struct {
+		n, k int
+	}{
+		{1, 1},
+		{4, 3},
+		{7, 0},
+		{40, 40}, // Large enough for the blocks to be computed concurrently.
+	} {
+		for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				for _, sc := range []struct{ alpha, beta float64 }{{1, 0}, {2, 0.5}, {0, 3}, {-1, 1}} {
+					n, k := test.n, test.k
+					a, tB := randmat(n, k, k+1), blas.Trans
+					if tA == blas.Trans {
+						a, tB = randmat(k, n, n+1), blas.NoTrans
+					}
+					c := randmat(n, n, n+2)
+					poisonTriangle(c, n, ul, sentinel)
+					want := c.clone()
+					Blasser.DgemmReference(tA, tB, n, n, k, sc.alpha, a.data, a.stride, a.data, a.stride, sc.beta, want.data, want.stride)
+
+					Blasser.Dsyrk(ul, tA, n, k, sc.alpha, a.data, a.stride, sc.beta, c.data, c.stride)
+					if i, j, ok := checkTriangle(c, n, ul); !ok {
+						t.Errorf("n = %v, k = %v, ul = %c, tA = %c, %+v: C[%v][%v] = %v outside the triangle was modified", n, k, ul, tA, sc, i, j, c.data[i*c.stride+j])
+					}
+					for i := 0; i < n; i++ {
+						for j := 0; j < n; j++ {
+							if (ul == blas.Upper && j < i) || (ul == blas.Lower && j > i) {
+								continue
+							}
+							if math.Abs(c.at(i, j)-want.at(i, j)) > 1e-12 {
+								t.Errorf("n = %v, k = %v, ul = %c, tA = %c, %+v: C[%v][%v] = %v, want %v", n, k, ul, tA, sc, i, j, c.at(i, j), want.at(i, j))
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestDsyrkParallel checks that computing the blocks of the triangle of C
+// concurrently gives exactly the serial result, including for a partial
+// last block.
+func TestDsyrkParallel(t *testing.T) {
+	const n, k = 45, 40
+	for _, order := range []BlockOrder{RowMajorBlocks, DiagonalBlocks} {
+		bl := New(WithBlockSize(8), WithBlockOrder(order))
+		bl.nWorkers = 4
+		serial := New(WithMaxWorkers(1))
+		for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				a := randmat(n, k, k)
+				if tA == blas.Trans {
+					a = randmat(k, n, n)
+				}
+				c := randmat(n, n, n)
+				want := c.clone()
+				serial.Dsyrk(ul, tA, n, k, 1.5, a.data, a.stride, 0.5, want.data, want.stride)
+				bl.Dsyrk(ul, tA, n, k, 1.5, a.data, a.stride, 0.5, c.data, c.stride)
+				for i, v := range c.data {
+					if v != want.data[i] {
+						t.Errorf("order = %v, ul = %c, tA = %c: C[%v][%v] = %v, want %v", order, ul, tA, i/n, i%n, v, want.data[i])
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestDsyr2kTriangle checks that Dsyr2k leaves the triangle of C opposite
+// to ul untouched. It is skipped while Dsyr2k is a stub.
+func TestDsyr2kTriangle(t *testing.T) {
+	if !Blasser.Implemented("Dsyr2k") {
+		t.Skip("Dsyr2k is not implemented")
+	}
+	for _, n := range []int{1, 4, 40} {
+		k := 3
+		for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				a, b := randmat(n, k, k), randmat(n, k, k)
+				if tA == blas.Trans {
+					a, b = randmat(k, n, n), randmat(k, n, n)
+				}
+				c := randmat(n, n, n+2)
+				poisonTriangle(c, n, ul, sentinel)
+				Blasser.Dsyr2k(ul, tA, n, k, 2, a.data, a.stride, b.data, b.stride, 0.5, c.data, c.stride)
+				if i, j, ok := checkTriangle(c, n, ul); !ok {
+					t.Errorf("n = %v, ul = %c, tA = %c: C[%v][%v] = %v outside the triangle was modified", n, ul, tA, i, j, c.data[i*c.stride+j])
+				}
+			}
+		}
+	}
+}
+
+// TestDsymmTriangle checks that Dsymm does not read the triangle of A
+// opposite to ul. C is a general matrix and is written in full. It is
+// skipped while Dsymm is a stub.
+func TestDsymmTriangle(t *testing.T) {
+	if !Blasser.Implemented("Dsymm") {
+		t.Skip("Dsymm is not implemented")
+	}
+	m, n := 5, 4
+	for _, s := range []blas.Side{blas.Left, blas.Right} {
+		for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+			na := m
+			if s == blas.Right {
+				na = n
+			}
+			full := randmat(na, na, na)
+			for i := 0; i < na; i++ {
+				for j := 0; j < i; j++ {
+					full.data[i*na+j] = full.data[j*na+i]
+				}
+			}
+			a := full.clone()
+			poisonTriangle(a, na, ul, math.NaN())
+			b := randmat(m, n, n)
+			c := randmat(m, n, n)
+			want := c.clone()
+			if s == blas.Left {
+				Blasser.DgemmReference(blas.NoTrans, blas.NoTrans, m, n, m, 2, full.data, na, b.data, n, 0.5, want.data, n)
+			} else {
+				Blasser.DgemmReference(blas.NoTrans, blas.NoTrans, m, n, n, 2, b.data, n, full.data, na, 0.5, want.data, n)
+			}
+			Blasser.Dsymm(s, ul, m, n, 2, a.data, na, b.data, n, 0.5, c.data, n)
+			if !generalEqualWithinAbs(c, want, 1e-12) {
+				t.Errorf("side = %c, ul = %c: C mismatch, the triangle of A opposite to ul may have been read", s, ul)
+			}
+		}
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"os"
+	"runtime"
+	"strconv"
+)
+
+// forceSerial is read once from the GOBLAS_SERIAL environment variable when
+// the package is initialized. See the package documentation.
+var forceSerial = parseSerial(os.Getenv("GOBLAS_SERIAL"))
+
+// parseSerial reports whether the value of GOBLAS_SERIAL requests serial
+// computation. Any value accepted by strconv.ParseBool as true does; an
+// empty or unrecognized value does not.
+func parseSerial(s string) bool {
+	b, err := strconv.ParseBool(s)
+	return err == nil && b
+}
+
+// Option configures a Blas returned by New.
+type Option func(*Blas)
+
+// New returns a Blas configured by opts. Options that are not given keep
+// the defaults of the zero value Blas. Each Blas holds its own configuration,
+// so differently configured values may be used concurrently.
+func New(opts ...Option) Blas {
+	var bl Blas
+	for _, opt := range opts {
+		opt(&bl)
+	}
+	return bl
+}
+
+// WithBlockSize sets the size of the square sub-blocks into which the
+// Level 3 routines partition their matrices. By default Dgemm chooses the
+// block size from the dimensions of C and the number of workers, starting
+// from 64, or from the size found by the autotuner described in the package
+// documentation if it is enabled, and using smaller blocks when
+// there would otherwise be too few to keep all workers busy. Setting the
+// block size also skips the autotuner for calls on this Blas.
+func WithBlockSize(bs int) Option {
+	if bs < 1 {
+		panic("goblas: block size < 1")
+	}
+	return func(bl *Blas) {
+		bl.bs = bs
+	}
+}
+
+// WithMaxWorkers limits the number of worker goroutines used by a single
+// call. The default is runtime.GOMAXPROCS(0).
+func WithMaxWorkers(n int) Option {
+	if n < 1 {
+		panic("goblas: max workers < 1")
+	}
+	return func(bl *Blas) {
+		bl.maxWorkers = n
+	}
+}
+
+// WithBlockOrder sets the order in which the blocks of C are handed to the
+// workers by Dgemm. The default is RowMajorBlocks.
+func WithBlockOrder(o BlockOrder) Option {
+	if o != RowMajorBlocks && o != DiagonalBlocks {
+		panic("goblas: unknown block order")
+	}
+	return func(bl *Blas) {
+		bl.order = o
+	}
+}
+
+// WithDispatchBuffer sets the number of blocks per worker that the blocked
+// Level 3 routines buffer in the channel from which the workers take their
+// blocks. The default is 4. A larger buffer lets the dispatcher run further
+// ahead of the workers, which helps when there are many small blocks that
+// each take little time to compute; a smaller one holds fewer blocks in
+// memory at a time. The buffer never holds more blocks than a call has.
+func WithDispatchBuffer(perWorker int) Option {
+	if perWorker < 1 {
+		panic("goblas: dispatch buffer < 1")
+	}
+	return func(bl *Blas) {
+		bl.dispatch = perWorker
+	}
+}
+
+// WithDgemmStrategy sets the algorithm Dgemm uses to partition the
+// multiplication. The default is TiledDgemm. With RecursiveDgemm, a block
+// size set by WithBlockSize is the largest dimension of a base case.
+func WithDgemmStrategy(s DgemmStrategy) Option {
+	if s != TiledDgemm && s != RecursiveDgemm {
+		panic("goblas: unknown Dgemm strategy")
+	}
+	return func(bl *Blas) {
+		bl.strategy = s
+	}
+}
+
+// WithReduceStrategy sets how the partial sums of the workers are added when
+// a routine splits its inner dimension among them, as DgemvWork does with A
+// transposed. The default is ReduceSequential, the fastest; ReducePairwise
+// and ReduceCompensated are more accurate when the partial sums cancel.
+func WithReduceStrategy(r ReduceStrategy) Option {
+	if r != ReduceSequential && r != ReducePairwise && r != ReduceCompensated {
+		panic("goblas: unknown reduce strategy")
+	}
+	return func(bl *Blas) {
+		bl.reduce = r
+	}
+}
+
+// WithStrictIEEE disables the short-circuits that skip the multiplication by
+// an element that is zero. By default, for speed, the following updates are
+// skipped, so that an Inf or NaN they would have multiplied does not
+// propagate into the result. A zero is any value that compares equal to
+// zero, including -0 and a scaled element that underflows to zero.
+//   - Dgemm: the update of a row of C with a row or column of B when the
+//     corresponding element of alpha*A is zero (all transpose cases except
+//     A not transposed and B transposed, which always uses dot products);
+//   - Dsyrk with A transposed: the update of a row of C with a row of A when
+//     the corresponding element of alpha*A is zero;
+//   - Dgbmv with A transposed: the update of y with row i of the band when
+//     x_i is zero;
+//   - Dger, DgerSym, DgerBatch, Zgeru and Zgerc: the update of row i of A
+//     when x_i, or alpha*x_i for DgerBatch, is zero;
+//   - Dtrmv, Dtbmv and Dtpmv with A transposed: the update of x with
+//     the elements of A that multiply x_j when x_j is zero;
+//   - Dtrsv, Dtbsv and Dtpsv with A transposed: the elimination of x_i from
+//     the remaining equations when the solved x_i is zero;
+//   - Dtrsm: with A on the left, the elimination with a row of B when the
+//     multiplying element of A is zero, and with A on the right and not
+//     transposed, the skips of Dtrsv above for each row of B;
+//   - Dtrtri: the update of a row of the inverse with a row of the inverse
+//     below it, or above it if A is lower triangular, when the multiplying
+//     element of A is zero.
+//
+// With strict set, these operations compute 0*Inf = NaN as IEEE 754
+// requires, matching a BLAS without the short-circuits. Dgemv never
+// short-circuits. Quick returns on alpha == 0, as specified by the BLAS
+// standard, are not affected.
+func WithStrictIEEE(strict bool) Option {
+	return func(bl *Blas) {
+		bl.strict = strict
+	}
+}
+
+// WithDebug enables additional internal consistency checks, such as
+// verifying the dimensions of every sub-block multiplication and that
+// every sub-matrix view taken by Dgemm lies within its parent. It also
+// validates arguments that are otherwise trusted: Dgemv checks that x and y
+// do not overlap, and Dgemm, DgemmTo, DgemmTransC and Dgemv panic with
+// "blas: non-finite scalar" if alpha or beta is NaN or infinite, rather than
+// silently filling the result with NaN. Dsyrk and DgerSym panic if a
+// downdate with alpha < 0 makes a positive diagonal element non-positive.
+// This is slower and intended for debugging only.
+func WithDebug(debug bool) Option {
+	return func(bl *Blas) {
+		bl.debug = debug
+	}
+}
+
+// workers returns the maximum number of workers for a call. It is one if
+// GOBLAS_SERIAL is set.
+func (bl Blas) workers() int {
+	if forceSerial {
+		return 1
+	}
+	if bl.nWorkers != 0 {
+		return bl.nWorkers
+	}
+	n := runtime.GOMAXPROCS(0)
+	if bl.maxWorkers != 0 && bl.maxWorkers < n {
+		n = bl.maxWorkers
+	}
+	return n
+}
@@ -0,0 +1,278 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestNew(t *testing.T) {
+	if New() != (Blas{}) {
+		t.Errorf("New without options differs from the zero value")
+	}
+	bl := New(WithBlockSize(16), WithMaxWorkers(3), WithDebug(true))
+	if bs := bl.dgemmBlockSize(1000, 1000); bs != 16 {
+		t.Errorf("block size mismatch. Want 16, got %v", bs)
+	}
+	if bl.workers() > 3 {
+		t.Errorf("workers exceeds the maximum. Want <= 3, got %v", bl.workers())
+	}
+	if !bl.debug {
+		t.Errorf("debug not set")
+	}
+	if bs := Blasser.dgemmBlockSize(1000, 1000); bs != blockSize {
+		t.Errorf("default block size mismatch. Want %v, got %v", blockSize, bs)
+	}
+	if !panics(func() { WithBlockSize(0) }) {
+		t.Errorf("Expected panic for block size 0")
+	}
+	if !panics(func() { WithMaxWorkers(0) }) {
+		t.Errorf("Expected panic for zero workers")
+	}
+	if !panics(func() { WithDispatchBuffer(0) }) {
+		t.Errorf("Expected panic for zero dispatch buffer")
+	}
+}
+
+func TestDgemmOptions(t *testing.T) {
+	const m, n, k = 70, 45, 33
+	for _, bl := range []Blas{
+		New(WithBlockSize(1)),
+		New(WithBlockSize(7), WithMaxWorkers(1)),
+		New(WithBlockSize(16), WithDebug(true)),
+		New(WithBlockSize(200)),
+		New(WithBlockSize(8), WithBlockOrder(DiagonalBlocks)),
+		New(WithBlockSize(4), WithMaxWorkers(4), WithDispatchBuffer(1)),
+		New(WithBlockSize(4), WithMaxWorkers(4), WithDispatchBuffer(1000)),
+	} {
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, tB := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				rowA, colA := m, k
+				if tA == blas.Trans {
+					rowA, colA = colA, rowA
+				}
+				rowB, colB := k, n
+				if tB == blas.Trans {
+					rowB, colB = colB, rowB
+				}
+				a := randmat(rowA, colA, colA)
+				b := randmat(rowB, colB, colB)
+				c := randmat(m, n, n)
+				want := c.clone()
+				bl.Dgemm(tA, tB, m, n, k, 1.5, a.data, a.stride, b.data, b.stride, 0.5, c.data, c.stride)
+				Blasser.Dgemm(tA, tB, m, n, k, 1.5, a.data, a.stride, b.data, b.stride, 0.5, want.data, want.stride)
+				if !c.equalWithinAbs(want, 1e-12) {
+					t.Errorf("Answer mismatch for block size %v, tA = %v, tB = %v", bl.bs, tA, tB)
+				}
+			}
+		}
+	}
+	_, parBlocks := New(WithBlockSize(8)).DgemmWork(blas.NoTrans, blas.NoTrans, 16, 24, 5)
+	if parBlocks != 6 {
+		t.Errorf("parBlocks mismatch for block size 8. Want 6, got %v", parBlocks)
+	}
+}
+
+func TestStrictIEEE(t *testing.T) {
+	inf := math.Inf(1)
+	strict := New(WithStrictIEEE(true))
+	for _, test := range []struct {
+		name string
+		f    func(bl Blas) []float64
+	}{
+		{"Dgemm", func(bl Blas) []float64 {
+			// A = [0 1], B = [Inf 1; 1 1].
+			c := []float64{0, 0}
+			bl.Dgemm(blas.NoTrans, blas.NoTrans, 1, 2, 2, 1, []float64{0, 1}, 2, []float64{inf, 1, 1, 1}, 2, 0, c, 2)
+			return c
+		}},
+		{"DgemmTransTrans", func(bl Blas) []float64 {
+			c := []float64{0, 0}
+			bl.Dgemm(blas.Trans, blas.Trans, 1, 2, 2, 1, []float64{0, 1}, 1, []float64{inf, 1, 1, 1}, 2, 0, c, 2)
+			return c
+		}},
+		{"DgemmUnderflow", func(bl Blas) []float64 {
+			// alpha*A[0][0] underflows to zero, which is skipped like a zero
+			// element of A in every transpose case that short-circuits.
+			var c []float64
+			for _, t := range []struct {
+				tA, tB blas.Transpose
+				lda    int
+			}{{blas.NoTrans, blas.NoTrans, 2}, {blas.Trans, blas.NoTrans, 1}, {blas.Trans, blas.Trans, 1}} {
+				ct := []float64{0, 0}
+				bl.Dgemm(t.tA, t.tB, 1, 2, 2, 1e-200, []float64{1e-200, 1}, t.lda, []float64{inf, 1, 1, 1}, 2, 0, ct, 2)
+				c = append(c, ct...)
+			}
+			return c
+		}},
+		{"DgemmNegativeZero", func(bl Blas) []float64 {
+			c := []float64{0, 0}
+			bl.Dgemm(blas.Trans, blas.NoTrans, 1, 2, 2, -1, []float64{math.Copysign(0, -1), 1}, 1, []float64{inf, 1, 1, 1}, 2, 0, c, 2)
+			return c
+		}},
+		{"Dsyrk", func(bl Blas) []float64 {
+			c := []float64{0, 0, 0, 0}
+			bl.Dsyrk(blas.Upper, blas.Trans, 2, 2, 1, []float64{0, inf, 1, 1}, 2, 0, c, 2)
+			return c
+		}},
+		{"Dgbmv", func(bl Blas) []float64 {
+			// Row 0 of the band holds A[0][0] = 1 and A[0][1] = Inf.
+			y := []float64{0, 0}
+			bl.Dgbmv(blas.Trans, 2, 2, 1, 1, 1, []float64{0, 1, inf, 1, 1, 0}, 3, []float64{0, 1}, 1, 0, y, 1)
+			return y
+		}},
+		{"Dger", func(bl Blas) []float64 {
+			a := []float64{1, 1, 1, 1}
+			bl.Dger(2, 2, 1, []float64{0, 1}, 1, []float64{inf, 1}, 1, a, 2)
+			return a
+		}},
+		{"DgerBatch", func(bl Blas) []float64 {
+			a := []float64{1, 1, 1, 1}
+			bl.DgerBatch(2, 2, 1, [][]float64{{0, 1}}, [][]float64{{inf, 1}}, a, 2)
+			return a
+		}},
+		{"DtrmvUpper", func(bl Blas) []float64 {
+			x := []float64{0, 1}
+			bl.Dtrmv(blas.Upper, blas.Trans, blas.Unit, 2, []float64{1, inf, 0, 1}, 2, x, 1)
+			return x
+		}},
+		{"DtrmvLower", func(bl Blas) []float64 {
+			x := []float64{1, 0}
+			bl.Dtrmv(blas.Lower, blas.Trans, blas.Unit, 2, []float64{1, 0, inf, 1}, 2, x, 1)
+			return x
+		}},
+		{"DtbmvUpper", func(bl Blas) []float64 {
+			x := []float64{0, 1}
+			bl.Dtbmv(blas.Upper, blas.Trans, blas.Unit, 2, 1, []float64{1, inf, 1, 0}, 2, x, 1)
+			return x
+		}},
+		{"DtbmvUpperInc", func(bl Blas) []float64 {
+			x := []float64{0, 0, 1}
+			bl.Dtbmv(blas.Upper, blas.Trans, blas.Unit, 2, 1, []float64{1, inf, 1, 0}, 2, x, 2)
+			return x
+		}},
+		{"DtbmvLower", func(bl Blas) []float64 {
+			x := []float64{1, 0}
+			bl.Dtbmv(blas.Lower, blas.Trans, blas.Unit, 2, 1, []float64{0, 1, inf, 1}, 2, x, 1)
+			return x
+		}},
+		{"DtbmvLowerInc", func(bl Blas) []float64 {
+			x := []float64{1, 0, 0}
+			bl.Dtbmv(blas.Lower, blas.Trans, blas.Unit, 2, 1, []float64{0, 1, inf, 1}, 2, x, 2)
+			return x
+		}},
+		{"DtpmvUpper", func(bl Blas) []float64 {
+			x := []float64{0, 1}
+			bl.Dtpmv(blas.Upper, blas.Trans, blas.Unit, 2, []float64{1, inf, 1}, x, 1)
+			return x
+		}},
+		{"DtpmvLower", func(bl Blas) []float64 {
+			x := []float64{1, 0}
+			bl.Dtpmv(blas.Lower, blas.Trans, blas.Unit, 2, []float64{1, inf, 1}, x, 1)
+			return x
+		}},
+		{"Dtrsv", func(bl Blas) []float64 {
+			x := []float64{0, 1}
+			bl.Dtrsv(blas.Upper, blas.Trans, blas.Unit, 2, []float64{1, inf, 0, 1}, 2, x, 1)
+			return x
+		}},
+	} {
+		if hasNaN(test.f(Blasser)) {
+			t.Errorf("%v: unexpected NaN with the zero short-circuit", test.name)
+		}
+		if !hasNaN(test.f(strict)) {
+			t.Errorf("%v: expected NaN in strict mode", test.name)
+		}
+	}
+}
+
+func hasNaN(s []float64) bool {
+	for _, v := range s {
+		if math.IsNaN(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestForceSerial(t *testing.T) {
+	for _, test := range []struct {
+		s    string
+		want bool
+	}{
+		{"", false},
+		{"0", false},
+		{"false", false},
+		{"yes", false},
+		{"1", true},
+		{"true", true},
+		{"TRUE", true},
+	} {
+		if got := parseSerial(test.s); got != test.want {
+			t.Errorf("GOBLAS_SERIAL=%q: want %v, got %v", test.s, test.want, got)
+		}
+	}
+
+	defer func(v bool) { forceSerial = v }(forceSerial)
+	forceSerial = true
+	bl := New(WithMaxWorkers(4), WithStats(true))
+	if w := bl.workers(); w != 1 {
+		t.Errorf("forced serial: %v workers", w)
+	}
+	const n = 200
+	a := randmat(n, n, n)
+	c := randmat(n, n, n)
+	want := c.clone()
+	Blasser.DgemmReference(blas.NoTrans, blas.NoTrans, n, n, n, 1, a.data, n, a.data, n, 1, want.data, n)
+	bl.Dgemm(blas.NoTrans, blas.NoTrans, n, n, n, 1, a.data, n, a.data, n, 1, c.data, n)
+	if bl.LastStats().Parallel {
+		t.Errorf("forced serial: Dgemm took the parallel path")
+	}
+	if !generalEqualWithinAbs(c, want, 1e-10) {
+		t.Errorf("forced serial: Dgemm result mismatch")
+	}
+}
+
+func TestDebugNonFiniteScalar(t *testing.T) {
+	a := []float64{1, 2, 3, 4}
+	x := []float64{1, 1}
+	for _, test := range []struct {
+		alpha, beta float64
+	}{
+		{math.NaN(), 1},
+		{1, math.Inf(1)},
+		{math.Inf(-1), 0},
+	} {
+		c := make([]float64, 4)
+		y := make([]float64, 2)
+		calls := map[string]func(bl Blas){
+			"Dgemm": func(bl Blas) {
+				bl.Dgemm(blas.NoTrans, blas.NoTrans, 2, 2, 2, test.alpha, a, 2, a, 2, test.beta, c, 2)
+			},
+			"DgemmTo": func(bl Blas) {
+				bl.DgemmTo(c, 2, blas.NoTrans, blas.NoTrans, 2, 2, 2, test.alpha, a, 2, a, 2, test.beta, c, 2)
+			},
+			"DgemmTransC": func(bl Blas) {
+				bl.DgemmTransC(blas.NoTrans, blas.NoTrans, 2, 2, 2, test.alpha, a, 2, a, 2, test.beta, c, 2)
+			},
+			"Dgemv": func(bl Blas) {
+				bl.Dgemv(blas.NoTrans, 2, 2, test.alpha, a, 2, x, 1, test.beta, y, 1)
+			},
+		}
+		for name, f := range calls {
+			if msg := panicMessage(func() { f(New(WithDebug(true))) }); msg != nonFiniteScalar {
+				t.Errorf("%s with alpha = %v, beta = %v in debug mode: got panic %q, want %q", name, test.alpha, test.beta, msg, nonFiniteScalar)
+			}
+			if panics(func() { f(New()) }) {
+				t.Errorf("%s with alpha = %v, beta = %v panicked without debug mode", name, test.alpha, test.beta)
+			}
+		}
+	}
+	// Finite scalars are accepted.
+	New(WithDebug(true)).Dgemm(blas.NoTrans, blas.NoTrans, 2, 2, 2, 1, a, 2, a, 2, 0, make([]float64, 4), 2)
+}
@@ -0,0 +1,40 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import "unsafe"
+
+const badOverlap = "goblas: x and y overlap"
+
+// vecOverlap returns whether any element of the vector x of nx elements with
+// increment incX shares memory with an element of the vector y of ny elements
+// with increment incY. Vectors that interleave, such as two columns of the
+// same matrix, do not overlap. The check takes O(nx) time and is intended for
+// debug mode.
+func vecOverlap(nx int, x []float64, incX int, ny int, y []float64, incY int) bool {
+	if nx <= 0 || ny <= 0 {
+		return false
+	}
+	if incX < 0 {
+		incX = -incX
+	}
+	if incY < 0 {
+		incY = -incY
+	}
+	const size = int(unsafe.Sizeof(float64(0)))
+	// d is the offset of y[0] from x[0] in elements. x references offsets
+	// i*incX and y references offsets d+j*incY.
+	d := int(uintptr(unsafe.Pointer(&y[0]))-uintptr(unsafe.Pointer(&x[0]))) / size
+	for i := 0; i < nx; i++ {
+		off := i*incX - d
+		if off < 0 {
+			continue
+		}
+		if off%incY == 0 && off/incY < ny {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestVecOverlap(t *testing.T) {
+	s := make([]float64, 20)
+	for i, test := range []struct {
+		nx         int
+		x          []float64
+		incX       int
+		ny         int
+		y          []float64
+		incY       int
+		wantResult bool
+	}{
+		{5, s, 1, 5, s, 1, true},
+		{5, s, 1, 5, s[5:], 1, false},
+		{5, s, 1, 5, s[4:], 1, true},
+		{5, s[4:], 1, 5, s, 1, true},
+		{5, s, 2, 5, s[1:], 2, false},
+		{5, s, 2, 5, s[2:], 2, true},
+		{5, s, 2, 3, s[1:], 3, true},
+		{5, s, -2, 5, s[1:], -2, false},
+		{5, s, 1, 5, make([]float64, 5), 1, false},
+		{0, s, 1, 5, s, 1, false},
+	} {
+		got := vecOverlap(test.nx, test.x, test.incX, test.ny, test.y, test.incY)
+		if got != test.wantResult {
+			t.Errorf("Case %v: overlap mismatch. Want %v, got %v", i, test.wantResult, got)
+		}
+	}
+}
+
+func TestDgemvOverlap(t *testing.T) {
+	bl := New(WithDebug(true))
+	a := []float64{1, 2, 3, 4}
+	x := []float64{1, 1, 1, 1}
+	if !panics(func() { bl.Dgemv(blas.NoTrans, 2, 2, 1, a, 2, x, 1, 0, x, 1) }) {
+		t.Errorf("Expected panic for x == y")
+	}
+	if !panics(func() { bl.Dgemv(blas.Trans, 2, 2, 1, a, 2, x, 2, 0, x[2:], 1) }) {
+		t.Errorf("Expected panic for overlapping x and y")
+	}
+	// Interleaved vectors, here two columns of the same matrix, are allowed.
+	bl.Dgemv(blas.NoTrans, 2, 2, 1, a, 2, x, 2, 0, x[1:], 2)
+	if x[1] != 3 || x[3] != 7 {
+		t.Errorf("Answer mismatch for interleaved x and y: %v", x)
+	}
+}
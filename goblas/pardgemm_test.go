@@ -5,7 +5,11 @@
 package goblas
 
 import (
+	"fmt"
+	"math"
 	"math/rand"
+	"runtime"
+	"sync"
 	"testing"
 
 	"github.com/gonum/blas"
@@ -124,6 +128,58 @@ func TestDgemmParallel(t *testing.T) {
 	}
 }
 
+// TestDgemmParallelConcurrent runs many concurrent Dgemm calls with shapes that
+// produce partial edge blocks. It is intended to be run with the race detector.
+func TestDgemmParallelConcurrent(t *testing.T) {
+	if runtime.GOMAXPROCS(0) < 4 {
+		defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	}
+	shapes := []struct{ m, n, k int }{
+		{blockSize*minParBlock + 1, blockSize*2 + 3, blockSize + 5},
+		{blockSize*2 + 7, blockSize*minParBlock - 1, blockSize*2 + 1},
+		{blockSize + 1, blockSize + 1, blockSize*3 + 11},
+		{blockSize*3 - 5, blockSize*3 + 5, 13},
+	}
+	nCalls := 32
+	if testing.Short() {
+		nCalls = 8
+	}
+	var wg sync.WaitGroup
+	errs := make(chan string, nCalls)
+	for call := 0; call < nCalls; call++ {
+		shape := shapes[call%len(shapes)]
+		tA := []blas.Transpose{blas.NoTrans, blas.Trans}[call%2]
+		tB := []blas.Transpose{blas.NoTrans, blas.Trans}[(call/2)%2]
+		rowA, colA := shape.m, shape.k
+		if tA == blas.Trans {
+			rowA, colA = colA, rowA
+		}
+		rowB, colB := shape.k, shape.n
+		if tB == blas.Trans {
+			rowB, colB = colB, rowB
+		}
+		a := randmat(rowA, colA, colA)
+		b := randmat(rowB, colB, colB)
+		c := randmat(shape.m, shape.n, shape.n)
+		want := c.clone()
+		dgemmSerial(tA, tB, a, b, want, 1.5, false)
+
+		wg.Add(1)
+		go func(call int) {
+			defer wg.Done()
+			Blasser.Dgemm(tA, tB, shape.m, shape.n, shape.k, 1.5, a.data, a.stride, b.data, b.stride, 1, c.data, c.stride)
+			if !c.equalWithinAbs(want, 1e-12) {
+				errs <- fmt.Sprintf("Call %v: answer not equal parallel and serial", call)
+			}
+		}(call)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
 func testMatchParallelSerial(t *testing.T, i int, tA, tB blas.Transpose, m, n, k int, alpha float64) {
 	var (
 		rowA, colA int
@@ -151,8 +207,8 @@ func testMatchParallelSerial(t *testing.T, i int, tA, tB blas.Transpose, m, n, k
 	bClone := b.clone()
 	cClone := c.clone()
 
-	dgemmSerial(tA, tB, a, b, cClone, alpha)
-	dgemmParallel(tA, tB, a, b, c, alpha)
+	dgemmSerial(tA, tB, a, b, cClone, alpha, false)
+	Blasser.dgemmParallel(tA, tB, a, b, c, alpha)
 	if !a.equal(aClone) {
 		t.Errorf("Case %v: a changed during call to dgemmParallel", i)
 	}
@@ -176,3 +232,158 @@ func randmat(r, c, stride int) general {
 		stride: stride,
 	}
 }
+
+func TestDgemmScale(t *testing.T) {
+	for i, test := range []struct {
+		m, n, stride int
+	}{
+		{3, 4, 4},
+		{3, 4, 7},
+		{minParScale / 64, 64, 64},
+		{minParScale/64 + 3, 70, 73},
+		{1, minParScale + 5, minParScale + 5},
+	} {
+		c := randmat(test.m, test.n, test.stride)
+		cClone := c.clone()
+		Blasser.dgemmScale(c, 2.5)
+		dgemmScaleSerial(cClone, 2.5)
+		if !c.equal(cClone) {
+			t.Errorf("Case %v: answer not equal parallel and serial", i)
+		}
+	}
+}
+
+func BenchmarkDgemmScaleSerialLg(b *testing.B) {
+	benchmarkDgemmScale(b, 2000, 2000, dgemmScaleSerial)
+}
+
+func BenchmarkDgemmScaleLg(b *testing.B) {
+	benchmarkDgemmScale(b, 2000, 2000, Blasser.dgemmScale)
+}
+
+func BenchmarkDgemmScaleSerialHg(b *testing.B) {
+	benchmarkDgemmScale(b, 5000, 5000, dgemmScaleSerial)
+}
+
+func BenchmarkDgemmScaleHg(b *testing.B) {
+	benchmarkDgemmScale(b, 5000, 5000, Blasser.dgemmScale)
+}
+
+func benchmarkDgemmScale(b *testing.B, m, n int, f func(general, float64)) {
+	c := randmat(m, n, n)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f(c, 1.0000001)
+	}
+}
+
+// The stride benchmarks scale a C whose rows are contiguous, which
+// dgemmScaleSerial scales in a single loop, and one whose stride leaves a
+// gap after each row, which it scales row by row.
+
+func BenchmarkDgemmScaleNarrowContiguous(b *testing.B) { benchmarkDgemmScaleStride(b, 50000, 4, 4) }
+func BenchmarkDgemmScaleNarrowStrided(b *testing.B)    { benchmarkDgemmScaleStride(b, 50000, 4, 5) }
+func BenchmarkDgemmScaleWideContiguous(b *testing.B)   { benchmarkDgemmScaleStride(b, 400, 500, 500) }
+func BenchmarkDgemmScaleWideStrided(b *testing.B)      { benchmarkDgemmScaleStride(b, 400, 500, 501) }
+
+func benchmarkDgemmScaleStride(b *testing.B, m, n, stride int) {
+	c := randmat(m, n, stride)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dgemmScaleSerial(c, -1)
+	}
+}
+
+// flatNotNot is the variant of dgemmSerialNotNot for contiguous a, b and c
+// that walks each matrix with a running offset into its flat data instead of
+// slicing each row by its stride. Unlike scaling, the product has no single
+// flat loop: each row of c is still updated with k rows of b. The NotNot
+// benchmarks compare it with the kernel, which it did not consistently
+// beat, so the kernel has no contiguous path.
+func flatNotNot(a, b, c general, alpha float64) {
+	m, k, n := a.rows, a.cols, b.cols
+	ad, bd, cd := a.data[:m*k], b.data[:k*n], c.data[:m*n]
+	var ci int
+	for ai := 0; ai < m*k; ai += k {
+		ctmp := cd[ci : ci+n]
+		var bi int
+		for _, v := range ad[ai : ai+k] {
+			tmp := alpha * v
+			if tmp != 0 {
+				for j, w := range bd[bi : bi+n] {
+					ctmp[j] += tmp * w
+				}
+			}
+			bi += n
+		}
+		ci += n
+	}
+}
+
+func BenchmarkDgemmNotNotNarrowKernel(b *testing.B) { benchmarkDgemmNotNot(b, 20000, 16, 4, false) }
+func BenchmarkDgemmNotNotNarrowFlat(b *testing.B)   { benchmarkDgemmNotNot(b, 20000, 16, 4, true) }
+func BenchmarkDgemmNotNotCubeKernel(b *testing.B)   { benchmarkDgemmNotNot(b, 200, 200, 200, false) }
+func BenchmarkDgemmNotNotCubeFlat(b *testing.B)     { benchmarkDgemmNotNot(b, 200, 200, 200, true) }
+
+func benchmarkDgemmNotNot(b *testing.B, m, k, n int, flat bool) {
+	amat := randmat(m, k, k)
+	bmat := randmat(k, n, n)
+	cmat := randmat(m, n, n)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if flat {
+			flatNotNot(amat, bmat, cmat, 1.5)
+		} else {
+			dgemmSerialNotNot(amat, bmat, cmat, 1.5, false)
+		}
+	}
+}
+
+// TestFlatNotNot checks that the benchmarked flat variant computes the same
+// product as the kernel, so that the comparison is of like with like.
+func TestFlatNotNot(t *testing.T) {
+	const m, k, n = 7, 5, 3
+	a := randmat(m, k, k)
+	b := randmat(k, n, n)
+	c := randmat(m, n, n)
+	want := c.clone()
+	flatNotNot(a, b, c, 1.5)
+	dgemmSerialNotNot(a, b, want, 1.5, false)
+	if !c.equalWithinAbs(want, 1e-14) {
+		t.Errorf("flat NotNot result mismatch")
+	}
+}
+
+// TestDgemmScaleSerial checks that scaling a contiguous C in a single loop
+// and a strided C by rows give the same elements, and that the gaps between
+// the rows of a strided C are not written.
+func TestDgemmScaleSerial(t *testing.T) {
+	for _, test := range []struct{ m, n, stride int }{
+		{0, 0, 1}, {1, 5, 5}, {1, 5, 8}, {7, 4, 4}, {7, 4, 6},
+	} {
+		for _, beta := range []float64{0, -1.5} {
+			c := randmat(test.m, test.n, test.stride)
+			for i := range c.data {
+				if i%test.stride >= test.n || i >= test.m*test.stride {
+					c.data[i] = math.NaN()
+				}
+			}
+			want := c.clone()
+			for i := 0; i < test.m; i++ {
+				for j := 0; j < test.n; j++ {
+					want.data[i*test.stride+j] *= beta
+					if beta == 0 {
+						want.data[i*test.stride+j] = 0
+					}
+				}
+			}
+			dgemmScaleSerial(c, beta)
+			for i, v := range c.data {
+				if v != want.data[i] && !(math.IsNaN(v) && math.IsNaN(want.data[i])) {
+					t.Errorf("%+v, beta = %v: element %v = %v, want %v", test, beta, i, v, want.data[i])
+					break
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import "math"
+
+// ReduceStrategy is the order in which a routine that splits its inner
+// dimension among the workers sums their partial results. DgemvWork with A
+// transposed does so. The strategies trade speed against rounding error,
+// which matters when the partial sums nearly cancel.
+type ReduceStrategy int
+
+const (
+	// ReduceSequential adds the partial sums from left to right. It is the
+	// fastest, and its error bound grows linearly with the number of
+	// workers.
+	ReduceSequential ReduceStrategy = iota
+	// ReducePairwise adds the partial sums as the leaves of a balanced
+	// binary tree, so the error bound grows with the logarithm of the number
+	// of workers.
+	ReducePairwise
+	// ReduceCompensated adds the partial sums with Kahan-Babuška
+	// (Neumaier) compensated summation, which carries the rounding error of
+	// each addition into the next. Its error bound does not depend on the
+	// number of workers or on the order of the terms, so the partial sums
+	// need not be sorted by magnitude. It is the slowest.
+	ReduceCompensated
+)
+
+// reduce returns the sum of the count elements work[w*stride+j], w = 0, ...,
+// count-1, using the strategy r.
+func (r ReduceStrategy) reduce(work []float64, stride, count, j int) float64 {
+	switch r {
+	case ReduceSequential:
+		var sum float64
+		for w := 0; w < count; w++ {
+			sum += work[w*stride+j]
+		}
+		return sum
+	case ReducePairwise:
+		return pairwiseSum(0, count, 1, func(lo, hi int) float64 {
+			var sum float64
+			for w := lo; w < hi; w++ {
+				sum += work[w*stride+j]
+			}
+			return sum
+		})
+	case ReduceCompensated:
+		var sum, comp float64
+		for w := 0; w < count; w++ {
+			v := work[w*stride+j]
+			t := sum + v
+			if math.Abs(sum) >= math.Abs(v) {
+				comp += (sum - t) + v
+			} else {
+				comp += (v - t) + sum
+			}
+			sum = t
+		}
+		return sum + comp
+	default:
+		panic("goblas: unknown reduce strategy")
+	}
+}
+
+// pairwiseSum returns the sum of the terms lo <= i < hi as the leaves of a
+// balanced binary tree. The range is halved recursively until it holds at
+// most block terms, whose sum is returned by leaf, and the sums of the two
+// halves are added. The rounding error grows as O(block + log n) rather than
+// O(n) for n terms, and since the split points depend only on lo, hi and
+// block, so does the order of the additions. It is shared by DdotPairwise,
+// the dot product kernel of Dgemm and ReducePairwise.
+func pairwiseSum(lo, hi, block int, leaf func(lo, hi int) float64) float64 {
+	if hi-lo <= block {
+		return leaf(lo, hi)
+	}
+	mid := lo + (hi-lo)/2
+	return pairwiseSum(lo, mid, block, leaf) + pairwiseSum(mid, hi, block, leaf)
+}
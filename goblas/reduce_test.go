@@ -0,0 +1,72 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestReduceStrategy(t *testing.T) {
+	// Column 1 of work, with stride 2, holds partial sums that cancel. The
+	// exact sum is 2, but 1e16+1 rounds to 1e16. Column 0 is summed exactly
+	// by every strategy.
+	work := []float64{
+		1, 1e16,
+		2, 1,
+		3, -1e16,
+		4, 1,
+		5, 5,
+	}
+	const count = 4
+	var seq float64
+	for w := 0; w < count; w++ {
+		seq += work[w*2+1]
+	}
+	if got := ReduceSequential.reduce(work, 2, count, 1); got != seq {
+		t.Errorf("sequential: got %v, want %v", got, seq)
+	}
+	pair := (work[1] + work[3]) + (work[5] + work[7])
+	if got := ReducePairwise.reduce(work, 2, count, 1); got != pair {
+		t.Errorf("pairwise: got %v, want %v", got, pair)
+	}
+	if got := ReduceCompensated.reduce(work, 2, count, 1); got != 2 {
+		t.Errorf("compensated: got %v, want 2", got)
+	}
+	for _, r := range []ReduceStrategy{ReduceSequential, ReducePairwise, ReduceCompensated} {
+		if got := r.reduce(work, 2, 0, 0); got != 0 {
+			t.Errorf("strategy %v: empty sum is %v", r, got)
+		}
+		if got := r.reduce(work, 2, 5, 0); got != 15 {
+			t.Errorf("strategy %v: sum of five is %v, want 15", r, got)
+		}
+	}
+
+	// DgemvWork gives the same result with each strategy and several
+	// workers.
+	m, n := minParScale/16, 16
+	a := randmat(m, n, n)
+	x := randSlice(m)
+	y := randSlice(n)
+	want := append([]float64(nil), y...)
+	Blasser.Dgemv(blas.Trans, m, n, 1, a.data, n, x, 1, 0.5, want, 1)
+	for _, r := range []ReduceStrategy{ReduceSequential, ReducePairwise, ReduceCompensated} {
+		bl := New(WithReduceStrategy(r))
+		bl.nWorkers = 5
+		got := append([]float64(nil), y...)
+		bl.DgemvWork(blas.Trans, m, n, 1, a.data, n, x, 1, 0.5, got, 1, make([]float64, bl.DgemvWorkLen(blas.Trans, m, n)))
+		for j := range got {
+			if math.Abs(got[j]-want[j]) > 1e-10 {
+				t.Errorf("strategy %v: y[%v] = %v, want %v", r, j, got[j], want[j])
+			}
+		}
+	}
+
+	if !panics(func() { WithReduceStrategy(3) }) {
+		t.Errorf("no panic for unknown strategy")
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"sync"
+	"time"
+)
+
+// DgemmStats describes a completed call to Dgemm, DgemmTo or DgemmTransC.
+type DgemmStats struct {
+	Elapsed   time.Duration // wall time of the call, excluding parameter checks
+	Flops     int64         // floating point operations of the multiplication, 2*m*n*k, or 0 if it was skipped
+	Parallel  bool          // whether the multiplication was computed concurrently
+	BlockSize int           // block size used to partition C, or the base case size of RecursiveDgemm
+}
+
+// FlopsPerSecond returns the rate of floating point operations of the call,
+// or 0 if no time elapsed.
+func (s DgemmStats) FlopsPerSecond() float64 {
+	if s.Elapsed <= 0 {
+		return 0
+	}
+	return float64(s.Flops) / s.Elapsed.Seconds()
+}
+
+// WithStats enables recording of the statistics returned by LastStats. The
+// recorder is shared by all copies of the returned Blas. When stats are not
+// enabled, the only cost to Dgemm is a nil check.
+func WithStats(enable bool) Option {
+	return func(bl *Blas) {
+		if enable {
+			bl.stats = &statsRecorder{}
+		} else {
+			bl.stats = nil
+		}
+	}
+}
+
+// LastStats returns the statistics of the most recently completed call to
+// Dgemm, DgemmTo or DgemmTransC on bl or a copy of it. If several calls run
+// concurrently, the call that finished last is reported. LastStats returns
+// the zero DgemmStats if bl was not created with WithStats(true) or no call
+// has completed.
+func (bl Blas) LastStats() DgemmStats {
+	if bl.stats == nil {
+		return DgemmStats{}
+	}
+	bl.stats.mu.Lock()
+	defer bl.stats.mu.Unlock()
+	return bl.stats.last
+}
+
+// statsRecorder holds the statistics of the last Dgemm call. It is referenced
+// by pointer so that Blas stays a comparable value type.
+type statsRecorder struct {
+	mu   sync.Mutex
+	last DgemmStats
+}
+
+func (r *statsRecorder) record(s DgemmStats) {
+	r.mu.Lock()
+	r.last = s
+	r.mu.Unlock()
+}
+
+// dgemmStats returns the statistics of a Dgemm call that started at start.
+func (bl Blas) dgemmStats(m, n, k int, alpha float64, start time.Time, parallel bool) DgemmStats {
+	s := DgemmStats{
+		Elapsed:   time.Since(start),
+		Parallel:  parallel,
+		BlockSize: bl.dgemmBlockSize(m, n),
+	}
+	if bl.strategy == RecursiveDgemm {
+		s.BlockSize = bl.recursiveBaseSize()
+	}
+	if alpha != 0 {
+		s.Flops = 2 * int64(m) * int64(n) * int64(k)
+	}
+	return s
+}
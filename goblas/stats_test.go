@@ -0,0 +1,83 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestLastStats(t *testing.T) {
+	if s := Blasser.LastStats(); s != (DgemmStats{}) {
+		t.Errorf("Stats recorded without WithStats: %+v", s)
+	}
+	bl := New(WithStats(true), WithBlockSize(32))
+	if s := bl.LastStats(); s != (DgemmStats{}) {
+		t.Errorf("Stats recorded before any call: %+v", s)
+	}
+
+	for _, test := range []struct {
+		m, n, k  int
+		alpha    float64
+		flops    int64
+		parallel bool
+	}{
+		{3, 4, 5, 1, 120, false},
+		{200, 150, 10, 2, 600000, true},
+		{200, 150, 10, 0, 0, false},
+		{0, 150, 10, 1, 0, false},
+	} {
+		a := randmat(test.m, test.k, test.k)
+		b := randmat(test.k, test.n, test.n)
+		c := randmat(test.m, test.n, test.n)
+		for _, to := range []bool{false, true} {
+			if to {
+				d := randmat(test.m, test.n, test.n)
+				bl.DgemmTo(d.data, d.stride, blas.NoTrans, blas.NoTrans, test.m, test.n, test.k, test.alpha, a.data, a.stride, b.data, b.stride, 1.5, c.data, c.stride)
+			} else {
+				bl.Dgemm(blas.NoTrans, blas.NoTrans, test.m, test.n, test.k, test.alpha, a.data, a.stride, b.data, b.stride, 1.5, c.data, c.stride)
+			}
+			s := bl.LastStats()
+			if s.Flops != test.flops {
+				t.Errorf("m = %v, n = %v, k = %v, alpha = %v, to = %v: flops mismatch. Want %v, got %v", test.m, test.n, test.k, test.alpha, to, test.flops, s.Flops)
+			}
+			// A single worker, for example with GOBLAS_SERIAL set or
+			// GOMAXPROCS=1, computes the blocks serially.
+			if want := test.parallel && bl.workers() > 1; s.Parallel != want {
+				t.Errorf("m = %v, n = %v, k = %v, alpha = %v, to = %v: parallel mismatch. Want %v, got %v", test.m, test.n, test.k, test.alpha, to, want, s.Parallel)
+			}
+			if s.BlockSize != 32 {
+				t.Errorf("m = %v, n = %v, k = %v, alpha = %v, to = %v: block size mismatch. Want 32, got %v", test.m, test.n, test.k, test.alpha, to, s.BlockSize)
+			}
+			if s.Elapsed < 0 {
+				t.Errorf("m = %v, n = %v, k = %v, alpha = %v, to = %v: negative elapsed time", test.m, test.n, test.k, test.alpha, to)
+			}
+		}
+	}
+
+	// Copies share the recorder, and concurrent calls are safe.
+	cp := bl
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			a := randmat(10, 10, 10)
+			c := randmat(10, 10, 10)
+			cp.Dgemm(blas.NoTrans, blas.NoTrans, 10, 10, 10, 1, a.data, a.stride, a.data, a.stride, 0, c.data, c.stride)
+			cp.LastStats()
+		}()
+	}
+	wg.Wait()
+	if s := bl.LastStats(); s.Flops != 2000 {
+		t.Errorf("Stats not shared between copies: %+v", s)
+	}
+
+	if s := New(WithStats(true), WithStats(false)); s != (Blas{}) {
+		t.Errorf("WithStats(false) did not disable stats")
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import "math/cmplx"
+
+const (
+	badLdbConj = "goblas: ldb must be at least max(1, m)"
+	shortA     = "goblas: not enough data in a"
+	shortB     = "goblas: not enough data in b"
+)
+
+// Zgeconj computes the conjugate transpose
+//
+//	B := A^H,
+//
+// where A is an m×n matrix with stride lda and B is an n×m matrix with stride
+// ldb. A and B must not overlap; use ZgeconjSquare to conjugate transpose a
+// square matrix in place.
+func (Blas) Zgeconj(m, n int, a []complex128, lda int, b []complex128, ldb int) {
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if lda < max(1, n) {
+		panic(badLdaRow)
+	}
+	if ldb < max(1, m) {
+		panic(badLdbConj)
+	}
+	if m == 0 || n == 0 {
+		return
+	}
+	if len(a) < (m-1)*lda+n {
+		panic(shortA)
+	}
+	if len(b) < (n-1)*ldb+m {
+		panic(shortB)
+	}
+	// Write B a row at a time, so that the strided accesses are reads.
+	for j := 0; j < n; j++ {
+		btmp := b[j*ldb : j*ldb+m]
+		for i := range btmp {
+			btmp[i] = cmplx.Conj(a[i*lda+j])
+		}
+	}
+}
+
+// ZgeconjSquare computes A := A^H in place for the n×n matrix A with stride
+// lda, swapping each pair of elements A[i][j] and A[j][i] and conjugating
+// both, and conjugating the diagonal.
+func (Blas) ZgeconjSquare(n int, a []complex128, lda int) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if lda < max(1, n) {
+		panic(badLdaRow)
+	}
+	if n == 0 {
+		return
+	}
+	if len(a) < (n-1)*lda+n {
+		panic(shortA)
+	}
+	for i := 0; i < n; i++ {
+		a[i*lda+i] = cmplx.Conj(a[i*lda+i])
+		for j := i + 1; j < n; j++ {
+			a[i*lda+j], a[j*lda+i] = cmplx.Conj(a[j*lda+i]), cmplx.Conj(a[i*lda+j])
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math/cmplx"
+	"math/rand"
+	"testing"
+)
+
+func TestZgeconj(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, lda, ldb int
+	}{
+		{0, 3, 3, 1},
+		{3, 0, 1, 3},
+		{1, 1, 1, 1},
+		{3, 4, 4, 3},
+		{5, 2, 6, 7},
+	} {
+		m, n := test.m, test.n
+		a := randComplex(rnd, m, n, test.lda)
+		b := randComplex(rnd, n, m, test.ldb)
+		bCopy := append([]complex128(nil), b...)
+		Blasser.Zgeconj(m, n, a, test.lda, b, test.ldb)
+		for j := 0; j < n; j++ {
+			for i := 0; i < test.ldb && j*test.ldb+i < len(b); i++ {
+				want := bCopy[j*test.ldb+i]
+				if i < m {
+					want = cmplx.Conj(a[i*test.lda+j])
+				}
+				if b[j*test.ldb+i] != want {
+					t.Errorf("m = %d, n = %d: mismatch at (%d, %d)", m, n, j, i)
+				}
+			}
+		}
+	}
+	for _, f := range []func(){
+		func() { Blasser.Zgeconj(-1, 2, nil, 2, nil, 1) },
+		func() { Blasser.Zgeconj(2, -1, nil, 1, nil, 2) },
+		func() { Blasser.Zgeconj(2, 3, make([]complex128, 6), 2, make([]complex128, 6), 2) },
+		func() { Blasser.Zgeconj(2, 3, make([]complex128, 6), 3, make([]complex128, 6), 1) },
+		func() { Blasser.Zgeconj(2, 3, make([]complex128, 5), 3, make([]complex128, 6), 2) },
+		func() { Blasser.Zgeconj(2, 3, make([]complex128, 6), 3, make([]complex128, 5), 2) },
+	} {
+		if !panics(f) {
+			t.Errorf("Expected panic")
+		}
+	}
+}
+
+func TestZgeconjSquare(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		n, lda int
+	}{
+		{0, 1},
+		{1, 1},
+		{2, 2},
+		{5, 5},
+		{4, 7},
+	} {
+		n, lda := test.n, test.lda
+		a := randComplex(rnd, n, n, lda)
+		want := append([]complex128(nil), a...)
+		if n > 0 {
+			// Use the out-of-place transpose as the reference.
+			tmp := make([]complex128, len(a))
+			Blasser.Zgeconj(n, n, a, lda, tmp, lda)
+			for i := 0; i < n; i++ {
+				copy(want[i*lda:i*lda+n], tmp[i*lda:i*lda+n])
+			}
+		}
+		Blasser.ZgeconjSquare(n, a, lda)
+		for i := range a {
+			if a[i] != want[i] {
+				t.Errorf("n = %d, lda = %d: mismatch at %d: got %v, want %v", n, lda, i, a[i], want[i])
+			}
+		}
+		// Applying it twice leaves A unchanged.
+		Blasser.ZgeconjSquare(n, a, lda)
+		Blasser.ZgeconjSquare(n, a, lda)
+		for i := range a {
+			if a[i] != want[i] {
+				t.Errorf("n = %d, lda = %d: ZgeconjSquare is not an involution", n, lda)
+				break
+			}
+		}
+	}
+	for _, f := range []func(){
+		func() { Blasser.ZgeconjSquare(-1, nil, 1) },
+		func() { Blasser.ZgeconjSquare(3, make([]complex128, 9), 2) },
+		func() { Blasser.ZgeconjSquare(3, make([]complex128, 8), 3) },
+	} {
+		if !panics(f) {
+			t.Errorf("Expected panic")
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import "github.com/gonum/blas"
+
+// ZgemmSplit computes the complex matrix product
+//
+//	C := beta * C + alpha * op(A) * op(B),
+//
+// where op(X) is X, X^T or X^H for tX equal to blas.NoTrans, blas.Trans or
+// blas.ConjTrans, and the complex matrices are held in split storage: the real
+// and imaginary parts of X are stored in the separate float64 slices xRe and
+// xIm, which share the row-major layout and stride ldx. The dimensions are as
+// for Dgemm, and alpha and beta are alphaRe + i*alphaIm and
+// betaRe + i*betaIm.
+//
+// The product is computed by four real multiplications with the Dgemm kernel
+// of bl, so the block size, strategy and workers of bl apply to each. If alpha
+// is not one or tB is blas.ConjTrans, a scaled copy of B is allocated. None of
+// cRe and cIm may overlap each other or any of the parts of A and B. If beta
+// is zero, C need not be set on input. If k or alpha is zero, A and B are not
+// referenced.
+func (bl Blas) ZgemmSplit(tA, tB blas.Transpose, m, n, k int, alphaRe, alphaIm float64, aRe, aIm []float64, lda int, bRe, bIm []float64, ldb int, betaRe, betaIm float64, cRe, cIm []float64, ldc int) {
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if tB != blas.NoTrans && tB != blas.Trans && tB != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	// The real parts are multiplied as for Dgemm; conjugation only changes
+	// the signs with which the imaginary parts are combined.
+	rA, rB := tA, tB
+	if rA == blas.ConjTrans {
+		rA = blas.Trans
+	}
+	if rB == blas.ConjTrans {
+		rB = blas.Trans
+	}
+	ar, br, cr := dgemmMats(rA, rB, m, n, k, aRe, lda, bRe, ldb, cRe, ldc)
+	ai, bi, ci := dgemmMats(rA, rB, m, n, k, aIm, lda, bIm, ldb, cIm, ldc)
+
+	if m == 0 || n == 0 {
+		return
+	}
+	alphaZero := alphaRe == 0 && alphaIm == 0
+	if alphaZero && betaRe == 1 && betaIm == 0 {
+		return
+	}
+	if betaRe != 1 || betaIm != 0 {
+		bl.zscaleSplit(cr, ci, betaRe, betaIm)
+	}
+	if k == 0 || alphaZero {
+		return
+	}
+
+	// Fold alpha and the conjugation of B into a copy of B, so that the
+	// product is C += op(A) * B' with B' = alpha * op(B) in B's layout.
+	if alphaRe != 1 || alphaIm != 0 || tB == blas.ConjTrans {
+		sign := 1.0
+		if tB == blas.ConjTrans {
+			sign = -1
+		}
+		sr := newGeneral(br.rows, br.cols)
+		si := newGeneral(br.rows, br.cols)
+		for i := 0; i < br.rows; i++ {
+			rowR := br.data[i*br.stride : i*br.stride+br.cols]
+			rowI := bi.data[i*bi.stride : i*bi.stride+bi.cols]
+			outR := sr.data[i*sr.stride : i*sr.stride+sr.cols]
+			outI := si.data[i*si.stride : i*si.stride+si.cols]
+			for j, vr := range rowR {
+				vi := sign * rowI[j]
+				outR[j] = alphaRe*vr - alphaIm*vi
+				outI[j] = alphaRe*vi + alphaIm*vr
+			}
+		}
+		br, bi = sr, si
+	}
+
+	// With A' = Ar + i*s*Ai, where s is -1 if A is conjugated,
+	//  Re(C) += Ar*Br' - s*Ai*Bi'
+	//  Im(C) += Ar*Bi' + s*Ai*Br'
+	s := 1.0
+	if tA == blas.ConjTrans {
+		s = -1
+	}
+	bl.dgemmMul(rA, rB, ar, br, cr, 1)
+	bl.dgemmMul(rA, rB, ai, bi, cr, -s)
+	bl.dgemmMul(rA, rB, ar, bi, ci, 1)
+	bl.dgemmMul(rA, rB, ai, br, ci, s)
+}
+
+// zscaleSplit computes C := beta * C for the complex matrix C with real part
+// cr and imaginary part ci. If beta is zero, C is set to zero.
+func (bl Blas) zscaleSplit(cr, ci general, betaRe, betaIm float64) {
+	bl.parallelRows(cr.rows, 2*cr.cols, func(i, r int) {
+		for ; r > 0; i, r = i+1, r-1 {
+			rowR := cr.data[i*cr.stride : i*cr.stride+cr.cols]
+			rowI := ci.data[i*ci.stride : i*ci.stride+ci.cols]
+			if betaRe == 0 && betaIm == 0 {
+				for j := range rowR {
+					rowR[j] = 0
+					rowI[j] = 0
+				}
+				continue
+			}
+			for j, vr := range rowR {
+				vi := rowI[j]
+				rowR[j] = betaRe*vr - betaIm*vi
+				rowI[j] = betaRe*vi + betaIm*vr
+			}
+		}
+	})
+}
@@ -0,0 +1,137 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goblas
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestZgemmSplit(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	trans := []blas.Transpose{blas.NoTrans, blas.Trans, blas.ConjTrans}
+	for i, test := range []struct {
+		m, n, k int
+		pad     int
+		alpha   complex128
+		beta    complex128
+	}{
+		{0, 3, 2, 1, 1, 0},
+		{3, 4, 0, 1, 1, 2 - 1i},
+		{1, 1, 1, 0, 1, 0},
+		{3, 4, 5, 0, 1, 1},
+		{5, 3, 4, 2, 2 - 0.5i, 0},
+		{4, 6, 3, 1, 0.5i, 1 + 1i},
+		{7, 2, 9, 1, 0, -1 + 0.5i},
+		{70, 90, 80, 3, -1 + 2i, 0.5},
+	} {
+		for _, tA := range trans {
+			for _, tB := range trans {
+				m, n, k := test.m, test.n, test.k
+				ar, ac := m, k
+				if tA != blas.NoTrans {
+					ar, ac = k, m
+				}
+				br, bc := k, n
+				if tB != blas.NoTrans {
+					br, bc = n, k
+				}
+				lda, ldb, ldc := ac+test.pad, bc+test.pad, n+test.pad
+				a := randComplex(rnd, ar, ac, lda)
+				b := randComplex(rnd, br, bc, ldb)
+				c := randComplex(rnd, m, n, ldc)
+
+				want := make([]complex128, len(c))
+				copy(want, c)
+				for r := 0; r < m; r++ {
+					for j := 0; j < n; j++ {
+						var sum complex128
+						for l := 0; l < k; l++ {
+							sum += opAt(tA, a, lda, r, l) * opAt(tB, b, ldb, l, j)
+						}
+						want[r*ldc+j] = test.beta*c[r*ldc+j] + test.alpha*sum
+					}
+				}
+
+				aRe, aIm := splitComplex(a)
+				bRe, bIm := splitComplex(b)
+				cRe, cIm := splitComplex(c)
+				Blasser.ZgemmSplit(tA, tB, m, n, k, real(test.alpha), imag(test.alpha), aRe, aIm, lda, bRe, bIm, ldb, real(test.beta), imag(test.beta), cRe, cIm, ldc)
+				for j := range want {
+					got := complex(cRe[j], cIm[j])
+					if cmplx.Abs(got-want[j]) > 1e-12*math.Max(1, float64(k)) {
+						t.Errorf("Case %v, tA = %c, tB = %c: mismatch at %v: got %v, want %v", i, tA, tB, j, got, want[j])
+						break
+					}
+				}
+			}
+		}
+	}
+
+	// A NaN in C is not propagated when beta is zero.
+	cRe, cIm := []float64{math.NaN()}, []float64{math.NaN()}
+	Blasser.ZgemmSplit(blas.NoTrans, blas.NoTrans, 1, 1, 1, 1, 0, []float64{2}, []float64{1}, 1, []float64{3}, []float64{0}, 1, 0, 0, cRe, cIm, 1)
+	if cRe[0] != 6 || cIm[0] != 3 {
+		t.Errorf("unexpected result with beta = 0 and NaN C: got %v + %vi, want 6 + 3i", cRe[0], cIm[0])
+	}
+
+	for _, f := range []func(){
+		func() {
+			Blasser.ZgemmSplit('x', blas.NoTrans, 1, 1, 1, 1, 0, nil, nil, 1, nil, nil, 1, 0, 0, nil, nil, 1)
+		},
+		func() {
+			Blasser.ZgemmSplit(blas.NoTrans, blas.NoTrans, -1, 1, 1, 1, 0, nil, nil, 1, nil, nil, 1, 0, 0, nil, nil, 1)
+		},
+		func() {
+			Blasser.ZgemmSplit(blas.NoTrans, blas.NoTrans, 2, 2, 2, 1, 0, make([]float64, 4), make([]float64, 3), 2, make([]float64, 4), make([]float64, 4), 2, 0, 0, make([]float64, 4), make([]float64, 4), 2)
+		},
+		func() {
+			Blasser.ZgemmSplit(blas.NoTrans, blas.NoTrans, 2, 2, 2, 1, 0, make([]float64, 4), make([]float64, 4), 2, make([]float64, 4), make([]float64, 4), 2, 0, 0, make([]float64, 4), make([]float64, 3), 2)
+		},
+	} {
+		if !panics(f) {
+			t.Errorf("Expected panic")
+		}
+	}
+}
+
+// randComplex returns an r×c complex matrix with stride ld and random
+// elements. The padding elements are also random.
+func randComplex(rnd *rand.Rand, r, c, ld int) []complex128 {
+	n := 0
+	if r > 0 && c > 0 {
+		n = (r-1)*ld + c
+	}
+	x := make([]complex128, n)
+	for i := range x {
+		x[i] = complex(rnd.NormFloat64(), rnd.NormFloat64())
+	}
+	return x
+}
+
+// opAt returns element (i, j) of op(X) for the row-major matrix x with stride ld.
+func opAt(t blas.Transpose, x []complex128, ld, i, j int) complex128 {
+	switch t {
+	case blas.Trans:
+		return x[j*ld+i]
+	case blas.ConjTrans:
+		return cmplx.Conj(x[j*ld+i])
+	}
+	return x[i*ld+j]
+}
+
+// splitComplex returns the real and imaginary parts of x.
+func splitComplex(x []complex128) (re, im []float64) {
+	re = make([]float64, len(x))
+	im = make([]float64, len(x))
+	for i, v := range x {
+		re[i], im[i] = real(v), imag(v)
+	}
+	return re, im
+}
@@ -1,6 +1,7 @@
 package testblas
 
 import (
+	"math"
 	"testing"
 
 	"github.com/gonum/blas"
@@ -248,5 +249,40 @@ func dgemmcomp(i int, name string, t *testing.T, blasser Dgemmer, tA, tB blas.Tr
 	if !dSliceTolEqual(ansFlat, cFlat) {
 		t.Errorf("Test %v case %v: answer mismatch. Expected %v, Found %v", i, name, ansFlat, cFlat)
 	}
-	// TODO: Need to add a sub-slice test where don't use up full matrix
+
+	// Repeat with the matrices as sub-blocks of larger arrays.
+	const pad = 3
+	aPad, lda := flattenPadded(a, pad)
+	bPad, ldb := flattenPadded(b, pad+1)
+	cPad, ldc := flattenPadded(c, pad+2)
+	blasser.Dgemm(tA, tB, m, n, k, alpha, aPad, lda, bPad, ldb, beta, cPad, ldc)
+	if !dSliceTolEqual(ansFlat, unpad(cPad, len(c), len(c[0]), ldc)) {
+		t.Errorf("Test %v case %v: answer mismatch with padded strides", i, name)
+	}
+}
+
+// flattenPadded returns a flattened with a stride of len(a[0])+pad. The padding
+// is filled with NaN.
+func flattenPadded(a [][]float64, pad int) ([]float64, int) {
+	m := len(a)
+	n := len(a[0])
+	stride := n + pad
+	s := make([]float64, (m-1)*stride+n)
+	for i := range s {
+		s[i] = math.NaN()
+	}
+	for i := 0; i < m; i++ {
+		copy(s[i*stride:i*stride+n], a[i])
+	}
+	return s, stride
+}
+
+// unpad returns the m×n matrix with the given stride stored in a as a
+// tightly packed slice.
+func unpad(a []float64, m, n, stride int) []float64 {
+	s := make([]float64, m*n)
+	for i := 0; i < m; i++ {
+		copy(s[i*n:(i+1)*n], a[i*stride:i*stride+n])
+	}
+	return s
 }
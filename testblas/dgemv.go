@@ -1,6 +1,7 @@
 package testblas
 
 import (
+	"math"
 	"testing"
 
 	"github.com/gonum/blas"
@@ -506,12 +507,50 @@ func DgemvTest(t *testing.T, blasser Dgemver) {
 			// Test that it passes with row-major
 			dgemvcomp(t, test, cas, i, blasser)
 
+			// Test that y is not read when beta is zero
+			if cas.beta == 0 {
+				dgemvNaNY(t, test, cas, i, blasser)
+			}
+
 			// Test the bad inputs
 			dgemvbad(t, test, cas, i, blasser)
 		}
 	}
 }
 
+// dgemvNaNY checks that when beta is zero the referenced elements of y are
+// assigned rather than scaled, so NaN values in y do not propagate.
+func dgemvNaNY(t *testing.T, test DgemvCase, cas DgemvSubcase, i int, blasser Dgemver) {
+	x := sliceCopy(test.x)
+	y := sliceCopy(test.y)
+	aFlat := flatten(sliceOfSliceCopy(test.A))
+
+	incX := test.incX
+	if cas.mulXNeg1 {
+		incX *= -1
+	}
+	incY := test.incY
+	if cas.mulYNeg1 {
+		incY *= -1
+	}
+	lenY := test.m
+	if test.tA != blas.NoTrans {
+		lenY = test.n
+	}
+	absIncY := incY
+	if absIncY < 0 {
+		absIncY = -absIncY
+	}
+	for iy := 0; iy < lenY*absIncY; iy += absIncY {
+		y[iy] = math.NaN()
+	}
+
+	blasser.Dgemv(test.tA, test.m, test.n, cas.alpha, aFlat, test.n, x, incX, cas.beta, y, incY)
+	if !dSliceTolEqual(cas.ans, y) {
+		t.Errorf("Test %v, case %v: answer mismatch with NaN y and beta = 0: Expected %v, Found %v", test.Name, i, cas.ans, y)
+	}
+}
+
 func dgemvcomp(t *testing.T, test DgemvCase, cas DgemvSubcase, i int, blasser Dgemver) {
 	x := sliceCopy(test.x)
 	y := sliceCopy(test.y)
@@ -0,0 +1,161 @@
+package testblas
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Dspmver interface {
+	Dspmv(ul blas.Uplo, n int, alpha float64, ap []float64, x []float64, incX int, beta float64, y []float64, incY int)
+	Dsymv(ul blas.Uplo, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int)
+}
+
+// DspmvTest compares Dspmv against Dsymv on the unpacked matrix for the
+// upper and lower triangles packed in row-major and in column-major order,
+// positive and negative increments and a range of scalars. The BLAS packs
+// by rows, so a column-major packing is passed with the opposite triangle:
+// column j of the upper triangle holds the same elements, in the same order,
+// as row j of the lower triangle of the symmetric matrix.
+func DspmvTest(t *testing.T, blasser Dspmver) {
+	for _, n := range []int{1, 2, 3, 7} {
+		a := randSymmetric(n)
+		dense := make([]float64, 0, n*n)
+		for _, row := range a {
+			dense = append(dense, row...)
+		}
+		for _, layout := range []struct {
+			name string
+			ul   blas.Uplo
+			ap   []float64
+		}{
+			{"row-major upper", blas.Upper, packSymmetric(blas.Upper, a)},
+			{"row-major lower", blas.Lower, packSymmetric(blas.Lower, a)},
+			{"column-major upper", blas.Lower, packSymmetricColMajor(blas.Upper, a)},
+			{"column-major lower", blas.Upper, packSymmetricColMajor(blas.Lower, a)},
+		} {
+			ul, ap := layout.ul, layout.ap
+			for _, inc := range []struct{ x, y int }{{1, 1}, {2, 3}, {-1, 1}, {1, -2}, {-3, -1}} {
+				for _, scal := range []struct{ alpha, beta float64 }{{0, 0}, {0, 1}, {1, 0}, {2, -0.5}, {-1.5, 1}} {
+					x := randStrided(n, inc.x)
+					y := randStrided(n, inc.y)
+					want := sliceCopy(y)
+					blasser.Dsymv(ul, n, scal.alpha, dense, n, x, inc.x, scal.beta, want, inc.y)
+					apCopy := sliceCopy(ap)
+					xCopy := sliceCopy(x)
+
+					blasser.Dspmv(ul, n, scal.alpha, ap, x, inc.x, scal.beta, y, inc.y)
+
+					if !dSliceEqual(ap, apCopy) {
+						t.Errorf("n = %v, %v: ap modified during call", n, layout.name)
+					}
+					if !dStridedSliceTolEqual(n, x, inc.x, xCopy, inc.x) {
+						t.Errorf("n = %v, %v: x modified during call", n, layout.name)
+					}
+					if !dStridedSliceTolEqual(n, y, inc.y, want, inc.y) {
+						t.Errorf("n = %v, %v, incX = %v, incY = %v, alpha = %v, beta = %v: answer mismatch",
+							n, layout.name, inc.x, inc.y, scal.alpha, scal.beta)
+					}
+				}
+			}
+		}
+	}
+	for _, f := range []func(){
+		func() {
+			blasser.Dspmv(blas.All, 2, 1, make([]float64, 3), make([]float64, 2), 1, 1, make([]float64, 2), 1)
+		},
+		func() { blasser.Dspmv(blas.Upper, -1, 1, nil, nil, 1, 1, nil, 1) },
+		func() {
+			blasser.Dspmv(blas.Upper, 3, 1, make([]float64, 5), make([]float64, 3), 1, 1, make([]float64, 3), 1)
+		},
+		func() {
+			blasser.Dspmv(blas.Upper, 2, 1, make([]float64, 3), make([]float64, 2), 0, 1, make([]float64, 2), 1)
+		},
+		func() {
+			blasser.Dspmv(blas.Upper, 2, 1, make([]float64, 3), make([]float64, 2), 1, 1, make([]float64, 2), 0)
+		},
+	} {
+		testpanics(f, "Dspmv", t)
+	}
+}
+
+// randSymmetric returns a random n×n symmetric matrix.
+func randSymmetric(n int) [][]float64 {
+	a := make([][]float64, n)
+	for i := range a {
+		a[i] = make([]float64, n)
+	}
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			v := rand.NormFloat64()
+			a[i][j] = v
+			a[j][i] = v
+		}
+	}
+	return a
+}
+
+// packSymmetric returns the ul triangle of a in row-major packed storage.
+func packSymmetric(ul blas.Uplo, a [][]float64) []float64 {
+	n := len(a)
+	ap := make([]float64, 0, n*(n+1)/2)
+	for i := 0; i < n; i++ {
+		if ul == blas.Upper {
+			ap = append(ap, a[i][i:]...)
+		} else {
+			ap = append(ap, a[i][:i+1]...)
+		}
+	}
+	return ap
+}
+
+// randStrided returns a random vector with n elements at increment inc. The
+// elements between those referenced are NaN.
+func randStrided(n, inc int) []float64 {
+	if inc < 0 {
+		inc = -inc
+	}
+	x := make([]float64, (n-1)*inc+1)
+	for i := range x {
+		if i%inc == 0 {
+			x[i] = rand.NormFloat64()
+		} else {
+			x[i] = math.NaN()
+		}
+	}
+	return x
+}
+
+// packSymmetricColMajor returns the ul triangle of a in column-major packed
+// storage: column j of the upper triangle holds rows 0 through j, and column
+// j of the lower triangle rows j through n-1.
+func packSymmetricColMajor(ul blas.Uplo, a [][]float64) []float64 {
+	n := len(a)
+	ap := make([]float64, 0, n*(n+1)/2)
+	for j := 0; j < n; j++ {
+		il, iu := j, n
+		if ul == blas.Upper {
+			il, iu = 0, j+1
+		}
+		for i := il; i < iu; i++ {
+			ap = append(ap, a[i][j])
+		}
+	}
+	return ap
+}
+
+// unstride returns the n logical elements of the vector x with increment inc.
+func unstride(n int, x []float64, inc int) []float64 {
+	s := make([]float64, n)
+	ix := 0
+	if inc < 0 {
+		ix = -(n - 1) * inc
+	}
+	for i := range s {
+		s[i] = x[ix]
+		ix += inc
+	}
+	return s
+}
@@ -0,0 +1,107 @@
+package testblas
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Dsyrker interface {
+	Dsyrk(ul blas.Uplo, t blas.Transpose, n, k int, alpha float64, a []float64, lda int, beta float64, c []float64, ldc int)
+}
+
+// DsyrkTest checks Dsyrk against a dense computation of alpha*op(A)*op(A)^T
+// + beta*C for both triangles and transposes. It also checks that the
+// triangle of C that is not referenced, which is filled with NaN, is left
+// unchanged.
+func DsyrkTest(t *testing.T, blasser Dsyrker) {
+	for _, n := range []int{0, 1, 2, 5, 40} {
+		for _, k := range []int{0, 1, 3, 30} {
+			for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+				for _, tr := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+					for _, alpha := range []float64{0, 1, -0.5} {
+						for _, beta := range []float64{0, 1, 2} {
+							rowA, colA := n, k
+							if tr == blas.Trans {
+								rowA, colA = k, n
+							}
+							lda := colA + 2
+							ldc := n + 3
+							a := make([]float64, rowA*lda)
+							for i := range a {
+								a[i] = rand.NormFloat64()
+							}
+							c := make([]float64, n*ldc)
+							for i := 0; i < n; i++ {
+								for j := 0; j < ldc; j++ {
+									inTri := j < n && ((ul == blas.Upper && j >= i) || (ul == blas.Lower && j <= i))
+									if inTri {
+										c[i*ldc+j] = rand.NormFloat64()
+									} else {
+										c[i*ldc+j] = math.NaN()
+									}
+								}
+							}
+							want := sliceCopy(c)
+							for i := 0; i < n; i++ {
+								for j := 0; j < n; j++ {
+									if (ul == blas.Upper && j < i) || (ul == blas.Lower && j > i) {
+										continue
+									}
+									var sum float64
+									for l := 0; l < k; l++ {
+										if tr == blas.NoTrans {
+											sum += a[i*lda+l] * a[j*lda+l]
+										} else {
+											sum += a[l*lda+i] * a[l*lda+j]
+										}
+									}
+									w := alpha * sum
+									if beta != 0 {
+										w += beta * c[i*ldc+j]
+									}
+									want[i*ldc+j] = w
+								}
+							}
+
+							blasser.Dsyrk(ul, tr, n, k, alpha, a, lda, beta, c, ldc)
+							for i := range c {
+								if math.IsNaN(want[i]) {
+									if !math.IsNaN(c[i]) {
+										t.Errorf("n = %v, k = %v, ul = %v, t = %v, alpha = %v, beta = %v: unreferenced element %v modified",
+											n, k, ul, tr, alpha, beta, i)
+										break
+									}
+									continue
+								}
+								if math.Abs(c[i]-want[i]) > 1e-12 {
+									t.Errorf("n = %v, k = %v, ul = %v, t = %v, alpha = %v, beta = %v: mismatch at %v. Want %v, got %v",
+										n, k, ul, tr, alpha, beta, i, want[i], c[i])
+									break
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	for _, f := range []func(){
+		func() {
+			blasser.Dsyrk(blas.All, blas.NoTrans, 2, 2, 1, make([]float64, 4), 2, 1, make([]float64, 4), 2)
+		},
+		func() { blasser.Dsyrk(blas.Upper, 'X', 2, 2, 1, make([]float64, 4), 2, 1, make([]float64, 4), 2) },
+		func() { blasser.Dsyrk(blas.Upper, blas.NoTrans, -1, 2, 1, nil, 2, 1, nil, 1) },
+		func() { blasser.Dsyrk(blas.Upper, blas.NoTrans, 2, -1, 1, nil, 1, 1, make([]float64, 4), 2) },
+		func() {
+			blasser.Dsyrk(blas.Upper, blas.NoTrans, 2, 3, 1, make([]float64, 6), 2, 1, make([]float64, 4), 2)
+		},
+		func() {
+			blasser.Dsyrk(blas.Upper, blas.NoTrans, 2, 2, 1, make([]float64, 4), 2, 1, make([]float64, 4), 1)
+		},
+	} {
+		testpanics(f, "Dsyrk", t)
+	}
+}
@@ -0,0 +1,100 @@
+package testblas
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Dtrsver interface {
+	Dtrsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float64, lda int, x []float64, incX int)
+}
+
+// DtrsvTest checks that the solution computed by Dtrsv satisfies op(A)*x = b for
+// all combinations of triangle, transpose and diagonal type. The triangle of A
+// that is not referenced is filled with NaN, as is the diagonal when it is
+// implicitly unit.
+func DtrsvTest(t *testing.T, blasser Dtrsver) {
+	for _, n := range []int{1, 2, 3, 8} {
+		for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				for _, d := range []blas.Diag{blas.NonUnit, blas.Unit} {
+					for _, lda := range []int{n, n + 2} {
+						for _, incX := range []int{1, 3, -1, -2} {
+							a, dense := randTriangular(ul, d, n, lda)
+							b := randStrided(n, incX)
+							x := sliceCopy(b)
+
+							blasser.Dtrsv(ul, tA, d, n, a, lda, x, incX)
+
+							xs := unstride(n, x, incX)
+							bs := unstride(n, b, incX)
+							for i := 0; i < n; i++ {
+								var sum float64
+								for j := 0; j < n; j++ {
+									if tA == blas.NoTrans {
+										sum += dense[i][j] * xs[j]
+									} else {
+										sum += dense[j][i] * xs[j]
+									}
+								}
+								if math.Abs(sum-bs[i]) > 1e-12 {
+									t.Errorf("n = %v, ul = %v, tA = %v, d = %v, lda = %v, incX = %v: op(A)*x != b at %v",
+										n, ul, tA, d, lda, incX, i)
+									break
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	for _, f := range []func(){
+		func() {
+			blasser.Dtrsv(blas.All, blas.NoTrans, blas.NonUnit, 2, make([]float64, 4), 2, make([]float64, 2), 1)
+		},
+		func() { blasser.Dtrsv(blas.Upper, 'X', blas.NonUnit, 2, make([]float64, 4), 2, make([]float64, 2), 1) },
+		func() { blasser.Dtrsv(blas.Upper, blas.NoTrans, 'X', 2, make([]float64, 4), 2, make([]float64, 2), 1) },
+		func() { blasser.Dtrsv(blas.Upper, blas.NoTrans, blas.NonUnit, -1, nil, 1, nil, 1) },
+		func() {
+			blasser.Dtrsv(blas.Upper, blas.NoTrans, blas.NonUnit, 2, make([]float64, 4), 1, make([]float64, 2), 1)
+		},
+		func() {
+			blasser.Dtrsv(blas.Upper, blas.NoTrans, blas.NonUnit, 2, make([]float64, 4), 2, make([]float64, 2), 0)
+		},
+	} {
+		testpanics(f, "Dtrsv", t)
+	}
+}
+
+// randTriangular returns a random well conditioned n×n triangular matrix
+// stored with leading dimension lda, and its dense form. The elements that
+// must not be referenced are NaN in the stored matrix.
+func randTriangular(ul blas.Uplo, d blas.Diag, n, lda int) ([]float64, [][]float64) {
+	a := make([]float64, n*lda)
+	for i := range a {
+		a[i] = math.NaN()
+	}
+	dense := make([][]float64, n)
+	for i := range dense {
+		dense[i] = make([]float64, n)
+		for j := 0; j < n; j++ {
+			switch {
+			case i == j:
+				if d == blas.Unit {
+					dense[i][j] = 1
+				} else {
+					dense[i][j] = float64(n) + rand.Float64()
+					a[i*lda+j] = dense[i][j]
+				}
+			case (ul == blas.Upper && j > i) || (ul == blas.Lower && j < i):
+				dense[i][j] = rand.Float64()
+				a[i*lda+j] = dense[i][j]
+			}
+		}
+	}
+	return a, dense
+}
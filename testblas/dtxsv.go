@@ -0,0 +1,143 @@
+package testblas
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Dtxsver interface {
+	Dtrsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float64, lda int, x []float64, incX int)
+	Dtbsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k int, a []float64, lda int, x []float64, incX int)
+	Dtpsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []float64, x []float64, incX int)
+}
+
+// DtxsvTest checks that the band and packed triangular solves Dtbsv and
+// Dtpsv agree with Dtrsv on the equivalent dense matrix for all combinations
+// of triangle, transpose and diagonal type. Elements of the band and packed
+// storage that must not be referenced are NaN.
+func DtxsvTest(t *testing.T, blasser Dtxsver) {
+	for _, n := range []int{1, 2, 3, 8} {
+		for _, k := range []int{0, 1, 2, n + 1} {
+			for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+				for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+					for _, d := range []blas.Diag{blas.NonUnit, blas.Unit} {
+						for _, incX := range []int{1, 3, -1, -2} {
+							a, ab, ldab, ap := randTriangularBand(ul, d, n, k)
+							b := randStrided(n, incX)
+
+							want := sliceCopy(b)
+							blasser.Dtrsv(ul, tA, d, n, a, n, want, incX)
+
+							x := sliceCopy(b)
+							blasser.Dtbsv(ul, tA, d, n, k, ab, ldab, x, incX)
+							if !dStridedSliceTolEqual(n, x, incX, want, incX) {
+								t.Errorf("Dtbsv: n = %v, k = %v, ul = %v, tA = %v, d = %v, incX = %v: mismatch with Dtrsv",
+									n, k, ul, tA, d, incX)
+							}
+
+							if k < n-1 {
+								continue
+							}
+							// With k >= n-1 the band is the full triangle,
+							// so the packed form is the same matrix.
+							x = sliceCopy(b)
+							blasser.Dtpsv(ul, tA, d, n, ap, x, incX)
+							if !dStridedSliceTolEqual(n, x, incX, want, incX) {
+								t.Errorf("Dtpsv: n = %v, ul = %v, tA = %v, d = %v, incX = %v: mismatch with Dtrsv",
+									n, ul, tA, d, incX)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	for _, f := range []func(){
+		func() {
+			blasser.Dtbsv(blas.All, blas.NoTrans, blas.NonUnit, 2, 1, make([]float64, 4), 2, make([]float64, 2), 1)
+		},
+		func() {
+			blasser.Dtbsv(blas.Upper, 'X', blas.NonUnit, 2, 1, make([]float64, 4), 2, make([]float64, 2), 1)
+		},
+		func() {
+			blasser.Dtbsv(blas.Upper, blas.NoTrans, 'X', 2, 1, make([]float64, 4), 2, make([]float64, 2), 1)
+		},
+		func() { blasser.Dtbsv(blas.Upper, blas.NoTrans, blas.NonUnit, -1, 1, nil, 2, nil, 1) },
+		func() {
+			blasser.Dtbsv(blas.Upper, blas.NoTrans, blas.NonUnit, 2, -1, make([]float64, 4), 2, make([]float64, 2), 1)
+		},
+		func() {
+			blasser.Dtbsv(blas.Upper, blas.NoTrans, blas.NonUnit, 2, 1, make([]float64, 4), 1, make([]float64, 2), 1)
+		},
+		func() {
+			blasser.Dtbsv(blas.Upper, blas.NoTrans, blas.NonUnit, 2, 1, make([]float64, 4), 2, make([]float64, 2), 0)
+		},
+		func() {
+			blasser.Dtpsv(blas.All, blas.NoTrans, blas.NonUnit, 2, make([]float64, 3), make([]float64, 2), 1)
+		},
+		func() { blasser.Dtpsv(blas.Upper, 'X', blas.NonUnit, 2, make([]float64, 3), make([]float64, 2), 1) },
+		func() { blasser.Dtpsv(blas.Upper, blas.NoTrans, 'X', 2, make([]float64, 3), make([]float64, 2), 1) },
+		func() { blasser.Dtpsv(blas.Upper, blas.NoTrans, blas.NonUnit, -1, nil, nil, 1) },
+		func() {
+			blasser.Dtpsv(blas.Upper, blas.NoTrans, blas.NonUnit, 2, make([]float64, 2), make([]float64, 2), 1)
+		},
+		func() {
+			blasser.Dtpsv(blas.Upper, blas.NoTrans, blas.NonUnit, 2, make([]float64, 3), make([]float64, 2), 0)
+		},
+	} {
+		testpanics(f, "Dtxsv", t)
+	}
+}
+
+// randTriangularBand returns a random well conditioned n×n triangular matrix
+// with k super- or sub-diagonals in dense storage with lda = n, in row-major
+// band storage with stride ldab, and, for k >= n-1, in row-major packed
+// storage. Unreferenced elements of the band and packed forms are NaN, as
+// is the diagonal when it is implicitly unit.
+func randTriangularBand(ul blas.Uplo, d blas.Diag, n, k int) (a, ab []float64, ldab int, ap []float64) {
+	a = make([]float64, n*n)
+	ldab = k + 1
+	ab = make([]float64, n*ldab)
+	for i := range ab {
+		ab[i] = math.NaN()
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			var inBand bool
+			var bi int
+			if ul == blas.Upper {
+				inBand = j >= i && j <= i+k
+				bi = i*ldab + j - i
+			} else {
+				inBand = j <= i && j >= i-k
+				bi = i*ldab + k + j - i
+			}
+			if !inBand {
+				continue
+			}
+			if i == j {
+				if d == blas.Unit {
+					a[i*n+j] = math.NaN()
+					continue
+				}
+				a[i*n+j] = float64(n) + rand.Float64()
+			} else {
+				a[i*n+j] = rand.Float64()
+			}
+			ab[bi] = a[i*n+j]
+		}
+	}
+	if k >= n-1 {
+		for i := 0; i < n; i++ {
+			if ul == blas.Upper {
+				ap = append(ap, a[i*n+i:i*n+n]...)
+			} else {
+				ap = append(ap, a[i*n:i*n+i+1]...)
+			}
+		}
+	}
+	return a, ab, ldab, ap
+}
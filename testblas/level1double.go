@@ -1706,4 +1706,24 @@ func DscalTest(t *testing.T, blasser Dscaler) {
 			}
 		}
 	}
+
+	// Scale each column of a row-major matrix in place, using the stride
+	// as the increment. The other columns and the padding at the end of
+	// each row must be untouched.
+	const m, n, stride = 4, 3, 5
+	for j := 0; j < n; j++ {
+		a := make([]float64, m*stride)
+		for i := range a {
+			a[i] = float64(i + 1)
+		}
+		want := make([]float64, len(a))
+		copy(want, a)
+		for i := 0; i < m; i++ {
+			want[i*stride+j] *= -2
+		}
+		dscal(m, -2, a[j:], stride)
+		if !dSliceEqual(a, want) {
+			t.Errorf("dscal: column %v of a %v×%v matrix with stride %v: expected %v, found %v", j, m, n, stride, want, a)
+		}
+	}
 }
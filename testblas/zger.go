@@ -0,0 +1,82 @@
+package testblas
+
+import (
+	"math/cmplx"
+	"math/rand"
+	"testing"
+)
+
+type Zgerer interface {
+	Zgeru(m, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int)
+	Zgerc(m, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int)
+}
+
+// ZgerTest checks Zgeru and Zgerc against a direct computation of the rank
+// one update. The elements of a beyond n in each row are NaN and must not be
+// modified.
+func ZgerTest(t *testing.T, blasser Zgerer) {
+	for _, dims := range []struct{ m, n int }{{1, 1}, {3, 1}, {1, 4}, {3, 4}, {7, 5}} {
+		m, n := dims.m, dims.n
+		for _, lda := range []int{n, n + 2} {
+			for _, inc := range []struct{ x, y int }{{1, 1}, {2, 3}, {-1, 1}, {1, -2}, {-3, -1}} {
+				for _, alpha := range []complex128{0, 1, 2 - 1i} {
+					for _, conj := range []bool{false, true} {
+						x := randStridedCmplx(m, inc.x)
+						y := randStridedCmplx(n, inc.y)
+						a := make([]complex128, (m-1)*lda+n)
+						for i := range a {
+							if i%lda < n {
+								a[i] = complex(rand.NormFloat64(), rand.NormFloat64())
+							} else {
+								a[i] = cmplx.NaN()
+							}
+						}
+						xs := unstrideCmplx(m, x, inc.x)
+						ys := unstrideCmplx(n, y, inc.y)
+						want := make([]complex128, len(a))
+						copy(want, a)
+						for i := 0; i < m; i++ {
+							for j := 0; j < n; j++ {
+								v := ys[j]
+								if conj {
+									v = cmplx.Conj(v)
+								}
+								want[i*lda+j] += alpha * xs[i] * v
+							}
+						}
+
+						name := "Zgeru"
+						if conj {
+							name = "Zgerc"
+							blasser.Zgerc(m, n, alpha, x, inc.x, y, inc.y, a, lda)
+						} else {
+							blasser.Zgeru(m, n, alpha, x, inc.x, y, inc.y, a, lda)
+						}
+						if !zSliceTolNaNEqual(a, want) {
+							t.Errorf("%v: m = %v, n = %v, lda = %v, incX = %v, incY = %v, alpha = %v: answer mismatch",
+								name, m, n, lda, inc.x, inc.y, alpha)
+						}
+					}
+				}
+			}
+		}
+	}
+	for _, f := range []func(){
+		func() { blasser.Zgeru(-1, 2, 1, nil, 1, make([]complex128, 2), 1, nil, 2) },
+		func() { blasser.Zgeru(2, -1, 1, make([]complex128, 2), 1, nil, 1, nil, 1) },
+		func() {
+			blasser.Zgeru(2, 2, 1, make([]complex128, 2), 0, make([]complex128, 2), 1, make([]complex128, 4), 2)
+		},
+		func() {
+			blasser.Zgeru(2, 2, 1, make([]complex128, 2), 1, make([]complex128, 2), 0, make([]complex128, 4), 2)
+		},
+		func() {
+			blasser.Zgeru(2, 2, 1, make([]complex128, 2), 1, make([]complex128, 2), 1, make([]complex128, 4), 1)
+		},
+		func() {
+			blasser.Zgerc(2, 2, 1, make([]complex128, 2), 1, make([]complex128, 2), 1, make([]complex128, 4), 1)
+		},
+	} {
+		testpanics(f, "Zger", t)
+	}
+}
@@ -0,0 +1,191 @@
+package testblas
+
+import (
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Zhemver interface {
+	Zhemv(ul blas.Uplo, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int)
+}
+
+// ZhemvTest compares Zhemv against a dense Hermitian matrix-vector product.
+// The triangle of A opposite to ul is filled with NaN and the diagonal is given
+// a non-zero imaginary part, so the test fails unless the stored triangle is
+// conjugated when reflected and the diagonal is treated as real.
+func ZhemvTest(t *testing.T, blasser Zhemver) {
+	for _, n := range []int{1, 2, 3, 7} {
+		h := randHermitian(n)
+		for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, lda := range []int{n, n + 3} {
+				a := storeHermitian(ul, h, lda)
+				for _, inc := range []struct{ x, y int }{{1, 1}, {2, 3}, {-1, 1}, {1, -2}, {-3, -1}} {
+					for _, scal := range []struct{ alpha, beta complex128 }{{0, 0}, {0, 1}, {1, 0}, {2 - 1i, 0.5i}, {-1.5, 1}} {
+						x := randStridedCmplx(n, inc.x)
+						y := randStridedCmplx(n, inc.y)
+						want := zgemvDense(h, scal.alpha, x, inc.x, scal.beta, y, inc.y)
+
+						blasser.Zhemv(ul, n, scal.alpha, a, lda, x, inc.x, scal.beta, y, inc.y)
+
+						if !zStridedSliceTolEqual(n, y, inc.y, want, 1) {
+							t.Errorf("n = %v, ul = %v, lda = %v, incX = %v, incY = %v, alpha = %v, beta = %v: answer mismatch",
+								n, ul, lda, inc.x, inc.y, scal.alpha, scal.beta)
+						}
+					}
+				}
+			}
+		}
+	}
+	for _, f := range []func(){
+		func() {
+			blasser.Zhemv(blas.All, 2, 1, make([]complex128, 4), 2, make([]complex128, 2), 1, 1, make([]complex128, 2), 1)
+		},
+		func() { blasser.Zhemv(blas.Upper, -1, 1, nil, 1, nil, 1, 1, nil, 1) },
+		func() {
+			blasser.Zhemv(blas.Upper, 2, 1, make([]complex128, 4), 1, make([]complex128, 2), 1, 1, make([]complex128, 2), 1)
+		},
+		func() {
+			blasser.Zhemv(blas.Upper, 2, 1, make([]complex128, 4), 2, make([]complex128, 2), 0, 1, make([]complex128, 2), 1)
+		},
+		func() {
+			blasser.Zhemv(blas.Upper, 2, 1, make([]complex128, 4), 2, make([]complex128, 2), 1, 1, make([]complex128, 2), 0)
+		},
+	} {
+		testpanics(f, "Zhemv", t)
+	}
+}
+
+// randHermitian returns a random n×n Hermitian matrix.
+func randHermitian(n int) [][]complex128 {
+	h := make([][]complex128, n)
+	for i := range h {
+		h[i] = make([]complex128, n)
+	}
+	for i := 0; i < n; i++ {
+		h[i][i] = complex(rand.NormFloat64(), 0)
+		for j := i + 1; j < n; j++ {
+			v := complex(rand.NormFloat64(), rand.NormFloat64())
+			h[i][j] = v
+			h[j][i] = cmplx.Conj(v)
+		}
+	}
+	return h
+}
+
+// storeHermitian returns the ul triangle of h in row-major storage with
+// leading dimension lda. All other elements are NaN, and the imaginary parts
+// of the diagonal elements are set to a non-zero value that must be ignored.
+func storeHermitian(ul blas.Uplo, h [][]complex128, lda int) []complex128 {
+	n := len(h)
+	a := make([]complex128, n*lda)
+	for i := range a {
+		a[i] = cmplx.NaN()
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (ul == blas.Upper && j >= i) || (ul == blas.Lower && j <= i) {
+				a[i*lda+j] = h[i][j]
+			}
+		}
+		a[i*lda+i] = complex(real(h[i][i]), 100)
+	}
+	return a
+}
+
+// randStridedCmplx returns a random vector with n elements at increment inc.
+// The elements between those referenced are NaN.
+func randStridedCmplx(n, inc int) []complex128 {
+	if inc < 0 {
+		inc = -inc
+	}
+	x := make([]complex128, (n-1)*inc+1)
+	for i := range x {
+		if i%inc == 0 {
+			x[i] = complex(rand.NormFloat64(), rand.NormFloat64())
+		} else {
+			x[i] = cmplx.NaN()
+		}
+	}
+	return x
+}
+
+// zgemvDense returns alpha*a*x + beta*y for the dense matrix a as a tightly
+// packed slice.
+func zgemvDense(a [][]complex128, alpha complex128, x []complex128, incX int, beta complex128, y []complex128, incY int) []complex128 {
+	m := len(a)
+	n := 0
+	if m > 0 {
+		n = len(a[0])
+	}
+	xs := unstrideCmplx(n, x, incX)
+	ys := unstrideCmplx(m, y, incY)
+	want := make([]complex128, m)
+	for i := 0; i < m; i++ {
+		var sum complex128
+		for j := 0; j < n; j++ {
+			sum += a[i][j] * xs[j]
+		}
+		want[i] = alpha * sum
+		if beta != 0 {
+			want[i] += beta * ys[i]
+		}
+	}
+	return want
+}
+
+// unstrideCmplx returns the n logical elements of the vector x with increment inc.
+func unstrideCmplx(n int, x []complex128, inc int) []complex128 {
+	s := make([]complex128, n)
+	ix := 0
+	if inc < 0 {
+		ix = -(n - 1) * inc
+	}
+	for i := range s {
+		s[i] = x[ix]
+		ix += inc
+	}
+	return s
+}
+
+func zTolEqual(a, b complex128) bool {
+	return dTolEqual(real(a), real(b)) && dTolEqual(imag(a), imag(b))
+}
+
+func zStridedSliceTolEqual(n int, a []complex128, inca int, b []complex128, incb int) bool {
+	ia := 0
+	ib := 0
+	if inca <= 0 {
+		ia = -(n - 1) * inca
+	}
+	if incb <= 0 {
+		ib = -(n - 1) * incb
+	}
+	for i := 0; i < n; i++ {
+		if !zTolEqual(a[ia], b[ib]) {
+			return false
+		}
+		ia += inca
+		ib += incb
+	}
+	return true
+}
+
+// zSliceTolNaNEqual returns whether a and b are equal within tolerance,
+// treating NaN elements as equal to each other.
+func zSliceTolNaNEqual(a, b []complex128) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if cmplx.IsNaN(a[i]) && cmplx.IsNaN(b[i]) {
+			continue
+		}
+		if !zTolEqual(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -1,13 +1,22 @@
 package zbw
 
+// Dotu returns the unconjugated dot product \sum_i x[i]*y[i]. Conjugation is
+// selected by calling Dotu or Dotc rather than by a flag, so the variant in
+// use is explicit at the call site.
 func Dotu(x, y Vector) complex128 {
+	must(x.Check())
+	must(y.Check())
 	if x.N != y.N {
 		panic("blas: dimension mismatch")
 	}
 	return impl.Zdotu(x.N, x.Data, x.Inc, y.Data, y.Inc)
 }
 
+// Dotc returns the dot product \sum_i conj(x[i])*y[i], conjugating the
+// elements of x, as the inner product of x and y.
 func Dotc(x, y Vector) complex128 {
+	must(x.Check())
+	must(y.Check())
 	if x.N != y.N {
 		panic("blas: dimension mismatch")
 	}
@@ -147,12 +147,25 @@ func NewVector(v []complex128) Vector {
 	return Vector{v, len(v), 1}
 }
 
+// Slice returns the sub-vector of v holding the elements l through r-1. The
+// result shares Data with v and has the same Inc. For a negative Inc, as in
+// the BLAS, element 0 of a vector is the last one stored in Data, so the
+// returned Data starts at the last element of the sub-vector rather than
+// at element l.
 func (v Vector) Slice(l, r int) Vector {
 	if l < 0 || r > v.N {
 		panic("blas: index out of range")
 	}
-	if r > l {
-		panic(fmt.Sprintf("blas: invalid slice index:", r, ">", l))
+	if l > r {
+		panic(fmt.Sprintf("blas: invalid slice index: %d > %d", l, r))
+	}
+	if l == r {
+		// An empty vector references no data, and the offset of element l
+		// may lie past the end of Data.
+		return Vector{nil, 0, v.Inc}
+	}
+	if v.Inc < 0 {
+		return Vector{v.Data[(v.N-r)*-v.Inc:], r - l, v.Inc}
 	}
 	return Vector{v.Data[l*v.Inc:], r - l, v.Inc}
 }
@@ -164,7 +177,14 @@ func (v Vector) Check() error {
 	if v.Inc == 0 {
 		return errors.New("blas: zero x index increment")
 	}
-	if (v.N-1)*v.Inc >= len(v.Data) {
+	if v.N == 0 {
+		return nil
+	}
+	inc := v.Inc
+	if inc < 0 {
+		inc = -inc
+	}
+	if (v.N-1)*inc >= len(v.Data) {
 		return errors.New("blas: index out of range")
 	}
 	return nil
@@ -0,0 +1,145 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zbw
+
+import (
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/goblas"
+)
+
+// dotImpl provides the complex dot products of goblas, which does not
+// implement the rest of blas.Complex128, for the wrappers under test.
+type dotImpl struct {
+	blas.Complex128
+}
+
+func (dotImpl) Zdotu(n int, x []complex128, incX int, y []complex128, incY int) complex128 {
+	return goblas.Blas{}.Zdotu(n, x, incX, y, incY)
+}
+
+func (dotImpl) Zdotc(n int, x []complex128, incX int, y []complex128, incY int) complex128 {
+	return goblas.Blas{}.Zdotc(n, x, incX, y, incY)
+}
+
+// at returns element i of v, which for a negative Inc is counted from the
+// end of Data.
+func at(v Vector, i int) complex128 {
+	if v.Inc < 0 {
+		return v.Data[(v.N-1-i)*-v.Inc]
+	}
+	return v.Data[i*v.Inc]
+}
+
+func TestVectorSlice(t *testing.T) {
+	data := make([]complex128, 20)
+	for i := range data {
+		data[i] = complex(float64(i), -float64(i))
+	}
+	for _, inc := range []int{1, 2, -1, -3} {
+		n := 6
+		v := Vector{data, n, inc}
+		for l := 0; l <= n; l++ {
+			for r := l; r <= n; r++ {
+				s := v.Slice(l, r)
+				if s.N != r-l || s.Inc != inc {
+					t.Errorf("inc = %v: Slice(%v, %v) has N = %v, Inc = %v", inc, l, r, s.N, s.Inc)
+					continue
+				}
+				if err := s.Check(); err != nil {
+					t.Errorf("inc = %v: Slice(%v, %v) invalid: %v", inc, l, r, err)
+				}
+				for i := 0; i < s.N; i++ {
+					if at(s, i) != at(v, l+i) {
+						t.Errorf("inc = %v: Slice(%v, %v) element %v mismatch", inc, l, r, i)
+					}
+				}
+			}
+		}
+		for _, lr := range [][2]int{{-1, 2}, {0, n + 1}, {3, 2}} {
+			if !panics(func() { v.Slice(lr[0], lr[1]) }) {
+				t.Errorf("inc = %v: Slice(%v, %v) did not panic", inc, lr[0], lr[1])
+			}
+		}
+	}
+
+	// Every slice, including the empty ones, of vectors with no spare Data
+	// after the last element.
+	for _, v := range []Vector{
+		NewGeneral(5, 3, nil).Col(1),
+		{make([]complex128, 16), 6, -3},
+		{make([]complex128, 16), 6, 3},
+	} {
+		for l := 0; l <= v.N; l++ {
+			for r := l; r <= v.N; r++ {
+				var s Vector
+				if panics(func() { s = v.Slice(l, r) }) {
+					t.Errorf("len(Data) = %v, inc = %v: Slice(%v, %v) panicked", len(v.Data), v.Inc, l, r)
+					continue
+				}
+				if s.N != r-l || s.Check() != nil {
+					t.Errorf("len(Data) = %v, inc = %v: Slice(%v, %v) = %+v is invalid", len(v.Data), v.Inc, l, r, s)
+				}
+			}
+		}
+	}
+}
+
+func panics(f func()) (b bool) {
+	defer func() {
+		if recover() != nil {
+			b = true
+		}
+	}()
+	f()
+	return
+}
+
+func TestVectorCheck(t *testing.T) {
+	for i, test := range []struct {
+		v     Vector
+		valid bool
+	}{
+		{Vector{make([]complex128, 4), 4, 1}, true},
+		{Vector{make([]complex128, 10), 4, 3}, true},
+		{Vector{make([]complex128, 10), 4, -3}, true},
+		{Vector{nil, 0, 1}, true},
+		{Vector{nil, 0, -2}, true},
+		{Vector{make([]complex128, 4), 4, 0}, false},
+		{Vector{nil, 0, 0}, false},
+		{Vector{make([]complex128, 4), -1, 1}, false},
+		// Data must hold (N-1)*|Inc|+1 elements.
+		{Vector{make([]complex128, 3), 4, 1}, false},
+		{Vector{make([]complex128, 9), 4, 3}, false},
+		{Vector{make([]complex128, 9), 4, -3}, false},
+	} {
+		err := test.v.Check()
+		if (err == nil) != test.valid {
+			t.Errorf("Case %v: unexpected result: %v", i, err)
+		}
+	}
+}
+
+func TestDot(t *testing.T) {
+	Register(dotImpl{})
+	defer Register(nil)
+
+	x := Vector{[]complex128{1 + 2i, 0, 3 - 1i}, 2, -2}
+	y := NewVector([]complex128{2 - 1i, 1i})
+	// Element 0 of x is 3-1i and element 1 is 1+2i.
+	if got, want := Dotu(x, y), (3-1i)*(2-1i)+(1+2i)*1i; got != want {
+		t.Errorf("Dotu: got %v, want %v", got, want)
+	}
+	if got, want := Dotc(x, y), (3+1i)*(2-1i)+(1-2i)*1i; got != want {
+		t.Errorf("Dotc: got %v, want %v", got, want)
+	}
+	if !panics(func() { Dotu(x, NewVector(make([]complex128, 3))) }) {
+		t.Errorf("Dotu: expected panic for a dimension mismatch")
+	}
+	if !panics(func() { Dotc(Vector{make([]complex128, 2), 2, 2}, y) }) {
+		t.Errorf("Dotc: expected panic for a short vector")
+	}
+}